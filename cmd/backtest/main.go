@@ -0,0 +1,88 @@
+// Binary backtest replays a recorded tick file through the strategy/risk/paper pipeline deterministically.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	ossignal "os/signal"
+	"syscall"
+
+	"memebot-go/internal/backtest"
+	"memebot-go/internal/config"
+	"memebot-go/internal/exchange"
+	"memebot-go/internal/execution"
+	"memebot-go/internal/paper"
+	"memebot-go/internal/risk"
+	"memebot-go/internal/strategy"
+	"memebot-go/internal/util"
+)
+
+func main() {
+	configPath := flag.String("config", "internal/config/config.yaml", "path to YAML configuration")
+	ticksPath := flag.String("ticks", "", "recorded JSONL tick file (defaults to exchange.replay.path)")
+	seed := flag.Int64("seed", 1, "seed for the fill simulator so runs are reproducible")
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	logLevel := flag.String("log", "warn", "log level for pipeline diagnostics")
+	flag.Parse()
+
+	log := util.NewLogger(*logLevel)
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatal().Err(err).Msg("load config")
+	}
+	path := *ticksPath
+	if path == "" {
+		path = cfg.Exchange.Replay.Path
+	}
+	if path == "" {
+		log.Fatal().Msg("no tick file supplied; pass -ticks or set exchange.replay.path")
+	}
+
+	reader, err := exchange.OpenTickFile(path)
+	if err != nil {
+		log.Fatal().Err(err).Str("path", path).Msg("open tick file")
+	}
+	defer reader.Close()
+
+	ctx, cancel := ossignal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	strat, err := strategy.FromConfig(cfg.Strategy)
+	if err != nil {
+		log.Fatal().Err(err).Msg("strategy")
+	}
+	limits := risk.Limits{
+		MaxNotionalPerTrade:  cfg.Risk.MaxNotionalPerTrade,
+		MaxDrawdownPct:       cfg.Risk.KillSwitchDrawdown,
+		IntraTradeDrawdown:   cfg.Risk.KillSwitchDrawdown / 2,
+		MaxDailyLoss:         cfg.Risk.MaxDailyLoss,
+		MaxPortfolioNotional: cfg.Risk.MaxPortfolioNotional,
+	}
+
+	exec := execution.NewExecutor(log)
+	exec.SetConfig(execution.Config{
+		MaxLatencyMs:           cfg.Paper.MaxLatencyMs,
+		SlippageBps:            cfg.Paper.SlippageBps,
+		PartialFillProbability: cfg.Paper.PartialFillProbability,
+		MaxPartialFills:        cfg.Paper.MaxPartialFills,
+	})
+	exec.SetSeed(*seed)
+
+	account := paper.NewAccount(cfg.Paper.StartingCash, cfg.Paper.MaxPositionPerSymbol, cfg.Paper.MaxPositionNotionalUSD)
+
+	report, err := backtest.NewRunner(log, strat, limits, exec, account).Run(ctx, reader)
+	if err != nil {
+		log.Fatal().Err(err).Msg("backtest failed")
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(report)
+		return
+	}
+	_ = report.WriteText(os.Stdout)
+}
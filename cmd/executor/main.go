@@ -1,13 +1,183 @@
-// Binary executor is intentionally left as a stub to avoid accidental live trading during scaffolding.
+// Binary executor runs the trading engine against live market data and routes orders to a real venue (Jupiter on Solana or Binance spot).
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
-	"log"
+	"os"
+	ossignal "os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"memebot-go/internal/cex/binance"
+	"memebot-go/internal/config"
+	dex "memebot-go/internal/dex/solana"
+	"memebot-go/internal/engine"
+	"memebot-go/internal/exchange"
+	"memebot-go/internal/execution"
+	"memebot-go/internal/instrument"
+	"memebot-go/internal/metrics"
+	"memebot-go/internal/paper"
+	"memebot-go/internal/risk"
+	"memebot-go/internal/strategy"
+	"memebot-go/internal/util"
 )
 
 func main() {
-	// Intentionally minimal to avoid accidental live trading during setup.
-	log.Println("executor stub - wire real exchange connectors here")
-	fmt.Print("")
+	configPath := flag.String("config", "internal/config/config.yaml", "path to YAML config")
+	flag.Parse()
+
+	log := util.NewLogger("info")
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatal().Err(err).Msg("load config")
+	}
+	// Real money moves from here on, so require an explicit opt-in.
+	if !cfg.Live.Enabled {
+		log.Fatal().Msg("live trading disabled; set live.enabled to true to run the executor")
+	}
+
+	ctx, cancel := ossignal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	// One registry names every market, so feed ticks, orders, and venue mint lookups share a symbol.
+	instruments := instrument.NewRegistry(log)
+
+	// Build the venue and seed the account from the cash it actually holds.
+	var (
+		venue execution.Venue
+		cash  float64
+	)
+	switch strings.ToLower(cfg.Live.Venue) {
+	case "", execution.JupiterVenueName:
+		venue, cash, err = jupiterVenue(ctx, log, cfg, instruments)
+	case execution.BinanceVenueName:
+		venue, cash, err = binanceVenue(ctx, log, cfg)
+	default:
+		err = fmt.Errorf("unknown live venue %q", cfg.Live.Venue)
+	}
+	if err != nil {
+		log.Fatal().Err(err).Msg("live venue")
+	}
+	log.Info().Str("venue", venue.Name()).Float64("cash", cash).Msg("venue ready")
+
+	srv := metrics.Serve(cfg.App.MetricsAddr)
+	log.Info().Str("addr", cfg.App.MetricsAddr).Msg("metrics up")
+
+	feedOpts := append(exchange.ConfigOptions(cfg.Exchange), exchange.WithInstruments(instruments))
+	if path := cfg.Exchange.RecordPath; path != "" {
+		rec, err := exchange.NewJSONLTickRecorder(log, path)
+		if err != nil {
+			log.Warn().Err(err).Msg("tick recorder disabled")
+		} else {
+			feedOpts = append(feedOpts, exchange.WithTickRecorder(rec))
+			defer rec.Close()
+		}
+	}
+	feed := exchange.NewComposite(cfg.Exchange.Name, cfg.Exchange.Symbols, log, feedOpts...)
+	log.Info().Strs("providers", feed.Providers()).Msg("market data feeds configured")
+
+	strat, err := strategy.FromConfig(cfg.Strategy)
+	if err != nil {
+		log.Fatal().Err(err).Msg("strategy")
+	}
+	log.Info().Str("strategy", strat.Name()).Msg("strategy initialized")
+	limits := risk.Limits{
+		MaxNotionalPerTrade:  cfg.Risk.MaxNotionalPerTrade,
+		MaxDrawdownPct:       cfg.Risk.KillSwitchDrawdown,
+		IntraTradeDrawdown:   cfg.Risk.KillSwitchDrawdown / 2,
+		MaxDailyLoss:         cfg.Risk.MaxDailyLoss,
+		MaxPortfolioNotional: cfg.Risk.MaxPortfolioNotional,
+	}
+
+	account := paper.NewAccount(cash, cfg.Paper.MaxPositionPerSymbol, cfg.Paper.MaxPositionNotionalUSD)
+	staleAfter := time.Duration(cfg.Risk.StaleAfterMs) * time.Millisecond
+	eng := engine.New(log, feed, strat, limits, venue, account, engine.WithStaleAfter(feed, staleAfter, cfg.Risk.FlagStalePositions))
+
+	log.Info().Msg("live engine started")
+	err = eng.Run(ctx)
+	switch {
+	case errors.Is(err, engine.ErrHalted):
+		log.Warn().Err(err).Msg("live engine halted")
+	case err != nil && !errors.Is(err, context.Canceled):
+		log.Error().Err(err).Msg("live engine stopped")
+	}
+	log.Info().Msg("shutting down")
+	srv.Shutdown(context.Background())
+}
+
+// jupiterVenue routes swaps through Jupiter with the wallet loaded from the environment.
+func jupiterVenue(ctx context.Context, log zerolog.Logger, cfg *config.Config, instruments *instrument.Registry) (execution.Venue, float64, error) {
+	owner, err := dex.LoadPrivateKeyFromEnv()
+	if err != nil {
+		return nil, 0, fmt.Errorf("wallet: %w", err)
+	}
+	client := dex.NewJupiterClient(
+		getEnv("SOLANA_RPC_URL", cfg.Dex.RpcURL),
+		getEnv("JUPITER_BASE_URL", cfg.Dex.JupiterBase),
+		owner,
+		getEnv("SOLANA_COMMITMENT", cfg.Dex.Commitment),
+	)
+
+	mapped := make([]execution.JupiterInstrument, 0, len(cfg.Live.Instruments))
+	for _, inst := range cfg.Live.Instruments {
+		mapped = append(mapped, execution.JupiterInstrument{Symbol: inst.Symbol, Mint: inst.Mint, Decimals: inst.Decimals})
+	}
+	venue, err := execution.NewJupiterVenue(log, client, execution.JupiterConfig{
+		SettlementMint:     cfg.Live.SettlementMint,
+		SettlementDecimals: cfg.Live.SettlementDecimals,
+		SlippageBps:        cfg.Live.SlippageBps,
+		ConfirmTimeout:     time.Duration(cfg.Live.ConfirmTimeoutMs) * time.Millisecond,
+		Instruments:        mapped,
+		Registry:           instruments,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	cash, err := venue.SettlementBalance(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("settlement balance: %w", err)
+	}
+	log.Info().Str("wallet", owner.PublicKey().String()).Msg("wallet loaded")
+	return venue, cash, nil
+}
+
+// binanceVenue places spot orders with the exchange credentials, preferring env overrides.
+func binanceVenue(ctx context.Context, log zerolog.Logger, cfg *config.Config) (execution.Venue, float64, error) {
+	apiKey := getEnv("BINANCE_API_KEY", cfg.Exchange.APIKey)
+	apiSecret := getEnv("BINANCE_API_SECRET", cfg.Exchange.APISecret)
+	if apiKey == "" || apiSecret == "" {
+		return nil, 0, errors.New("binance venue requires exchange.api_key and exchange.api_secret")
+	}
+	venue := execution.NewBinanceVenue(log, binance.NewClient(apiKey, apiSecret, cfg.Exchange.Testnet))
+	balances, err := venue.Balances(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	quote := cfg.Live.QuoteAsset
+	if quote == "" {
+		quote = "USDT"
+	}
+	cash := 0.0
+	for _, balance := range balances {
+		if balance.Asset == quote {
+			cash = balance.Free
+		}
+	}
+	log.Info().Bool("testnet", cfg.Exchange.Testnet).Str("quote_asset", quote).Msg("binance account loaded")
+	return venue, cash, nil
+}
+
+// getEnv fetches an environment variable and falls back to a default when unset.
+func getEnv(k, def string) string {
+	if v := os.Getenv(k); v != "" {
+		return v
+	}
+	return def
 }
@@ -4,23 +4,23 @@ package main
 import (
 	"context"
 	"encoding/json"
-	"math"
+	"errors"
 	"net/http"
 	"os"
 	ossignal "os/signal"
-	"strings"
+	"strconv"
 	"syscall"
 	"time"
 
-	"github.com/rs/zerolog"
-
+	"memebot-go/internal/bus"
 	"memebot-go/internal/config"
+	"memebot-go/internal/engine"
 	"memebot-go/internal/exchange"
 	"memebot-go/internal/execution"
+	"memebot-go/internal/instrument"
 	"memebot-go/internal/metrics"
 	"memebot-go/internal/paper"
 	"memebot-go/internal/risk"
-	sig "memebot-go/internal/signal"
 	"memebot-go/internal/strategy"
 	"memebot-go/internal/util"
 )
@@ -44,46 +44,68 @@ func main() {
 	defer cancel()
 
 	// Wire the market data feed and channel fanout the strategy consumes.
-	feedOpts := []exchange.Option{}
-	if cfg.Exchange.DexScreener.PollInterval > 0 {
-		feedOpts = append(feedOpts, exchange.WithPollInterval(time.Duration(cfg.Exchange.DexScreener.PollInterval)*time.Millisecond))
-	}
-	if strings.EqualFold(cfg.Exchange.Name, exchange.ProviderDexScreener) {
-		feedOpts = append(feedOpts, exchange.WithDexScreenerConfig(cfg.Exchange.DexScreener.BaseURL, cfg.Exchange.DexScreener.DefaultChain))
+	// Symbols prefixed with a provider (e.g. "binance:WIFUSDT") get their own child feed; the rest use exchange.name.
+	// One registry names every market, so feeds, discovery, and the account agree on symbols.
+	instruments := instrument.NewRegistry(log)
+	feedOpts := append(exchange.ConfigOptions(cfg.Exchange), exchange.WithInstruments(instruments))
+	if path := cfg.Exchange.RecordPath; path != "" {
+		rec, err := exchange.NewJSONLTickRecorder(log, path)
+		if err != nil {
+			log.Warn().Err(err).Msg("tick recorder disabled")
+		} else {
+			feedOpts = append(feedOpts, exchange.WithTickRecorder(rec))
+			defer rec.Close()
+			log.Info().Str("path", path).Msg("recording ticks")
+		}
 	}
-	feed := exchange.NewFeed(cfg.Exchange.Name, cfg.Exchange.Symbols, log, feedOpts...)
-	ticks := make(chan sig.Tick, 1024)
+	feed := exchange.NewComposite(cfg.Exchange.Name, cfg.Exchange.Symbols, log, feedOpts...)
+	log.Info().Strs("providers", feed.Providers()).Msg("market data feeds configured")
 
-	if strings.EqualFold(cfg.Exchange.Name, exchange.ProviderDexScreener) {
-		if discovery := exchange.NewDexScreenerDiscovery(log, feed, cfg.Exchange.Symbols, cfg.Exchange.DexScreener, cfg.Exchange.Discovery); discovery != nil {
-			discovery.Start(ctx)
-		}
+	// Every tick, bar, signal, order, fill, halt, and universe change is published on the event bus;
+	// extra consumers subscribe there instead of being wired into the trading loop.
+	events := bus.New(log)
+	defer events.Close()
+
+	// Allow/deny lists survive restarts and are edited through /paper/lists.
+	lists, err := exchange.LoadSymbolLists(cfg.Exchange.ListsPath)
+	if err != nil {
+		log.Fatal().Err(err).Msg("load symbol lists")
 	}
 
-	// Kick off feed streaming in the background; cancel the app if the feed errors.
-	go func() {
-		if err := feed.Run(ctx, ticks); err != nil {
-			log.Error().Err(err).Msg("feed stopped")
-			cancel()
+	var discovery *exchange.DexScreenerDiscovery
+	var history *exchange.JSONLUniverseHistory
+	dexFeed := feed.Feed(exchange.ProviderDexScreener)
+	manual := exchange.RouteSymbols(cfg.Exchange.Name, cfg.Exchange.Symbols)[exchange.ProviderDexScreener]
+	if dexFeed != nil {
+		discovery = exchange.NewDexScreenerDiscovery(log, dexFeed, manual, cfg.Exchange.DexScreener, cfg.Exchange.Discovery)
+		discovery.SetLists(lists)
+		if path := cfg.Exchange.Discovery.HistoryPath; path != "" {
+			history, err = exchange.NewJSONLUniverseHistory(log, path)
+			if err != nil {
+				log.Warn().Err(err).Msg("universe history disabled")
+			} else {
+				discovery.SetHistory(history)
+				defer history.Close()
+				log.Info().Str("path", path).Msg("recording universe history")
+			}
 		}
-	}()
+		discovery.OnChange(func(current, previous []string) {
+			events.Publish(bus.NewUniverseEvent(current, previous))
+		})
+	}
 
-	// Instantiate strategy, risk checks, executor, mark storage, and paper account state.
-	strategyParams := strategy.Params{
-		OBILevels:         cfg.Strategy.Params.OBILevels,
-		OBIThreshold:      cfg.Strategy.Params.OBIThreshold,
-		VolWindowSecs:     cfg.Strategy.Params.VolWindowSecs,
-		TrendThreshold:    cfg.Strategy.Params.TrendThreshold,
-		TrendWindowSecs:   cfg.Strategy.Params.TrendWindowSecs,
-		TrendMinVolumeUSD: cfg.Strategy.Params.TrendMinVolumeUSD,
+	// Instantiate strategy, risk checks, executor, and paper account state.
+	strat, err := strategy.FromConfig(cfg.Strategy)
+	if err != nil {
+		log.Fatal().Err(err).Msg("strategy")
 	}
-	strat := strategy.Build(cfg.Strategy.Mode, strategyParams)
 	log.Info().Str("strategy", strat.Name()).Msg("strategy initialized")
 	limits := risk.Limits{
-		MaxNotionalPerTrade: cfg.Risk.MaxNotionalPerTrade,
-		MaxDrawdownPct:      cfg.Risk.KillSwitchDrawdown,
-		IntraTradeDrawdown:  cfg.Risk.KillSwitchDrawdown / 2,
-		MaxDailyLoss:        cfg.Risk.MaxDailyLoss,
+		MaxNotionalPerTrade:  cfg.Risk.MaxNotionalPerTrade,
+		MaxDrawdownPct:       cfg.Risk.KillSwitchDrawdown,
+		IntraTradeDrawdown:   cfg.Risk.KillSwitchDrawdown / 2,
+		MaxDailyLoss:         cfg.Risk.MaxDailyLoss,
+		MaxPortfolioNotional: cfg.Risk.MaxPortfolioNotional,
 	}
 
 	exec := execution.NewExecutor(log)
@@ -93,21 +115,46 @@ func main() {
 		PartialFillProbability: cfg.Paper.PartialFillProbability,
 		MaxPartialFills:        cfg.Paper.MaxPartialFills,
 	})
+	exec.SetBalance(execution.PaperQuoteAsset, cfg.Paper.StartingCash)
 
 	account := paper.NewAccount(cfg.Paper.StartingCash, cfg.Paper.MaxPositionPerSymbol, cfg.Paper.MaxPositionNotionalUSD)
-	marks := make(map[string]float64, len(cfg.Exchange.Symbols))
 	ledger := paper.NewLedger(2048)
+	staleAfter := time.Duration(cfg.Risk.StaleAfterMs) * time.Millisecond
+	engineOpts := []engine.Option{
+		engine.WithLedger(ledger),
+		engine.WithStaleAfter(feed, staleAfter, cfg.Risk.FlagStalePositions),
+		engine.WithObserver(bus.NewObserver(events)),
+	}
 
-	var recorder paper.FillRecorder
 	if path := cfg.Paper.FillsPath; path != "" {
 		rec, err := paper.NewJSONLRecorder(path)
 		if err != nil {
 			log.Warn().Err(err).Msg("paper recorder disabled")
 		} else {
-			recorder = rec
+			engineOpts = append(engineOpts, engine.WithFillRecorder(rec))
 			defer rec.Close()
 		}
 	}
+	eng := engine.New(log, bus.NewTap(feed, events), strat, limits, exec, account, engineOpts...)
+	// Discovery must keep polling anything we hold or are trading, or its mark would freeze.
+	discovery.PinHeld(eng)
+	if dexFeed != nil {
+		// Denied symbols stop being polled once flat, so open positions keep their marks.
+		dexFeed.SetSymbolFilter(exchange.DenyFilter(lists, instruments, eng))
+		lists.OnChange(func() {
+			if discovery == nil {
+				dexFeed.SetSymbols(manual)
+				return
+			}
+			go func() {
+				if err := discovery.Refresh(ctx); err != nil {
+					log.Warn().Err(err).Msg("discovery refresh after list change failed")
+				}
+			}()
+		})
+		dexFeed.SetSymbols(manual)
+	}
+	discovery.Start(ctx)
 
 	// Expose ledger snapshots at /paper/fills for testers.
 	mux := http.NewServeMux()
@@ -117,262 +164,155 @@ func main() {
 		_ = json.NewEncoder(w).Encode(fills)
 	})
 	mux.HandleFunc("/paper/account", func(w http.ResponseWriter, r *http.Request) {
-		snap := account.Snapshot(marks)
+		snap := eng.Snapshot()
 		w.Header().Set("Content-Type", "application/json")
 		_ = json.NewEncoder(w).Encode(snap)
 	})
-	go func() {
-		log.Info().Str("addr", ":8081").Msg("paper HTTP API up")
-		_ = http.ListenAndServe(":8081", mux)
-	}()
-
-	peakEquity := cfg.Paper.StartingCash
-	halted := false
-	terminate := func(reason string) {
-		if halted {
-			return
+	mux.HandleFunc("/paper/feed", func(w http.ResponseWriter, r *http.Request) {
+		type symbolAge struct {
+			Provider   string    `json:"provider"`
+			Symbol     string    `json:"symbol"`
+			LastTick   time.Time `json:"last_tick"`
+			AgeSeconds float64   `json:"age_seconds"`
+			Stale      bool      `json:"stale"`
 		}
-		halted = true
-		log.Warn().Str("reason", reason).Msg("risk limit triggered; flattening positions and pausing trading")
-		flattenPositions(exec, account, marks, ledger, recorder, log)
-		snap := account.Snapshot(marks)
-		metrics.PaperEquity.Set(snap.Equity)
-		for sym := range marks {
-			metrics.PaperPositions.WithLabelValues(sym).Set(0)
+		ages := feed.TickAges(time.Now())
+		out := struct {
+			Symbols        []symbolAge `json:"symbols"`
+			StalePositions []string    `json:"stale_positions"`
+		}{Symbols: make([]symbolAge, 0, len(ages)), StalePositions: eng.StalePositions()}
+		for _, age := range ages {
+			out.Symbols = append(out.Symbols, symbolAge{
+				Provider:   age.Provider,
+				Symbol:     age.Symbol,
+				LastTick:   age.LastTick,
+				AgeSeconds: age.Age.Seconds(),
+				Stale:      staleAfter > 0 && age.Age > staleAfter,
+			})
 		}
-		for sym, pos := range snap.Positions {
-			metrics.PaperPositions.WithLabelValues(sym).Set(pos.Qty)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(out)
+	})
+	// The polled universe with each symbol's origin: manual, discovered, pinned, held, or min_residency.
+	mux.HandleFunc("GET /paper/universe", func(w http.ResponseWriter, r *http.Request) {
+		universe := discovery.Universe()
+		if discovery == nil {
+			for _, sym := range cfg.Exchange.Symbols {
+				universe = append(universe, exchange.UniverseEntry{Symbol: sym, Origin: exchange.OriginManual})
+			}
 		}
-		peakEquity = snap.Equity
-	}
-
-	log.Info().Msg("paper engine started")
-	for {
-		select {
-		case <-ctx.Done():
-			log.Info().Msg("shutting down")
-			srv.Shutdown(context.Background())
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(universe)
+	})
+	// Query recorded universe changes: ?symbol=<symbol or pair address>&action=added|removed&since=&until= (RFC 3339)&limit=N.
+	mux.HandleFunc("GET /paper/universe/history", func(w http.ResponseWriter, r *http.Request) {
+		if history == nil {
+			http.Error(w, "universe history disabled (exchange.discovery.history_path)", http.StatusNotFound)
 			return
-		case tk := <-ticks:
-			if tk.Price <= 0 {
-				continue
-			}
-			marks[tk.Symbol] = tk.Price
-			if halted {
-				continue
-			}
-
-			// Check drawdowns before trading.
-			currentSnap := account.Snapshot(marks)
-			if currentSnap.Equity > peakEquity {
-				peakEquity = currentSnap.Equity
-			}
-			if limits.DailyLossBreached(account.RealizedPnL()) {
-				terminate("daily loss limit reached")
-				return
-			}
-			if limits.Breached(account.StartingCash(), currentSnap.Equity) {
-				terminate("drawdown limit reached")
-				return
-			}
-			if limits.IntraTradeBreached(peakEquity, currentSnap.Equity) {
-				terminate("intratrade drawdown reached")
-				return
-			}
-
-			// Strategy -> Signal
-			sig := strat.OnTick(tk)
-			if sig == nil {
-				continue
-			}
-
-			side := execution.Buy
-			if sig.Score < 0 {
-				side = execution.Sell
-			}
-
-			var qty float64
-			switch side {
-			case execution.Buy:
-				cashBudget := account.AvailableCash()
-				if cashBudget <= 0 {
-					log.Warn().Msg("paper account out of cash; waiting for positions to unwind")
-					continue
-				}
-				notional := cfg.Risk.MaxNotionalPerTrade
-				if notional <= 0 {
-					notional = cashBudget
-				} else {
-					notional = math.Min(notional, cashBudget)
-				}
-				if notional <= 0 {
-					continue
-				}
-				qty = notional / tk.Price
-				capacity := account.MaxAdditionalLong(tk.Symbol, tk.Price)
-				if capacity <= 0 {
-					log.Debug().Str("symbol", tk.Symbol).Msg("position cap reached; skipping buy")
-					continue
-				}
-				qty = math.Min(qty, capacity)
-			case execution.Sell:
-				qty = account.Position(tk.Symbol)
-			}
-
-			if qty <= 0 {
-				continue
-			}
-
-			if side == execution.Buy && limits.MaxPortfolioNotional > 0 {
-				grossBefore, _ := risk.Exposure(extractQtys(currentSnap.Positions), marks)
-				projected := grossBefore + qty*tk.Price
-				if limits.PortfolioBreached(grossBefore, projected) {
-					log.Debug().Float64("projected", projected).Float64("limit", limits.MaxPortfolioNotional).Str("symbol", tk.Symbol).Msg("portfolio notional limit reached; skipping buy")
-					continue
-				}
-			}
-
-			order := execution.Order{
-				Symbol: tk.Symbol,
-				Side:   side,
-				Qty:    qty,
-				Price:  tk.Price,
-			}
-
-			notional := order.Qty * order.Price
-			if side == execution.Buy && !limits.Allow(notional) {
-				log.Warn().Str("symbol", order.Symbol).Msg("risk rejected order over notional limit")
-				continue
-			}
-
-			fills, err := exec.Submit(order)
-			if err != nil {
-				log.Error().Err(err).Str("symbol", order.Symbol).Msg("executor submit failed")
-				continue
-			}
-
-			var totalFilled float64
-			for _, fill := range fills {
-				price := fill.Price
-				if price <= 0 {
-					price = order.Price
-				}
-				if err := account.MarketFill(order.Symbol, order.Side, fill.Qty, price); err != nil {
-					log.Warn().Err(err).Str("symbol", order.Symbol).Msg("paper fill rejected")
-					continue
-				}
-				totalFilled += fill.Qty
-				ledger.Record(fill)
-				if recorder != nil {
-					recorder.Record(fill)
+		}
+		params := r.URL.Query()
+		query := exchange.UniverseQuery{Symbol: params.Get("symbol"), Action: params.Get("action")}
+		var err error
+		for name, dst := range map[string]*time.Time{"since": &query.Since, "until": &query.Until} {
+			if raw := params.Get(name); raw != "" {
+				if *dst, err = time.Parse(time.RFC3339, raw); err != nil {
+					http.Error(w, "bad "+name+": "+err.Error(), http.StatusBadRequest)
+					return
 				}
 			}
-			if totalFilled <= 0 {
-				continue
-			}
-
-			snap := account.Snapshot(marks)
-			metrics.PaperEquity.Set(snap.Equity)
-			for sym, pos := range snap.Positions {
-				metrics.PaperPositions.WithLabelValues(sym).Set(pos.Qty)
-			}
-			if _, ok := snap.Positions[order.Symbol]; !ok {
-				metrics.PaperPositions.WithLabelValues(order.Symbol).Set(0)
-			}
-
-			gross, net := risk.Exposure(extractQtys(snap.Positions), marks)
-			unrealized := aggregateUnrealized(snap.Positions)
-			logEvent := log.Info().Str("symbol", order.Symbol).
-				Str("side", string(order.Side)).
-				Float64("qty", totalFilled).
-				Float64("signal_score", sig.Score).
-				Float64("cash", snap.Cash).
-				Float64("equity", snap.Equity).
-				Float64("realized", snap.RealizedPnL).
-				Float64("gross_exposure", gross).
-				Float64("net_exposure", net).
-				Float64("unrealized", unrealized)
-			if pos, ok := snap.Positions[order.Symbol]; ok {
-				logEvent = logEvent.Float64("position", pos.Qty).Float64("avg_cost", pos.AvgCost)
-			} else {
-				logEvent = logEvent.Float64("position", 0).Float64("avg_cost", 0)
-			}
-			logEvent.Msg("paper fills processed")
-
-			if snap.Equity > peakEquity {
-				peakEquity = snap.Equity
-			}
-			if limits.Breached(account.StartingCash(), snap.Equity) {
-				terminate("drawdown limit reached after fill")
-				return
-			}
-			if limits.DailyLossBreached(account.RealizedPnL()) {
-				terminate("daily loss limit reached after fill")
+		}
+		if raw := params.Get("limit"); raw != "" {
+			if query.Limit, err = strconv.Atoi(raw); err != nil {
+				http.Error(w, "bad limit: "+err.Error(), http.StatusBadRequest)
 				return
 			}
-			currentSnap = snap
 		}
-	}
-}
-
-func extractQtys(pos map[string]paper.PositionSnapshot) map[string]float64 {
-	out := make(map[string]float64, len(pos))
-	for sym, snapshot := range pos {
-		out[sym] = snapshot.Qty
-	}
-	return out
-}
-
-func aggregateUnrealized(pos map[string]paper.PositionSnapshot) float64 {
-	total := 0.0
-	for _, snapshot := range pos {
-		total += snapshot.Unrealized
-	}
-	return total
-}
-
-func flattenPositions(exec *execution.Executor, account *paper.Account, marks map[string]float64, ledger *paper.Ledger, recorder paper.FillRecorder, log zerolog.Logger) {
-	snap := account.Snapshot(marks)
-	for sym, pos := range snap.Positions {
-		qty := pos.Qty
-		if math.Abs(qty) <= 1e-9 {
-			continue
-		}
-		side := execution.Sell
-		if qty < 0 {
-			side = execution.Buy
-			qty = -qty
+		changes, err := history.Query(query)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-		price := marks[sym]
-		if price <= 0 {
-			price = pos.AvgCost
-			if price <= 0 {
-				price = 1
-			}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(changes)
+	})
+	mux.HandleFunc("GET /paper/instruments", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(instruments.All())
+	})
+	mux.HandleFunc("GET /paper/lists", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string][]exchange.ListEntry{
+			exchange.ListAllow: lists.Entries(exchange.ListAllow),
+			exchange.ListDeny:  lists.Entries(exchange.ListDeny),
+		})
+	})
+	// POST a {"key", "chain", "note"} body to allow or deny a pair address or token mint.
+	mux.HandleFunc("POST /paper/lists/{list}", func(w http.ResponseWriter, r *http.Request) {
+		var entry exchange.ListEntry
+		if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+			http.Error(w, "decode list entry: "+err.Error(), http.StatusBadRequest)
+			return
 		}
-		order := execution.Order{Symbol: sym, Side: side, Qty: qty, Price: price}
-		fills, err := exec.Submit(order)
+		entry, err := lists.Add(r.PathValue("list"), entry)
 		if err != nil {
-			log.Warn().Err(err).Str("symbol", sym).Msg("flatten submit failed")
-			continue
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		for _, fill := range fills {
-			px := fill.Price
-			if px <= 0 {
-				px = order.Price
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(entry)
+	})
+	mux.HandleFunc("DELETE /paper/lists/{list}/{key}", func(w http.ResponseWriter, r *http.Request) {
+		removed, err := lists.Remove(r.PathValue("list"), r.PathValue("key"))
+		switch {
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case !removed:
+			http.Error(w, "no such list entry", http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	// Stream bus events as newline-delimited JSON; slow clients lose the oldest events rather than stall trading.
+	mux.HandleFunc("/paper/events", func(w http.ResponseWriter, r *http.Request) {
+		var kinds []bus.Kind
+		for _, kind := range r.URL.Query()["kind"] {
+			kinds = append(kinds, bus.Kind(kind))
+		}
+		// Clients share one subscriber name so per-connection addresses do not mint new metric series.
+		sub := events.Subscribe("http", 256, bus.DropOldest, kinds...)
+		defer sub.Close()
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		flusher, _ := w.(http.Flusher)
+		enc := json.NewEncoder(w)
+		for {
+			ev, err := sub.Recv(r.Context())
+			if err != nil {
+				return
 			}
-			if err := account.MarketFill(sym, side, fill.Qty, px); err != nil {
-				log.Warn().Err(err).Str("symbol", sym).Msg("flatten fill rejected")
-				continue
+			if err := enc.Encode(struct {
+				Kind  bus.Kind  `json:"kind"`
+				Event bus.Event `json:"event"`
+			}{ev.Kind(), ev}); err != nil {
+				return
 			}
-			fill.Price = px
-			fill.Side = side
-			fill.Symbol = sym
-			ledger.Record(fill)
-			if recorder != nil {
-				recorder.Record(fill)
+			if flusher != nil {
+				flusher.Flush()
 			}
 		}
-		marks[sym] = price
+	})
+	go func() {
+		log.Info().Str("addr", ":8081").Msg("paper HTTP API up")
+		_ = http.ListenAndServe(":8081", mux)
+	}()
+
+	log.Info().Msg("paper engine started")
+	err = eng.Run(ctx)
+	switch {
+	case errors.Is(err, engine.ErrHalted):
+		log.Warn().Err(err).Msg("paper engine halted")
+	case err != nil && !errors.Is(err, context.Canceled):
+		log.Error().Err(err).Msg("paper engine stopped")
 	}
+	log.Info().Msg("shutting down")
+	srv.Shutdown(context.Background())
 }
@@ -0,0 +1,136 @@
+// Package backtest drives the trading pipeline over recorded ticks using simulated time.
+package backtest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"memebot-go/internal/engine"
+	"memebot-go/internal/execution"
+	"memebot-go/internal/paper"
+	"memebot-go/internal/risk"
+	"memebot-go/internal/signal"
+	"memebot-go/internal/strategy"
+)
+
+// TickSource yields historical ticks in order, returning io.EOF once exhausted.
+type TickSource interface {
+	Next() (signal.Tick, error)
+}
+
+// Runner replays ticks through the shared trading engine with a simulated clock.
+type Runner struct {
+	engine  *engine.Engine
+	account *paper.Account
+	clock   time.Time
+	report  *Report
+}
+
+// NewRunner wires the pipeline components; the executor clock is bound to simulated tick time.
+func NewRunner(log zerolog.Logger, strat strategy.Strategy, limits risk.Limits, exec *execution.Executor, account *paper.Account) *Runner {
+	r := &Runner{
+		account: account,
+		report:  newReport(strat.Name(), account.StartingCash()),
+	}
+	exec.SetClock(func() time.Time { return r.clock })
+	r.engine = engine.New(log, nil, strat, limits, exec, account, engine.WithObserver(r))
+	return r
+}
+
+// Run consumes the tick source to completion and returns the resulting report.
+func (r *Runner) Run(ctx context.Context, src TickSource) (*Report, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		tk, err := src.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		r.onTick(ctx, tk)
+	}
+	r.finish()
+	return r.report, nil
+}
+
+func (r *Runner) onTick(ctx context.Context, tk signal.Tick) {
+	if tk.Price <= 0 {
+		return
+	}
+	if tk.Ts.After(r.clock) {
+		r.clock = tk.Ts
+	}
+	if r.report.Start.IsZero() {
+		r.report.Start = r.clock
+	}
+	r.report.End = r.clock
+	r.report.Ticks++
+
+	r.engine.Process(ctx, tk)
+	r.observeEquity(r.engine.Snapshot().Equity)
+}
+
+// OnSignal counts strategy signals.
+func (r *Runner) OnSignal(signal.Signal) { r.report.Signals++ }
+
+// OnFill attributes fill volume and realized PnL to the traded symbol.
+func (r *Runner) OnFill(order execution.Order, fill execution.Fill, realizedPnL float64) {
+	r.report.Fills++
+	sym := r.report.symbol(order.Symbol)
+	sym.Fills++
+	sym.VolumeUSD += fill.Qty * fill.Price
+	sym.RealizedPnL += realizedPnL
+}
+
+// OnOrder counts orders that produced at least one accepted fill.
+func (r *Runner) OnOrder(order execution.Order, filledQty float64) {
+	if filledQty <= 0 {
+		return
+	}
+	r.report.Trades++
+	sym := r.report.symbol(order.Symbol)
+	sym.Trades++
+	if order.Side == execution.Buy {
+		sym.Buys++
+	} else {
+		sym.Sells++
+	}
+}
+
+// OnHalt records the kill switch reason.
+func (r *Runner) OnHalt(reason string) {
+	if r.report.HaltReason == "" {
+		r.report.HaltReason = reason
+	}
+}
+
+func (r *Runner) observeEquity(equity float64) {
+	if equity > r.report.PeakEquity {
+		r.report.PeakEquity = equity
+	}
+	if r.report.PeakEquity > 0 {
+		if dd := (r.report.PeakEquity - equity) / r.report.PeakEquity; dd > r.report.MaxDrawdownPct {
+			r.report.MaxDrawdownPct = dd
+		}
+	}
+}
+
+func (r *Runner) finish() {
+	snap := r.engine.Snapshot()
+	r.report.FinalEquity = snap.Equity
+	r.report.Cash = snap.Cash
+	r.report.RealizedPnL = snap.RealizedPnL
+	for sym, pos := range snap.Positions {
+		entry := r.report.symbol(sym)
+		entry.Position = pos.Qty
+		entry.UnrealizedPnL = pos.Unrealized
+		r.report.UnrealizedPnL += pos.Unrealized
+	}
+}
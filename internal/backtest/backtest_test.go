@@ -0,0 +1,105 @@
+package backtest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"memebot-go/internal/execution"
+	"memebot-go/internal/paper"
+	"memebot-go/internal/risk"
+	"memebot-go/internal/signal"
+	"memebot-go/internal/strategy"
+)
+
+type sliceSource struct {
+	ticks []signal.Tick
+	idx   int
+}
+
+func (s *sliceSource) Next() (signal.Tick, error) {
+	if s.idx >= len(s.ticks) {
+		return signal.Tick{}, io.EOF
+	}
+	tk := s.ticks[s.idx]
+	s.idx++
+	return tk, nil
+}
+
+func pumpAndDump(start time.Time) []signal.Tick {
+	var ticks []signal.Tick
+	px := 1.0
+	for i := 0; i < 40; i++ {
+		side := 1
+		if i >= 20 {
+			side = -1
+			px *= 0.97
+		} else {
+			px *= 1.02
+		}
+		ticks = append(ticks, signal.Tick{Symbol: "WIFSOL", Price: px, Size: 500, Side: side, Ts: start.Add(time.Duration(i) * 10 * time.Second)})
+	}
+	return ticks
+}
+
+func runOnce(t *testing.T, ticks []signal.Tick, limits risk.Limits) *Report {
+	t.Helper()
+	exec := execution.NewExecutor(zerolog.Nop())
+	exec.SetConfig(execution.Config{MaxLatencyMs: 20, SlippageBps: 5, PartialFillProbability: 0.5, MaxPartialFills: 3})
+	exec.SetSeed(7)
+	account := paper.NewAccount(1000, 0, 200)
+	strat := strategy.NewTrendFollower(0.03, 60, 10)
+	report, err := NewRunner(zerolog.Nop(), strat, limits, exec, account).Run(context.Background(), &sliceSource{ticks: ticks})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	return report
+}
+
+func TestRunnerProducesDeterministicReport(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	limits := risk.Limits{MaxNotionalPerTrade: 50}
+	first := runOnce(t, pumpAndDump(start), limits)
+	second := runOnce(t, pumpAndDump(start), limits)
+
+	if first.Trades == 0 {
+		t.Fatalf("expected trades in report: %+v", first)
+	}
+	if first.FinalEquity != second.FinalEquity || first.RealizedPnL != second.RealizedPnL || first.Fills != second.Fills {
+		t.Fatalf("expected identical runs, got %+v vs %+v", first, second)
+	}
+	if !first.Start.Equal(start) || !first.End.Equal(start.Add(390*time.Second)) {
+		t.Fatalf("expected simulated time bounds, got %v -> %v", first.Start, first.End)
+	}
+	sym := first.Symbols["WIFSOL"]
+	if sym == nil || sym.Buys == 0 || sym.Sells == 0 {
+		t.Fatalf("expected buys and sells for WIFSOL, got %+v", sym)
+	}
+	if first.MaxDrawdownPct <= 0 {
+		t.Fatalf("expected drawdown to be tracked")
+	}
+
+	var buf bytes.Buffer
+	if err := first.WriteText(&buf); err != nil {
+		t.Fatalf("WriteText error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "WIFSOL") || !strings.Contains(buf.String(), "max drawdown") {
+		t.Fatalf("unexpected report output: %s", buf.String())
+	}
+}
+
+func TestRunnerHaltsOnDrawdown(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	report := runOnce(t, pumpAndDump(start), risk.Limits{MaxNotionalPerTrade: 200, IntraTradeDrawdown: 0.01})
+	if report.HaltReason == "" {
+		t.Fatalf("expected kill switch to trigger")
+	}
+	if pos := report.Symbols["WIFSOL"]; pos != nil && pos.Position != 0 {
+		t.Fatalf("expected positions flattened after halt, got %.4f", pos.Position)
+	}
+}
@@ -0,0 +1,105 @@
+package backtest
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// Report summarises a completed backtest run.
+type Report struct {
+	Strategy       string                   `json:"strategy"`
+	Start          time.Time                `json:"start"`
+	End            time.Time                `json:"end"`
+	Ticks          int                      `json:"ticks"`
+	Signals        int                      `json:"signals"`
+	Trades         int                      `json:"trades"`
+	Fills          int                      `json:"fills"`
+	StartingCash   float64                  `json:"starting_cash"`
+	Cash           float64                  `json:"cash"`
+	FinalEquity    float64                  `json:"final_equity"`
+	PeakEquity     float64                  `json:"peak_equity"`
+	RealizedPnL    float64                  `json:"realized_pnl"`
+	UnrealizedPnL  float64                  `json:"unrealized_pnl"`
+	MaxDrawdownPct float64                  `json:"max_drawdown_pct"`
+	HaltReason     string                   `json:"halt_reason,omitempty"`
+	Symbols        map[string]*SymbolReport `json:"symbols"`
+}
+
+// SymbolReport breaks trading activity down per symbol.
+type SymbolReport struct {
+	Trades        int     `json:"trades"`
+	Buys          int     `json:"buys"`
+	Sells         int     `json:"sells"`
+	Fills         int     `json:"fills"`
+	VolumeUSD     float64 `json:"volume_usd"`
+	RealizedPnL   float64 `json:"realized_pnl"`
+	UnrealizedPnL float64 `json:"unrealized_pnl"`
+	Position      float64 `json:"position"`
+}
+
+func newReport(strategy string, startingCash float64) *Report {
+	return &Report{
+		Strategy:     strategy,
+		StartingCash: startingCash,
+		PeakEquity:   startingCash,
+		Symbols:      make(map[string]*SymbolReport),
+	}
+}
+
+func (r *Report) symbol(sym string) *SymbolReport {
+	entry := r.Symbols[sym]
+	if entry == nil {
+		entry = &SymbolReport{}
+		r.Symbols[sym] = entry
+	}
+	return entry
+}
+
+// ReturnPct reports total return relative to the starting bankroll.
+func (r *Report) ReturnPct() float64 {
+	if r.StartingCash <= 0 {
+		return 0
+	}
+	return (r.FinalEquity - r.StartingCash) / r.StartingCash
+}
+
+// WriteText renders a human-readable summary followed by a per-symbol table.
+func (r *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "strategy\t%s\n", r.Strategy)
+	fmt.Fprintf(tw, "period\t%s -> %s (%s)\n", r.Start.Format(time.RFC3339), r.End.Format(time.RFC3339), r.End.Sub(r.Start))
+	fmt.Fprintf(tw, "ticks / signals\t%d / %d\n", r.Ticks, r.Signals)
+	fmt.Fprintf(tw, "trades / fills\t%d / %d\n", r.Trades, r.Fills)
+	fmt.Fprintf(tw, "starting cash\t%.2f\n", r.StartingCash)
+	fmt.Fprintf(tw, "final equity\t%.2f (%.2f%%)\n", r.FinalEquity, r.ReturnPct()*100)
+	fmt.Fprintf(tw, "realized pnl\t%.2f\n", r.RealizedPnL)
+	fmt.Fprintf(tw, "unrealized pnl\t%.2f\n", r.UnrealizedPnL)
+	fmt.Fprintf(tw, "max drawdown\t%.2f%%\n", r.MaxDrawdownPct*100)
+	if r.HaltReason != "" {
+		fmt.Fprintf(tw, "halted\t%s\n", r.HaltReason)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if len(r.Symbols) == 0 {
+		return nil
+	}
+
+	symbols := make([]string, 0, len(r.Symbols))
+	for sym := range r.Symbols {
+		symbols = append(symbols, sym)
+	}
+	sort.Strings(symbols)
+
+	fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "symbol\ttrades\tbuys\tsells\tvolume\trealized\tunrealized\tposition\t")
+	for _, sym := range symbols {
+		s := r.Symbols[sym]
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%.2f\t%.2f\t%.2f\t%.6f\t\n", sym, s.Trades, s.Buys, s.Sells, s.VolumeUSD, s.RealizedPnL, s.UnrealizedPnL, s.Position)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,133 @@
+// Package bars aggregates ticks into per-symbol OHLCV bars across one or more timeframes.
+package bars
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"memebot-go/internal/signal"
+)
+
+// Builder folds ticks into open bars and hands back the bars each tick closes.
+// Bars close on tick time rather than wall time, so replays and backtests aggregate identically;
+// intervals without ticks produce no bar. Each provider keeps its own clock, so a provider whose
+// timestamps run ahead (exchange time vs receipt time, or a replay) cannot close another's bars early.
+type Builder struct {
+	timeframes []time.Duration
+	mu         sync.Mutex
+	open       map[barKey]*signal.Bar
+	latest     map[string]time.Time // newest tick time per provider
+}
+
+type barKey struct {
+	symbol    string
+	timeframe time.Duration
+}
+
+// NewBuilder creates a builder for the given timeframes; duplicates and non-positive values are dropped.
+func NewBuilder(timeframes ...time.Duration) *Builder {
+	seen := make(map[time.Duration]struct{}, len(timeframes))
+	b := &Builder{open: make(map[barKey]*signal.Bar), latest: make(map[string]time.Time)}
+	for _, tf := range timeframes {
+		if _, dup := seen[tf]; dup || tf <= 0 {
+			continue
+		}
+		seen[tf] = struct{}{}
+		b.timeframes = append(b.timeframes, tf)
+	}
+	sort.Slice(b.timeframes, func(i, j int) bool { return b.timeframes[i] < b.timeframes[j] })
+	return b
+}
+
+// Timeframes lists the configured timeframes in ascending order.
+func (b *Builder) Timeframes() []time.Duration {
+	return append([]time.Duration(nil), b.timeframes...)
+}
+
+// Update adds a tick and returns every bar that is now closed: the tick's own symbol when it starts a new
+// window, plus any other symbol from the same provider whose window ended before the tick's timestamp.
+// Book snapshots and ticks older than the symbol's open bar are ignored.
+func (b *Builder) Update(tk signal.Tick) []signal.Bar {
+	if tk.Symbol == "" || tk.Price <= 0 || tk.Book != nil || tk.Ts.IsZero() {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var closed []signal.Bar
+	for _, tf := range b.timeframes {
+		key := barKey{symbol: tk.Symbol, timeframe: tf}
+		start := tk.Ts.Truncate(tf)
+		bar := b.open[key]
+		if bar != nil && start.Before(bar.Start) {
+			continue
+		}
+		if bar != nil && start.After(bar.Start) {
+			closed = append(closed, *bar)
+			bar = nil
+		}
+		if bar == nil {
+			bar = &signal.Bar{Provider: tk.Provider, Symbol: tk.Symbol, Timeframe: tf, Start: start, Open: tk.Price, High: tk.Price, Low: tk.Price}
+			b.open[key] = bar
+		}
+		apply(bar, tk)
+	}
+	if tk.Ts.After(b.latest[tk.Provider]) {
+		b.latest[tk.Provider] = tk.Ts
+	}
+	closed = append(closed, b.flushLocked(b.latest[tk.Provider], func(bar *signal.Bar) bool { return bar.Provider == tk.Provider })...)
+	sortBars(closed)
+	return closed
+}
+
+// Flush closes and returns every open bar, from any provider, whose window ended at or before now.
+func (b *Builder) Flush(now time.Time) []signal.Bar {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	closed := b.flushLocked(now, nil)
+	sortBars(closed)
+	return closed
+}
+
+// flushLocked closes the open bars that ended at or before now and match, or all of them when match is nil.
+func (b *Builder) flushLocked(now time.Time, match func(*signal.Bar) bool) []signal.Bar {
+	var closed []signal.Bar
+	for key, bar := range b.open {
+		if !bar.End().After(now) && (match == nil || match(bar)) {
+			closed = append(closed, *bar)
+			delete(b.open, key)
+		}
+	}
+	return closed
+}
+
+func apply(bar *signal.Bar, tk signal.Tick) {
+	bar.High = max(bar.High, tk.Price)
+	bar.Low = min(bar.Low, tk.Price)
+	bar.Close = tk.Price
+	if tk.Size <= 0 {
+		return
+	}
+	bar.Volume += tk.Size
+	bar.Trades += max(1, tk.Trades)
+	switch {
+	case tk.Side > 0:
+		bar.BuyVolume += tk.Size
+	case tk.Side < 0:
+		bar.SellVolume += tk.Size
+	}
+}
+
+// sortBars orders closed bars by end time, then timeframe, then symbol, so consumers see them chronologically.
+func sortBars(out []signal.Bar) {
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].End().Equal(out[j].End()) {
+			return out[i].End().Before(out[j].End())
+		}
+		if out[i].Timeframe != out[j].Timeframe {
+			return out[i].Timeframe < out[j].Timeframe
+		}
+		return out[i].Symbol < out[j].Symbol
+	})
+}
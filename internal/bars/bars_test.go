@@ -0,0 +1,80 @@
+package bars
+
+import (
+	"testing"
+	"time"
+
+	"memebot-go/internal/signal"
+)
+
+func TestBuilderAggregatesOHLCV(t *testing.T) {
+	b := NewBuilder(time.Minute, time.Second, time.Minute, 0)
+	if got := b.Timeframes(); len(got) != 2 || got[0] != time.Second || got[1] != time.Minute {
+		t.Fatalf("unexpected timeframes %v", got)
+	}
+
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ticks := []signal.Tick{
+		{Symbol: "WIF", Price: 2.0, Size: 10, Side: 1, Ts: base.Add(100 * time.Millisecond)},
+		{Symbol: "WIF", Price: 2.2, Size: 5, Side: -1, Ts: base.Add(400 * time.Millisecond)},
+		{Symbol: "WIF", Price: 2.1, Book: &signal.Book{}, Ts: base.Add(500 * time.Millisecond)},
+		{Symbol: "WIF", Price: 1.9, Size: 4, Side: -1, Ts: base.Add(900 * time.Millisecond)},
+	}
+	for _, tk := range ticks {
+		if closed := b.Update(tk); len(closed) != 0 {
+			t.Fatalf("expected no closed bars inside the first second, got %+v", closed)
+		}
+	}
+
+	closed := b.Update(signal.Tick{Symbol: "WIF", Price: 2.05, Size: 1, Side: 1, Ts: base.Add(1500 * time.Millisecond)})
+	if len(closed) != 1 {
+		t.Fatalf("expected the 1s bar to close, got %+v", closed)
+	}
+	bar := closed[0]
+	if bar.Timeframe != time.Second || !bar.Start.Equal(base) || !bar.End().Equal(base.Add(time.Second)) {
+		t.Fatalf("unexpected bar window %+v", bar)
+	}
+	if bar.Open != 2.0 || bar.High != 2.2 || bar.Low != 1.9 || bar.Close != 1.9 {
+		t.Fatalf("unexpected OHLC %+v", bar)
+	}
+	if bar.Volume != 19 || bar.BuyVolume != 10 || bar.SellVolume != 9 || bar.Trades != 3 {
+		t.Fatalf("unexpected volume breakdown %+v", bar)
+	}
+}
+
+func TestBuilderClosesQuietSymbolsOnTickTime(t *testing.T) {
+	b := NewBuilder(time.Second)
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	b.Update(signal.Tick{Symbol: "BODEN", Price: 1, Size: 1, Side: 1, Ts: base})
+
+	// A late tick for BODEN from before its open bar is ignored.
+	if closed := b.Update(signal.Tick{Symbol: "BODEN", Price: 5, Size: 1, Ts: base.Add(-time.Second)}); len(closed) != 0 {
+		t.Fatalf("expected late tick to be ignored, got %+v", closed)
+	}
+
+	closed := b.Update(signal.Tick{Symbol: "WIF", Price: 2, Size: 1, Side: 1, Ts: base.Add(3 * time.Second)})
+	if len(closed) != 1 || closed[0].Symbol != "BODEN" || closed[0].High != 1 {
+		t.Fatalf("expected BODEN to close on WIF's clock, got %+v", closed)
+	}
+	if flushed := b.Flush(base.Add(4 * time.Second)); len(flushed) != 1 || flushed[0].Symbol != "WIF" {
+		t.Fatalf("expected flush to close WIF, got %+v", flushed)
+	}
+	if flushed := b.Flush(base.Add(time.Hour)); len(flushed) != 0 {
+		t.Fatalf("expected nothing left to flush, got %+v", flushed)
+	}
+}
+
+func TestBuilderKeepsProviderClocksApart(t *testing.T) {
+	b := NewBuilder(time.Second)
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	b.Update(signal.Tick{Provider: "dexscreener", Symbol: "BODEN", Price: 1, Size: 1, Side: 1, Ts: base})
+
+	// Binance stamps exchange time, which may run ahead of Dexscreener's receipt time.
+	if closed := b.Update(signal.Tick{Provider: "binance", Symbol: "WIFUSDT", Price: 2, Size: 1, Side: 1, Ts: base.Add(5 * time.Second)}); len(closed) != 0 {
+		t.Fatalf("expected another provider's clock to leave BODEN open, got %+v", closed)
+	}
+	closed := b.Update(signal.Tick{Provider: "dexscreener", Symbol: "POPCAT", Price: 3, Size: 1, Side: 1, Ts: base.Add(2 * time.Second)})
+	if len(closed) != 1 || closed[0].Symbol != "BODEN" {
+		t.Fatalf("expected BODEN to close on its own provider's clock, got %+v", closed)
+	}
+}
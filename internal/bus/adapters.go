@@ -0,0 +1,82 @@
+package bus
+
+import (
+	"context"
+	"time"
+
+	"memebot-go/internal/execution"
+	"memebot-go/internal/signal"
+)
+
+// Observer publishes engine notifications onto the bus (satisfies engine.Observer and engine.BarObserver).
+type Observer struct {
+	bus *Bus
+}
+
+// NewObserver adapts the bus to the engine observer hooks.
+func NewObserver(b *Bus) *Observer {
+	return &Observer{bus: b}
+}
+
+// OnSignal publishes a SignalEvent.
+func (o *Observer) OnSignal(sig signal.Signal) {
+	o.bus.Publish(SignalEvent{Signal: sig})
+}
+
+// OnFill publishes a FillEvent.
+func (o *Observer) OnFill(order execution.Order, fill execution.Fill, realizedPnL float64) {
+	o.bus.Publish(FillEvent{Order: order, Fill: fill, RealizedPnL: realizedPnL})
+}
+
+// OnOrder publishes an OrderEvent.
+func (o *Observer) OnOrder(order execution.Order, filledQty float64) {
+	o.bus.Publish(OrderEvent{Order: order, FilledQty: filledQty, At: time.Now()})
+}
+
+// OnHalt publishes a halt RiskEvent.
+func (o *Observer) OnHalt(reason string) {
+	o.bus.Publish(RiskEvent{Action: "halt", Reason: reason, At: time.Now()})
+}
+
+// OnBar publishes a BarEvent.
+func (o *Observer) OnBar(bar signal.Bar) {
+	o.bus.Publish(BarEvent{Bar: bar})
+}
+
+// TickSource matches engine.Source without importing the engine.
+type TickSource interface {
+	Run(ctx context.Context, out chan<- signal.Tick) error
+}
+
+// Tap wraps a tick source so every tick is published on the bus before it reaches the trading loop.
+type Tap struct {
+	src TickSource
+	bus *Bus
+}
+
+// NewTap wraps src; the returned Tap is itself a TickSource.
+func NewTap(src TickSource, b *Bus) *Tap {
+	return &Tap{src: src, bus: b}
+}
+
+// Run relays ticks from the wrapped source to out, publishing each one as a TickEvent.
+func (t *Tap) Run(ctx context.Context, out chan<- signal.Tick) error {
+	relay := make(chan signal.Tick, cap(out))
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- t.src.Run(ctx, relay)
+		close(relay)
+	}()
+	for tk := range relay {
+		t.bus.Publish(TickEvent{Tick: tk})
+		select {
+		case out <- tk:
+		case <-ctx.Done():
+			// Keep draining so the wrapped source can observe cancellation and return.
+			for range relay {
+			}
+			return <-errCh
+		}
+	}
+	return <-errCh
+}
@@ -0,0 +1,221 @@
+// Package bus fans typed trading events (ticks, bars, signals, orders, fills, risk actions, universe
+// changes) out to independent subscribers, each with its own buffer and overflow policy.
+package bus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"memebot-go/internal/metrics"
+)
+
+// ErrClosed is returned by Recv once the subscription or the bus has been closed.
+var ErrClosed = errors.New("bus subscription closed")
+
+// Policy decides what Publish does when a subscriber's buffer is full.
+type Policy int
+
+const (
+	// DropNewest discards the incoming event, keeping what is already queued.
+	DropNewest Policy = iota
+	// DropOldest evicts the oldest queued event to make room for the incoming one.
+	DropOldest
+	// Block makes Publish wait until the subscriber has room (or unsubscribes).
+	Block
+)
+
+// String returns the policy name used in logs.
+func (p Policy) String() string {
+	switch p {
+	case DropOldest:
+		return "drop_oldest"
+	case Block:
+		return "block"
+	default:
+		return "drop_newest"
+	}
+}
+
+// envelope stamps an event with its publish time so receivers can measure lag.
+type envelope struct {
+	event Event
+	at    time.Time
+}
+
+// Bus delivers every published event to each subscriber interested in its kind.
+type Bus struct {
+	log    zerolog.Logger
+	mu     sync.RWMutex
+	subs   map[*Subscription]struct{}
+	closed bool
+}
+
+// New creates an empty bus.
+func New(log zerolog.Logger) *Bus {
+	return &Bus{log: log, subs: make(map[*Subscription]struct{})}
+}
+
+// Subscription is one consumer's queue; read it with Recv and release it with Close.
+type Subscription struct {
+	name   string
+	policy Policy
+	kinds  map[Kind]struct{} // empty = every kind
+	queue  chan envelope
+	sendMu sync.Mutex // serialises producers so DropOldest's evict-then-send stays consistent
+	done   chan struct{}
+	once   sync.Once
+	bus    *Bus
+}
+
+// Subscribe registers a consumer named for metrics and logs; buffer sizes its queue (minimum 1)
+// and kinds filters what it receives (none = everything).
+func (b *Bus) Subscribe(name string, buffer int, policy Policy, kinds ...Kind) *Subscription {
+	if buffer < 1 {
+		buffer = 1
+	}
+	sub := &Subscription{
+		name:   name,
+		policy: policy,
+		kinds:  make(map[Kind]struct{}, len(kinds)),
+		queue:  make(chan envelope, buffer),
+		done:   make(chan struct{}),
+		bus:    b,
+	}
+	for _, kind := range kinds {
+		sub.kinds[kind] = struct{}{}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		sub.once.Do(func() { close(sub.done) })
+		return sub
+	}
+	b.subs[sub] = struct{}{}
+	b.log.Debug().Str("subscriber", name).Int("buffer", buffer).Str("policy", policy.String()).Msg("bus subscriber added")
+	return sub
+}
+
+// Publish hands ev to every interested subscriber according to its policy.
+func (b *Bus) Publish(ev Event) {
+	if ev == nil {
+		return
+	}
+	env := envelope{event: ev, at: time.Now()}
+	metrics.BusPublished.WithLabelValues(string(ev.Kind())).Inc()
+
+	b.mu.RLock()
+	subs := make([]*Subscription, 0, len(b.subs))
+	for sub := range b.subs {
+		if sub.wants(ev.Kind()) {
+			subs = append(subs, sub)
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, sub := range subs {
+		sub.deliver(env)
+	}
+}
+
+// Close detaches every subscriber; pending events remain readable until drained.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	subs := b.subs
+	b.subs = make(map[*Subscription]struct{})
+	b.closed = true
+	b.mu.Unlock()
+	for sub := range subs {
+		sub.once.Do(func() { close(sub.done) })
+	}
+}
+
+// Name returns the subscriber name.
+func (s *Subscription) Name() string { return s.name }
+
+// Recv returns the next event, recording how long it waited in the queue.
+func (s *Subscription) Recv(ctx context.Context) (Event, error) {
+	select {
+	case env := <-s.queue:
+		return s.received(env), nil
+	default:
+	}
+	select {
+	case env := <-s.queue:
+		return s.received(env), nil
+	case <-s.done:
+		// Drain whatever was queued before the close.
+		select {
+		case env := <-s.queue:
+			return s.received(env), nil
+		default:
+			return nil, ErrClosed
+		}
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close unsubscribes; a publisher blocked on this subscriber is released.
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	delete(s.bus.subs, s)
+	s.bus.mu.Unlock()
+	s.once.Do(func() { close(s.done) })
+}
+
+func (s *Subscription) wants(kind Kind) bool {
+	if len(s.kinds) == 0 {
+		return true
+	}
+	_, ok := s.kinds[kind]
+	return ok
+}
+
+func (s *Subscription) received(env envelope) Event {
+	metrics.BusLagSeconds.WithLabelValues(s.name).Observe(time.Since(env.at).Seconds())
+	metrics.BusQueueDepth.WithLabelValues(s.name).Set(float64(len(s.queue)))
+	return env.event
+}
+
+func (s *Subscription) deliver(env envelope) {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	defer func() { metrics.BusQueueDepth.WithLabelValues(s.name).Set(float64(len(s.queue))) }()
+
+	select {
+	case <-s.done:
+		return
+	case s.queue <- env:
+		return
+	default:
+	}
+
+	switch s.policy {
+	case Block:
+		select {
+		case s.queue <- env:
+		case <-s.done:
+		}
+	case DropOldest:
+		select {
+		case old := <-s.queue:
+			s.dropped(old.event)
+		default:
+		}
+		select {
+		case s.queue <- env:
+		default:
+			s.dropped(env.event)
+		}
+	default:
+		s.dropped(env.event)
+	}
+}
+
+func (s *Subscription) dropped(ev Event) {
+	metrics.BusDropped.WithLabelValues(s.name, string(ev.Kind())).Inc()
+}
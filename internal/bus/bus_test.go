@@ -0,0 +1,158 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+
+	"memebot-go/internal/engine"
+	"memebot-go/internal/metrics"
+	"memebot-go/internal/signal"
+)
+
+var (
+	_ engine.Observer    = (*Observer)(nil)
+	_ engine.BarObserver = (*Observer)(nil)
+	_ engine.Source      = (*Tap)(nil)
+)
+
+func tickEvent(price float64) TickEvent {
+	return TickEvent{Tick: signal.Tick{Symbol: "BONK", Price: price, Ts: time.Unix(0, 0)}}
+}
+
+func recvPrice(t *testing.T, sub *Subscription) float64 {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	ev, err := sub.Recv(ctx)
+	if err != nil {
+		t.Fatalf("recv: %v", err)
+	}
+	return ev.(TickEvent).Tick.Price
+}
+
+func TestDropPolicies(t *testing.T) {
+	b := New(zerolog.Nop())
+	newest := b.Subscribe("test-newest", 2, DropNewest)
+	oldest := b.Subscribe("test-oldest", 2, DropOldest)
+
+	for _, price := range []float64{1, 2, 3, 4} {
+		b.Publish(tickEvent(price))
+	}
+
+	if got := []float64{recvPrice(t, newest), recvPrice(t, newest)}; got[0] != 1 || got[1] != 2 {
+		t.Fatalf("drop newest kept %v, want [1 2]", got)
+	}
+	if got := []float64{recvPrice(t, oldest), recvPrice(t, oldest)}; got[0] != 3 || got[1] != 4 {
+		t.Fatalf("drop oldest kept %v, want [3 4]", got)
+	}
+	for _, name := range []string{"test-newest", "test-oldest"} {
+		if dropped := testutil.ToFloat64(metrics.BusDropped.WithLabelValues(name, string(KindTick))); dropped != 2 {
+			t.Fatalf("%s dropped %v events, want 2", name, dropped)
+		}
+	}
+}
+
+func TestBlockPolicyReleasedByClose(t *testing.T) {
+	b := New(zerolog.Nop())
+	sub := b.Subscribe("test-block", 1, Block)
+	b.Publish(tickEvent(1))
+
+	published := make(chan struct{})
+	go func() {
+		b.Publish(tickEvent(2))
+		close(published)
+	}()
+	select {
+	case <-published:
+		t.Fatal("publish to a full blocking subscriber returned early")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	sub.Close()
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("closing the subscriber did not release the publisher")
+	}
+	if price := recvPrice(t, sub); price != 1 {
+		t.Fatalf("expected queued event to remain readable, got %v", price)
+	}
+	if _, err := sub.Recv(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Fatalf("expected ErrClosed after drain, got %v", err)
+	}
+}
+
+func TestSubscribersFilterByKind(t *testing.T) {
+	b := New(zerolog.Nop())
+	risk := b.Subscribe("test-risk", 4, DropNewest, KindRisk)
+	all := b.Subscribe("test-all", 4, DropNewest)
+	obs := NewObserver(b)
+
+	b.Publish(tickEvent(1))
+	obs.OnHalt("daily loss")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	ev, err := risk.Recv(ctx)
+	if err != nil {
+		t.Fatalf("recv: %v", err)
+	}
+	if re, ok := ev.(RiskEvent); !ok || re.Action != "halt" || re.Reason != "daily loss" {
+		t.Fatalf("unexpected risk event %#v", ev)
+	}
+	for _, want := range []Kind{KindTick, KindRisk} {
+		ev, err := all.Recv(ctx)
+		if err != nil || ev.Kind() != want {
+			t.Fatalf("expected %s event, got %v (%v)", want, ev, err)
+		}
+	}
+}
+
+type sliceSource struct {
+	ticks []signal.Tick
+}
+
+func (s *sliceSource) Run(ctx context.Context, out chan<- signal.Tick) error {
+	for _, tk := range s.ticks {
+		select {
+		case out <- tk:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func TestTapPublishesRelayedTicks(t *testing.T) {
+	b := New(zerolog.Nop())
+	sub := b.Subscribe("test-tap", 4, DropNewest, KindTick)
+	src := &sliceSource{ticks: []signal.Tick{{Symbol: "BONK", Price: 1}, {Symbol: "BONK", Price: 2}}}
+
+	out := make(chan signal.Tick, 4)
+	if err := NewTap(src, b).Run(context.Background(), out); err != nil {
+		t.Fatalf("tap run: %v", err)
+	}
+	close(out)
+	var relayed []float64
+	for tk := range out {
+		relayed = append(relayed, tk.Price)
+	}
+	if len(relayed) != 2 || relayed[0] != 1 || relayed[1] != 2 {
+		t.Fatalf("unexpected relayed ticks %v", relayed)
+	}
+	if first, second := recvPrice(t, sub), recvPrice(t, sub); first != 1 || second != 2 {
+		t.Fatalf("unexpected published ticks %v, %v", first, second)
+	}
+}
+
+func TestUniverseEventDiff(t *testing.T) {
+	ev := NewUniverseEvent([]string{"BONK", "WIF"}, []string{"WIF", "POPCAT"})
+	if len(ev.Added) != 1 || ev.Added[0] != "BONK" || len(ev.Removed) != 1 || ev.Removed[0] != "POPCAT" {
+		t.Fatalf("unexpected diff added=%v removed=%v", ev.Added, ev.Removed)
+	}
+}
@@ -0,0 +1,110 @@
+package bus
+
+import (
+	"time"
+
+	"memebot-go/internal/execution"
+	"memebot-go/internal/signal"
+)
+
+// Kind identifies an event type for subscription filters and metrics.
+type Kind string
+
+// Event kinds published on the bus.
+const (
+	KindTick     Kind = "tick"
+	KindBar      Kind = "bar"
+	KindSignal   Kind = "signal"
+	KindOrder    Kind = "order"
+	KindFill     Kind = "fill"
+	KindRisk     Kind = "risk"
+	KindUniverse Kind = "universe"
+)
+
+// Event is implemented by every payload carried on the bus.
+type Event interface {
+	Kind() Kind
+	Time() time.Time
+}
+
+// TickEvent carries a market data tick as delivered by the feed.
+type TickEvent struct {
+	Tick signal.Tick
+}
+
+// BarEvent carries a closed OHLCV bar.
+type BarEvent struct {
+	Bar signal.Bar
+}
+
+// SignalEvent carries a strategy signal before sizing and risk checks.
+type SignalEvent struct {
+	Signal signal.Signal
+}
+
+// OrderEvent reports a routed order and how much of it filled.
+type OrderEvent struct {
+	Order     execution.Order
+	FilledQty float64
+	At        time.Time
+}
+
+// FillEvent reports a fill booked by the account with the PnL it realised.
+type FillEvent struct {
+	Order       execution.Order
+	Fill        execution.Fill
+	RealizedPnL float64
+}
+
+// RiskEvent reports a risk action such as a kill-switch halt.
+type RiskEvent struct {
+	Action string // e.g. "halt"
+	Reason string
+	At     time.Time
+}
+
+// UniverseEvent reports a change to the traded symbol set.
+type UniverseEvent struct {
+	Symbols []string
+	Added   []string
+	Removed []string
+	At      time.Time
+}
+
+func (TickEvent) Kind() Kind     { return KindTick }
+func (BarEvent) Kind() Kind      { return KindBar }
+func (SignalEvent) Kind() Kind   { return KindSignal }
+func (OrderEvent) Kind() Kind    { return KindOrder }
+func (FillEvent) Kind() Kind     { return KindFill }
+func (RiskEvent) Kind() Kind     { return KindRisk }
+func (UniverseEvent) Kind() Kind { return KindUniverse }
+
+func (e TickEvent) Time() time.Time     { return e.Tick.Ts }
+func (e BarEvent) Time() time.Time      { return e.Bar.End() }
+func (e SignalEvent) Time() time.Time   { return e.Signal.Ts }
+func (e OrderEvent) Time() time.Time    { return e.At }
+func (e FillEvent) Time() time.Time     { return e.Fill.Ts }
+func (e RiskEvent) Time() time.Time     { return e.At }
+func (e UniverseEvent) Time() time.Time { return e.At }
+
+// NewUniverseEvent diffs two symbol sets into a universe change.
+func NewUniverseEvent(current, previous []string) UniverseEvent {
+	prev := make(map[string]struct{}, len(previous))
+	for _, sym := range previous {
+		prev[sym] = struct{}{}
+	}
+	ev := UniverseEvent{Symbols: append([]string(nil), current...), At: time.Now()}
+	cur := make(map[string]struct{}, len(current))
+	for _, sym := range current {
+		cur[sym] = struct{}{}
+		if _, ok := prev[sym]; !ok {
+			ev.Added = append(ev.Added, sym)
+		}
+	}
+	for _, sym := range previous {
+		if _, ok := cur[sym]; !ok {
+			ev.Removed = append(ev.Removed, sym)
+		}
+	}
+	return ev
+}
@@ -0,0 +1,225 @@
+// Package binance provides a signed REST client for Binance spot trading.
+package binance
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	// MainnetBaseURL is the production spot REST endpoint.
+	MainnetBaseURL = "https://api.binance.com"
+	// TestnetBaseURL is the spot testnet REST endpoint; keys are issued separately at testnet.binance.vision.
+	TestnetBaseURL = "https://testnet.binance.vision"
+)
+
+// Order types supported by PlaceOrder.
+const (
+	OrderTypeMarket = "MARKET"
+	OrderTypeLimit  = "LIMIT"
+)
+
+// Client signs and sends Binance spot REST requests.
+type Client struct {
+	Base       string
+	APIKey     string
+	APISecret  string
+	RecvWindow time.Duration
+	Http       *http.Client
+	now        func() time.Time
+}
+
+// APIError carries the code/msg body Binance returns on rejected requests.
+type APIError struct {
+	Status int    `json:"-"`
+	Code   int    `json:"code"`
+	Msg    string `json:"msg"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("binance status %d code %d: %s", e.Status, e.Code, e.Msg)
+}
+
+// ErrCodeUnknownOrder is returned when cancelling or querying an order the matching engine no longer holds open.
+const ErrCodeUnknownOrder = -2011
+
+// NewClient wires API credentials against mainnet or testnet.
+func NewClient(apiKey, apiSecret string, testnet bool) *Client {
+	base := MainnetBaseURL
+	if testnet {
+		base = TestnetBaseURL
+	}
+	return &Client{
+		Base:       base,
+		APIKey:     apiKey,
+		APISecret:  apiSecret,
+		RecvWindow: 5 * time.Second,
+		Http:       &http.Client{Timeout: 10 * time.Second},
+		now:        time.Now,
+	}
+}
+
+// OrderRequest describes a new spot order; quantity and price must already respect the symbol filters.
+type OrderRequest struct {
+	Symbol        string
+	Side          string // BUY or SELL
+	Type          string // MARKET or LIMIT
+	Quantity      string
+	Price         string // required for LIMIT
+	ClientOrderID string
+}
+
+// OrderFill is a single trade reported in a FULL order response.
+type OrderFill struct {
+	Price           string `json:"price"`
+	Qty             string `json:"qty"`
+	Commission      string `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"`
+	TradeID         int64  `json:"tradeId"`
+}
+
+// Order mirrors the order payload returned by place, cancel, and query endpoints.
+type Order struct {
+	Symbol              string      `json:"symbol"`
+	OrderID             int64       `json:"orderId"`
+	ClientOrderID       string      `json:"clientOrderId"`
+	Price               string      `json:"price"`
+	OrigQty             string      `json:"origQty"`
+	ExecutedQty         string      `json:"executedQty"`
+	CummulativeQuoteQty string      `json:"cummulativeQuoteQty"`
+	Status              string      `json:"status"`
+	Type                string      `json:"type"`
+	Side                string      `json:"side"`
+	TransactTime        int64       `json:"transactTime"`
+	UpdateTime          int64       `json:"updateTime"`
+	Fills               []OrderFill `json:"fills"`
+}
+
+// AssetBalance is a single entry of the account balances list.
+type AssetBalance struct {
+	Asset  string `json:"asset"`
+	Free   string `json:"free"`
+	Locked string `json:"locked"`
+}
+
+// Account captures the balances section of GET /api/v3/account.
+type Account struct {
+	CanTrade bool           `json:"canTrade"`
+	Balances []AssetBalance `json:"balances"`
+}
+
+// PlaceOrder submits a new order and requests the FULL response so fills come back inline.
+func (client *Client) PlaceOrder(ctx context.Context, req OrderRequest) (*Order, error) {
+	params := url.Values{}
+	params.Set("symbol", req.Symbol)
+	params.Set("side", req.Side)
+	params.Set("type", req.Type)
+	params.Set("quantity", req.Quantity)
+	params.Set("newOrderRespType", "FULL")
+	if req.Type == OrderTypeLimit {
+		params.Set("price", req.Price)
+		params.Set("timeInForce", "GTC")
+	}
+	if req.ClientOrderID != "" {
+		params.Set("newClientOrderId", req.ClientOrderID)
+	}
+	var order Order
+	if err := client.signed(ctx, http.MethodPost, "/api/v3/order", params, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// CancelOrder cancels an open order by client order id.
+func (client *Client) CancelOrder(ctx context.Context, symbol, clientOrderID string) (*Order, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("origClientOrderId", clientOrderID)
+	var order Order
+	if err := client.signed(ctx, http.MethodDelete, "/api/v3/order", params, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// QueryOrder fetches the current state of an order by client order id.
+func (client *Client) QueryOrder(ctx context.Context, symbol, clientOrderID string) (*Order, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("origClientOrderId", clientOrderID)
+	var order Order
+	if err := client.signed(ctx, http.MethodGet, "/api/v3/order", params, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// Account returns the spot account balances.
+func (client *Client) Account(ctx context.Context) (*Account, error) {
+	var account Account
+	if err := client.signed(ctx, http.MethodGet, "/api/v3/account", url.Values{}, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// signed appends timestamp, recvWindow, and the HMAC-SHA256 signature before sending the request.
+func (client *Client) signed(ctx context.Context, method, path string, params url.Values, out any) error {
+	params.Set("timestamp", strconv.FormatInt(client.now().UnixMilli(), 10))
+	if client.RecvWindow > 0 {
+		params.Set("recvWindow", strconv.FormatInt(client.RecvWindow.Milliseconds(), 10))
+	}
+	query := params.Encode()
+	query += "&signature=" + client.sign(query)
+	return client.do(ctx, method, path, query, true, out)
+}
+
+// sign returns the hex HMAC-SHA256 of payload keyed by the API secret.
+func (client *Client) sign(payload string) string {
+	mac := hmac.New(sha256.New, []byte(client.APISecret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (client *Client) do(ctx context.Context, method, path, query string, auth bool, out any) error {
+	URL := client.Base + path
+	if query != "" {
+		URL += "?" + query
+	}
+	req, err := http.NewRequestWithContext(ctx, method, URL, nil)
+	if err != nil {
+		return err
+	}
+	if auth {
+		req.Header.Set("X-MBX-APIKEY", client.APIKey)
+	}
+	resp, err := client.Http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &APIError{Status: resp.StatusCode}
+		if json.Unmarshal(body, apiErr) != nil || apiErr.Msg == "" {
+			apiErr.Msg = string(body)
+		}
+		return apiErr
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("decode %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignMatchesBinanceDocsExample(t *testing.T) {
+	client := NewClient("key", "NhqPtmdSJYdKjVHjA7PZj4Mge3R5YNiP1e3UZjInClVN65XAbvqqM6A7H5fATj0j", false)
+	payload := "symbol=LTCBTC&side=BUY&type=LIMIT&timeInForce=GTC&quantity=1&price=0.1&recvWindow=5000&timestamp=1499827319559"
+	want := "c8db56825ae71d6d79447849e617115f4a920fa2acdcab2b053c4b2838bd6b71"
+	if got := client.sign(payload); got != want {
+		t.Fatalf("unexpected signature %s", got)
+	}
+}
+
+func TestNewClientTestnetBase(t *testing.T) {
+	if got := NewClient("k", "s", true).Base; got != TestnetBaseURL {
+		t.Fatalf("expected testnet base, got %s", got)
+	}
+	if got := NewClient("k", "s", false).Base; got != MainnetBaseURL {
+		t.Fatalf("expected mainnet base, got %s", got)
+	}
+}
+
+func TestPlaceOrderSignsRequest(t *testing.T) {
+	client := NewClient("api-key", "secret", true)
+	client.now = func() time.Time { return time.UnixMilli(1700000000000) }
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v3/order" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("X-MBX-APIKEY") != "api-key" {
+			t.Fatalf("missing api key header")
+		}
+		raw := r.URL.RawQuery
+		idx := strings.LastIndex(raw, "&signature=")
+		if idx < 0 || client.sign(raw[:idx]) != raw[idx+len("&signature="):] {
+			t.Fatalf("signature does not cover query: %s", raw)
+		}
+		query := r.URL.Query()
+		if query.Get("type") != OrderTypeLimit || query.Get("price") != "1.2300" || query.Get("timeInForce") != "GTC" {
+			t.Fatalf("unexpected limit params: %v", query)
+		}
+		if query.Get("timestamp") != "1700000000000" || query.Get("recvWindow") != "5000" {
+			t.Fatalf("unexpected timing params: %v", query)
+		}
+		fmt.Fprint(w, `{"symbol":"WIFUSDT","orderId":7,"clientOrderId":"c-1","status":"NEW","executedQty":"0","fills":[]}`)
+	}))
+	defer server.Close()
+	client.Base = server.URL
+	client.Http = server.Client()
+
+	order, err := client.PlaceOrder(context.Background(), OrderRequest{
+		Symbol: "WIFUSDT", Side: "BUY", Type: OrderTypeLimit, Quantity: "10", Price: "1.2300", ClientOrderID: "c-1",
+	})
+	if err != nil {
+		t.Fatalf("PlaceOrder returned error: %v", err)
+	}
+	if order.OrderID != 7 || order.Status != "NEW" {
+		t.Fatalf("unexpected order %+v", order)
+	}
+}
+
+func TestAPIErrorDecoded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"code":-2011,"msg":"Unknown order sent."}`)
+	}))
+	defer server.Close()
+	client := NewClient("k", "s", true)
+	client.Base = server.URL
+
+	_, err := client.CancelOrder(context.Background(), "WIFUSDT", "missing")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	if apiErr.Code != ErrCodeUnknownOrder || apiErr.Status != http.StatusBadRequest {
+		t.Fatalf("unexpected api error %+v", apiErr)
+	}
+}
+
+func TestExchangeInfoRounding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("symbol") != "WIFUSDT" {
+			t.Fatalf("expected symbol filter, got %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"symbols":[{"symbol":"WIFUSDT","status":"TRADING","baseAsset":"WIF","quoteAsset":"USDT","filters":[
+			{"filterType":"PRICE_FILTER","minPrice":"0.00010000","maxPrice":"1000.00000000","tickSize":"0.00010000"},
+			{"filterType":"LOT_SIZE","minQty":"0.10000000","maxQty":"90000.00000000","stepSize":"0.10000000"},
+			{"filterType":"NOTIONAL","minNotional":"5.00000000"}]}]}`)
+	}))
+	defer server.Close()
+	client := NewClient("k", "s", true)
+	client.Base = server.URL
+
+	rules, err := client.ExchangeInfo(context.Background(), "WIFUSDT")
+	if err != nil {
+		t.Fatalf("ExchangeInfo returned error: %v", err)
+	}
+	wif := rules["WIFUSDT"]
+	if got := wif.FormatQty(wif.RoundQty(12.3456)); got != "12.3" {
+		t.Fatalf("unexpected rounded qty %s", got)
+	}
+	if got := wif.FormatPrice(wif.RoundPrice(2.345678)); got != "2.3456" {
+		t.Fatalf("unexpected rounded price %s", got)
+	}
+	if got := wif.FormatQty(wif.RoundQty(0.3)); got != "0.3" {
+		t.Fatalf("expected step-aligned qty to survive rounding, got %s", got)
+	}
+	if err := wif.Validate(1, 2); err == nil {
+		t.Fatalf("expected min notional violation")
+	}
+	if err := wif.Validate(0.05, 200); err == nil {
+		t.Fatalf("expected min qty violation")
+	}
+	if err := wif.Validate(3, 2); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SymbolRules holds the trading filters needed to build valid orders for one symbol.
+type SymbolRules struct {
+	Symbol      string
+	Status      string
+	BaseAsset   string
+	QuoteAsset  string
+	MinQty      float64
+	MaxQty      float64
+	StepSize    float64
+	MinPrice    float64
+	MaxPrice    float64
+	TickSize    float64
+	MinNotional float64
+
+	qtyPrecision   int
+	pricePrecision int
+}
+
+type exchangeInfo struct {
+	Symbols []struct {
+		Symbol     string            `json:"symbol"`
+		Status     string            `json:"status"`
+		BaseAsset  string            `json:"baseAsset"`
+		QuoteAsset string            `json:"quoteAsset"`
+		Filters    []json.RawMessage `json:"filters"`
+	} `json:"symbols"`
+}
+
+type symbolFilter struct {
+	FilterType  string `json:"filterType"`
+	MinQty      string `json:"minQty"`
+	MaxQty      string `json:"maxQty"`
+	StepSize    string `json:"stepSize"`
+	MinPrice    string `json:"minPrice"`
+	MaxPrice    string `json:"maxPrice"`
+	TickSize    string `json:"tickSize"`
+	MinNotional string `json:"minNotional"`
+}
+
+// ExchangeInfo loads LOT_SIZE, PRICE_FILTER, and (MIN_)NOTIONAL filters for the given symbols.
+func (client *Client) ExchangeInfo(ctx context.Context, symbols ...string) (map[string]SymbolRules, error) {
+	query := ""
+	switch len(symbols) {
+	case 0:
+	case 1:
+		query = url.Values{"symbol": {symbols[0]}}.Encode()
+	default:
+		encoded, _ := json.Marshal(symbols)
+		query = url.Values{"symbols": {string(encoded)}}.Encode()
+	}
+	var info exchangeInfo
+	if err := client.do(ctx, http.MethodGet, "/api/v3/exchangeInfo", query, false, &info); err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]SymbolRules, len(info.Symbols))
+	for _, sym := range info.Symbols {
+		rules := SymbolRules{Symbol: sym.Symbol, Status: sym.Status, BaseAsset: sym.BaseAsset, QuoteAsset: sym.QuoteAsset}
+		for _, raw := range sym.Filters {
+			var filter symbolFilter
+			if err := json.Unmarshal(raw, &filter); err != nil {
+				return nil, fmt.Errorf("decode %s filter: %w", sym.Symbol, err)
+			}
+			switch filter.FilterType {
+			case "LOT_SIZE":
+				rules.MinQty = parseFloat(filter.MinQty)
+				rules.MaxQty = parseFloat(filter.MaxQty)
+				rules.StepSize = parseFloat(filter.StepSize)
+				rules.qtyPrecision = precision(filter.StepSize)
+			case "PRICE_FILTER":
+				rules.MinPrice = parseFloat(filter.MinPrice)
+				rules.MaxPrice = parseFloat(filter.MaxPrice)
+				rules.TickSize = parseFloat(filter.TickSize)
+				rules.pricePrecision = precision(filter.TickSize)
+			case "NOTIONAL", "MIN_NOTIONAL":
+				rules.MinNotional = parseFloat(filter.MinNotional)
+			}
+		}
+		out[sym.Symbol] = rules
+	}
+	return out, nil
+}
+
+// RoundQty floors qty to the lot step size and clamps it to the maximum.
+func (rules SymbolRules) RoundQty(qty float64) float64 {
+	qty = floorToStep(qty, rules.StepSize)
+	if rules.MaxQty > 0 && qty > rules.MaxQty {
+		qty = floorToStep(rules.MaxQty, rules.StepSize)
+	}
+	return qty
+}
+
+// RoundPrice floors price to the tick size.
+func (rules SymbolRules) RoundPrice(price float64) float64 {
+	return floorToStep(price, rules.TickSize)
+}
+
+// FormatQty renders a rounded quantity with the precision implied by the step size.
+func (rules SymbolRules) FormatQty(qty float64) string {
+	return strconv.FormatFloat(qty, 'f', rules.qtyPrecision, 64)
+}
+
+// FormatPrice renders a rounded price with the precision implied by the tick size.
+func (rules SymbolRules) FormatPrice(price float64) string {
+	return strconv.FormatFloat(price, 'f', rules.pricePrecision, 64)
+}
+
+// Validate reports why a rounded order would be rejected by the symbol filters.
+func (rules SymbolRules) Validate(qty, price float64) error {
+	if qty <= 0 || qty < rules.MinQty {
+		return fmt.Errorf("%s quantity %v below min %v", rules.Symbol, qty, rules.MinQty)
+	}
+	if price > 0 && rules.MinPrice > 0 && price < rules.MinPrice {
+		return fmt.Errorf("%s price %v below min %v", rules.Symbol, price, rules.MinPrice)
+	}
+	if price > 0 && rules.MinNotional > 0 && qty*price < rules.MinNotional {
+		return fmt.Errorf("%s notional %.8f below min %v", rules.Symbol, qty*price, rules.MinNotional)
+	}
+	return nil
+}
+
+// floorToStep rounds down to a multiple of step, tolerating float noise just under the boundary.
+func floorToStep(value, step float64) float64 {
+	if step <= 0 {
+		return value
+	}
+	return math.Floor(value/step+1e-9) * step
+}
+
+// precision counts significant decimals in a Binance step string such as "0.00100000".
+func precision(step string) int {
+	dot := strings.IndexByte(step, '.')
+	if dot < 0 {
+		return 0
+	}
+	decimals := strings.TrimRight(step[dot+1:], "0")
+	return len(decimals)
+}
+
+func parseFloat(value string) float64 {
+	parsed, _ := strconv.ParseFloat(value, 64)
+	return parsed
+}
@@ -2,7 +2,10 @@
 package config
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 
 	"gopkg.in/yaml.v3"
@@ -18,32 +21,118 @@ type App struct {
 
 // Exchange describes the centralized exchange connectivity parameters the bot expects.
 type Exchange struct {
-	Name        string
-	Symbols     []string
-	APIKey      string
-	APISecret   string
-	Testnet     bool
+	Name        string      `yaml:"name"`
+	Symbols     []string    `yaml:"symbols"`
+	APIKey      string      `yaml:"api_key"`
+	APISecret   string      `yaml:"api_secret"`
+	Testnet     bool        `yaml:"testnet"` // Binance spot testnet for order routing
+	Binance     Binance     `yaml:"binance"`
 	DexScreener DexScreener `yaml:"dexscreener"`
+	Solana      SolanaFeed  `yaml:"solana"`
 	Discovery   Discovery   `yaml:"discovery"`
+	RecordPath  string      `yaml:"record_path"`
+	ListsPath   string      `yaml:"lists_path"` // persistent allow/deny lists edited via the paper API; "" keeps them in memory
+	Replay      Replay      `yaml:"replay"`
+}
+
+// Replay points the replay provider at a recorded tick file and controls its pacing.
+type Replay struct {
+	Path  string  `yaml:"path"`
+	Speed float64 `yaml:"speed"` // 1 = original pacing, 10 = 10x, <= 0 = as fast as possible
+}
+
+// Binance configures the public websocket market data feed.
+type Binance struct {
+	StreamURL   string `yaml:"stream_url"`
+	DepthLevels int    `yaml:"depth_levels"` // partial book depth per symbol (5, 10, 20); 0 streams trades only
+}
+
+// SolanaFeed configures the on-chain swap feed over Solana RPC websocket subscriptions.
+type SolanaFeed struct {
+	WSURL      string       `yaml:"ws_url"`
+	RPCURL     string       `yaml:"rpc_url"`    // HTTP RPC for block times; derived from ws_url when empty
+	Commitment string       `yaml:"commitment"` // processed|confirmed|finalized
+	Pools      []SolanaPool `yaml:"pools"`
+}
+
+// SolanaPool maps a feed symbol to a Raydium AMM v4 or Orca Whirlpool pool account.
+type SolanaPool struct {
+	Symbol        string `yaml:"symbol"`
+	Address       string `yaml:"address"`
+	Dex           string `yaml:"dex"` // raydium|orca
+	BaseDecimals  int    `yaml:"base_decimals"`
+	QuoteDecimals int    `yaml:"quote_decimals"`
+	Invert        bool   `yaml:"invert"` // base asset is the pool's second token (Raydium pc / Whirlpool token B)
 }
 
 // DexScreener configures the HTTP polling feed targeting Dexscreener pairs.
 type DexScreener struct {
-	BaseURL      string `yaml:"base_url"`
-	DefaultChain string `yaml:"default_chain"`
-	PollInterval int    `yaml:"poll_interval_ms"`
+	BaseURL        string `yaml:"base_url"`
+	DefaultChain   string `yaml:"default_chain"`
+	PollInterval   int    `yaml:"poll_interval_ms"`
+	BatchSize      int    `yaml:"batch_size"`      // pair addresses per request, max 30
+	MaxConcurrency int    `yaml:"max_concurrency"` // batch requests in flight per poll cycle
 }
 
 // Discovery configures automatic symbol discovery.
 type Discovery struct {
-	Enabled            bool     `yaml:"enabled"`
-	Keywords           []string `yaml:"keywords"`
-	Chains             []string `yaml:"chains"`
-	MaxPairs           int      `yaml:"max_pairs"`
-	RefreshInterval    int      `yaml:"refresh_interval_ms"`
-	MinLiquidityUSD    float64  `yaml:"min_liquidity_usd"`
-	MinVolumeUSD       float64  `yaml:"min_volume_usd"`
-	MaxPairsPerKeyword int      `yaml:"max_pairs_per_keyword"`
+	Enabled            bool        `yaml:"enabled"`
+	Mode               string      `yaml:"mode"` // keywords (default), new_listings, or both
+	Keywords           []string    `yaml:"keywords"`
+	Chains             []string    `yaml:"chains"`
+	MaxPairs           int         `yaml:"max_pairs"`
+	RefreshInterval    int         `yaml:"refresh_interval_ms"`
+	MinLiquidityUSD    float64     `yaml:"min_liquidity_usd"`
+	MinVolumeUSD       float64     `yaml:"min_volume_usd"`
+	MaxPairsPerKeyword int         `yaml:"max_pairs_per_keyword"`
+	MinResidencyMs     int         `yaml:"min_residency_ms"` // keep a discovered symbol at least this long once admitted; 0 disables
+	HistoryPath        string      `yaml:"history_path"`     // JSONL log of symbols entering/leaving the universe; "" disables
+	NewListings        NewListings `yaml:"new_listings"`
+	Screening          Screening   `yaml:"screening"`
+	Scoring            Scoring     `yaml:"scoring"`
+	Pools              Pools       `yaml:"pools"`
+}
+
+// Pools chooses the one pair discovery keeps per base token when several pools trade it.
+type Pools struct {
+	PreferQuotes      []string `yaml:"prefer_quotes"`       // quote symbols or mints, most preferred first, e.g. [USDC, SOL]
+	MinLiquidityShare float64  `yaml:"min_liquidity_share"` // a preferred quote only wins with at least this share of the deepest pool's liquidity (default 0.5)
+}
+
+// Scoring configures the weighted model that ranks discovery candidates.
+type Scoring struct {
+	Features    []ScoreFeature `yaml:"features"`     // empty = liquidity_usd*0.6 + volume*0.35 + price_change_h24*1000 (floored at 0)
+	LogFeatures bool           `yaml:"log_features"` // log each candidate's breakdown at info instead of debug
+}
+
+// ScoreFeature weights one pair feature after clipping and normalization.
+type ScoreFeature struct {
+	Name      string   `yaml:"name"`
+	Weight    float64  `yaml:"weight"`
+	Normalize string   `yaml:"normalize"` // none (default), log, minmax, zscore, or rank; the last three are relative to the refresh's candidates
+	Floor     *float64 `yaml:"floor"`     // clip raw values below this
+	Cap       *float64 `yaml:"cap"`       // clip raw values above this
+}
+
+// NewListings configures discovery of freshly launched pairs from Dexscreener's latest token feeds.
+type NewListings struct {
+	Sources  []string `yaml:"sources"`    // token_profiles, token_boosts, top_boosts (default: all)
+	MinAgeMs int      `yaml:"min_age_ms"` // youngest pair admitted (default 10m)
+	MaxAgeMs int      `yaml:"max_age_ms"` // oldest pair admitted (default 6h)
+}
+
+// Screening configures the token safety checks applied to discovered pairs before they join the universe.
+type Screening struct {
+	Enabled         bool     `yaml:"enabled"`
+	RPCURL          string   `yaml:"rpc_url"`
+	Commitment      string   `yaml:"commitment"`
+	TopHolders      int      `yaml:"top_holders"`        // largest token accounts counted toward concentration (default 10)
+	MaxTopHolderPct float64  `yaml:"max_top_holder_pct"` // % of supply those accounts may hold, pool vaults excluded; 0 disables
+	MinLPBurnPct    float64  `yaml:"min_lp_burn_pct"`    // % of Raydium AMM LP tokens that must be burned; 0 disables
+	MinPairAgeMs    int      `yaml:"min_pair_age_ms"`    // 0 disables
+	Reject          []string `yaml:"reject"`             // checks that drop a candidate, also when the RPC cannot evaluate them; other failures only scale its score
+	ScorePenalty    float64  `yaml:"score_penalty"`      // score multiplier per soft failure (default 0.5)
+	CacheTTL        int      `yaml:"cache_ttl_ms"`       // how long on-chain results are reused (default 10m)
 }
 
 // Risk encodes guard-rails for how much size the executor may take on.
@@ -52,22 +141,34 @@ type Risk struct {
 	MaxDailyLoss         float64 `yaml:"max_daily_loss"`
 	KillSwitchDrawdown   float64 `yaml:"kill_switch_drawdown"`
 	MaxPortfolioNotional float64 `yaml:"max_portfolio_notional"`
+	StaleAfterMs         int     `yaml:"stale_after_ms"`       // refuse entries on symbols without a tick for this long; 0 disables
+	FlagStalePositions   bool    `yaml:"flag_stale_positions"` // also warn about open positions whose data goes stale
 }
 
-// StrategyParams groups tunable knobs for a strategy implementation.
-type StrategyParams struct {
-	OBILevels         int
-	OBIThreshold      float64
-	VolWindowSecs     int
-	TrendThreshold    float64 `yaml:"trend_threshold"`
-	TrendWindowSecs   int     `yaml:"trend_window_secs"`
-	TrendMinVolumeUSD float64 `yaml:"trend_min_volume_usd"`
+// Strategy selects the active strategy; every other key is a parameter block named after a registered
+// strategy (e.g. obi_momentum, trend_follow) and decoded by that strategy into its own typed parameters.
+type Strategy struct {
+	Mode   string               `yaml:"mode"`
+	Blocks map[string]yaml.Node `yaml:",inline"`
 }
 
-// Strategy specifies which strategy is active along with the parameter bundle.
-type Strategy struct {
-	Mode   string
-	Params StrategyParams
+// Decode strictly decodes the named parameter block into out, rejecting unknown keys; out keeps its
+// defaults when the block is absent.
+func (s Strategy) Decode(name string, out any) error {
+	node, ok := s.Blocks[name]
+	if !ok {
+		return nil
+	}
+	raw, err := yaml.Marshal(&node)
+	if err != nil {
+		return fmt.Errorf("strategy.%s: %w", name, err)
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	dec.KnownFields(true)
+	if err := dec.Decode(out); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("strategy.%s: %w", name, err)
+	}
+	return nil
 }
 
 // Paper captures paper-trading account settings such as starting cash, per-symbol caps, and execution tuning.
@@ -91,6 +192,7 @@ type Config struct {
 	Dex      Dex      `yaml:"dex"`
 	Wallet   Wallet   `yaml:"wallet"`
 	Paper    Paper    `yaml:"paper"`
+	Live     Live     `yaml:"live"`
 }
 
 // Load reads a YAML file from disk and hydrates a Config struct.
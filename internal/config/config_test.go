@@ -18,6 +18,12 @@ func TestLoad(t *testing.T) {
 	if len(cfg.Exchange.Symbols) != 1 || cfg.Exchange.Symbols[0] != "BTCUSDT" {
 		t.Fatalf("expected BTCUSDT symbol, got %+v", cfg.Exchange.Symbols)
 	}
+	if cfg.Exchange.APIKey != "k" || cfg.Exchange.APISecret != "s" || !cfg.Exchange.Testnet {
+		t.Fatalf("unexpected exchange credentials: key=%q secret=%q testnet=%v", cfg.Exchange.APIKey, cfg.Exchange.APISecret, cfg.Exchange.Testnet)
+	}
+	if cfg.Exchange.Binance.StreamURL != "wss://stream.example" || cfg.Exchange.Binance.DepthLevels != 10 {
+		t.Fatalf("unexpected binance config: %+v", cfg.Exchange.Binance)
+	}
 	if cfg.Exchange.DexScreener.BaseURL != "https://api.dexscreener.com" {
 		t.Fatalf("unexpected DexScreener.BaseURL: %s", cfg.Exchange.DexScreener.BaseURL)
 	}
@@ -27,6 +33,9 @@ func TestLoad(t *testing.T) {
 	if cfg.Exchange.DexScreener.PollInterval != 750 {
 		t.Fatalf("unexpected DexScreener.PollInterval: %d", cfg.Exchange.DexScreener.PollInterval)
 	}
+	if cfg.Exchange.DexScreener.BatchSize != 10 || cfg.Exchange.DexScreener.MaxConcurrency != 2 {
+		t.Fatalf("unexpected DexScreener batching: %+v", cfg.Exchange.DexScreener)
+	}
 	if !cfg.Exchange.Discovery.Enabled {
 		t.Fatalf("expected discovery enabled")
 	}
@@ -48,18 +57,69 @@ func TestLoad(t *testing.T) {
 	if cfg.Exchange.Discovery.MaxPairsPerKeyword != 3 {
 		t.Fatalf("unexpected discovery max pairs per keyword: %d", cfg.Exchange.Discovery.MaxPairsPerKeyword)
 	}
-	if cfg.Strategy.Params.TrendThreshold != 0.05 {
-		t.Fatalf("unexpected trend threshold: %.2f", cfg.Strategy.Params.TrendThreshold)
+	if cfg.Exchange.Discovery.MinResidencyMs != 120000 {
+		t.Fatalf("unexpected discovery min residency: %d", cfg.Exchange.Discovery.MinResidencyMs)
+	}
+	if cfg.Exchange.Discovery.HistoryPath != "universe.jsonl" {
+		t.Fatalf("unexpected discovery history path: %s", cfg.Exchange.Discovery.HistoryPath)
+	}
+	if nl := cfg.Exchange.Discovery.NewListings; cfg.Exchange.Discovery.Mode != "both" || len(nl.Sources) != 1 || nl.Sources[0] != "token_boosts" ||
+		nl.MinAgeMs != 300000 || nl.MaxAgeMs != 3600000 {
+		t.Fatalf("unexpected new listing discovery: mode %q %+v", cfg.Exchange.Discovery.Mode, nl)
+	}
+	if sc := cfg.Exchange.Discovery.Scoring; !sc.LogFeatures || len(sc.Features) != 2 {
+		t.Fatalf("unexpected discovery scoring: %+v", sc)
+	}
+	if f := cfg.Exchange.Discovery.Scoring.Features[1]; f.Name != "price_change_h24" || f.Weight != 0.5 || f.Normalize != "minmax" ||
+		f.Floor == nil || *f.Floor != 0 || f.Cap == nil || *f.Cap != 100 {
+		t.Fatalf("unexpected score feature: %+v", f)
+	}
+	if pools := cfg.Exchange.Discovery.Pools; len(pools.PreferQuotes) != 2 || pools.PreferQuotes[0] != "USDC" || pools.MinLiquidityShare != 0.25 {
+		t.Fatalf("unexpected discovery pool preference: %+v", pools)
+	}
+	if scr := cfg.Exchange.Discovery.Screening; !scr.Enabled || scr.RPCURL != "http://localhost:8899" || scr.TopHolders != 5 ||
+		scr.MaxTopHolderPct != 40 || scr.MinLPBurnPct != 95 || scr.MinPairAgeMs != 600000 ||
+		len(scr.Reject) != 1 || scr.Reject[0] != "freeze_authority" || scr.ScorePenalty != 0.25 || scr.CacheTTL != 60000 {
+		t.Fatalf("unexpected discovery screening: %+v", scr)
+	}
+	if cfg.Exchange.RecordPath != "ticks.jsonl" {
+		t.Fatalf("unexpected record path: %s", cfg.Exchange.RecordPath)
+	}
+	if cfg.Exchange.ListsPath != "symbol_lists.json" {
+		t.Fatalf("unexpected lists path: %s", cfg.Exchange.ListsPath)
 	}
-	if cfg.Strategy.Params.TrendWindowSecs != 90 {
-		t.Fatalf("unexpected trend window: %d", cfg.Strategy.Params.TrendWindowSecs)
+	if cfg.Exchange.Replay.Path != "ticks.jsonl" || cfg.Exchange.Replay.Speed != 100 {
+		t.Fatalf("unexpected replay config: %+v", cfg.Exchange.Replay)
 	}
-	if cfg.Strategy.Params.TrendMinVolumeUSD != 1000 {
-		t.Fatalf("unexpected trend min volume: %.2f", cfg.Strategy.Params.TrendMinVolumeUSD)
+	if sol := cfg.Exchange.Solana; sol.WSURL != "ws://localhost:8900" || sol.RPCURL != "http://localhost:8899" || sol.Commitment != "processed" || len(sol.Pools) != 1 {
+		t.Fatalf("unexpected solana feed config: %+v", sol)
+	}
+	if pool := cfg.Exchange.Solana.Pools[0]; pool.Dex != "raydium" || pool.BaseDecimals != 6 || pool.QuoteDecimals != 9 || !pool.Invert {
+		t.Fatalf("unexpected solana pool: %+v", pool)
+	}
+	var trend struct {
+		Threshold    float64 `yaml:"threshold"`
+		WindowSecs   int     `yaml:"window_secs"`
+		MinVolumeUSD float64 `yaml:"min_volume_usd"`
+	}
+	if err := cfg.Strategy.Decode("trend_follow", &trend); err != nil {
+		t.Fatalf("decode trend_follow block: %v", err)
+	}
+	if cfg.Strategy.Mode != "obi_momentum" || trend.Threshold != 0.05 || trend.WindowSecs != 90 || trend.MinVolumeUSD != 1000 {
+		t.Fatalf("unexpected strategy config: mode %q trend %+v", cfg.Strategy.Mode, trend)
+	}
+	var strict struct {
+		Threshold float64 `yaml:"threshold"`
+	}
+	if err := cfg.Strategy.Decode("trend_follow", &strict); err == nil {
+		t.Fatalf("expected unknown block keys to be rejected")
 	}
 	if cfg.Risk.MaxPortfolioNotional != 100 {
 		t.Fatalf("unexpected max portfolio notional: %.2f", cfg.Risk.MaxPortfolioNotional)
 	}
+	if cfg.Risk.StaleAfterMs != 15000 || !cfg.Risk.FlagStalePositions {
+		t.Fatalf("unexpected staleness config: %+v", cfg.Risk)
+	}
 	if cfg.Dex.Commitment != "processed" {
 		t.Fatalf("expected processed commitment, got %s", cfg.Dex.Commitment)
 	}
@@ -81,6 +141,15 @@ func TestLoad(t *testing.T) {
 	if cfg.Paper.MaxPositionNotionalUSD != 200 {
 		t.Fatalf("expected max position notional 200, got %.2f", cfg.Paper.MaxPositionNotionalUSD)
 	}
+	if cfg.Live.Venue != "binance" || cfg.Live.QuoteAsset != "USDT" {
+		t.Fatalf("unexpected live venue: %+v", cfg.Live)
+	}
+	if !cfg.Live.Enabled || cfg.Live.SlippageBps != 75 || cfg.Live.ConfirmTimeoutMs != 30000 {
+		t.Fatalf("unexpected live config: %+v", cfg.Live)
+	}
+	if len(cfg.Live.Instruments) != 1 || cfg.Live.Instruments[0].Symbol != "BTCUSDT" || cfg.Live.Instruments[0].Decimals != 6 {
+		t.Fatalf("unexpected live instruments: %+v", cfg.Live.Instruments)
+	}
 }
 
 func TestLoadMissingFile(t *testing.T) {
@@ -13,3 +13,22 @@ type Dex struct {
 type Wallet struct {
 	PrivateKeyBase58 string `yaml:"private_key_base58"`
 }
+
+// Live gates real-money execution and maps feed symbols onto on-chain mints.
+type Live struct {
+	Enabled            bool             `yaml:"enabled"`
+	Venue              string           `yaml:"venue"`               // jupiter (default) or binance
+	QuoteAsset         string           `yaml:"quote_asset"`         // Binance asset that funds the account (USDT by default)
+	SettlementMint     string           `yaml:"settlement_mint"`     // USD-pegged mint spent on buys (USDC by default)
+	SettlementDecimals int              `yaml:"settlement_decimals"` // base-unit decimals of the settlement mint
+	SlippageBps        int              `yaml:"slippage_bps"`
+	ConfirmTimeoutMs   int              `yaml:"confirm_timeout_ms"`
+	Instruments        []LiveInstrument `yaml:"instruments"`
+}
+
+// LiveInstrument binds a feed symbol to the SPL mint traded for it.
+type LiveInstrument struct {
+	Symbol   string `yaml:"symbol"`
+	Mint     string `yaml:"mint"`
+	Decimals int    `yaml:"decimals"`
+}
@@ -0,0 +1,94 @@
+package solana
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	solana "github.com/gagliardetto/solana-go"
+)
+
+const (
+	// mintLen is the base SPL mint layout; Token-2022 extensions follow it and are ignored here.
+	mintLen = 82
+
+	// Raydium AMM v4 AmmInfo offsets: 16 u64 params, 8 u64 fees, then swap accounting before the pubkeys.
+	raydiumCoinVaultOffset = 336
+	raydiumPCVaultOffset   = 368
+	raydiumCoinMintOffset  = 400
+	raydiumPCMintOffset    = 432
+	raydiumLPMintOffset    = 464
+	raydiumLPReserveOffset = 720
+	raydiumPoolLen         = 752
+
+	// Whirlpool vault offsets follow sqrt_price, tick_current_index, fees owed, and each token mint.
+	whirlpoolVaultAOffset = 133
+	whirlpoolVaultBOffset = 213
+)
+
+// Mint is the SPL token mint state the screener cares about.
+type Mint struct {
+	MintAuthority   *solana.PublicKey // nil once minting has been revoked
+	Supply          uint64
+	Decimals        uint8
+	FreezeAuthority *solana.PublicKey // nil when holders cannot be frozen
+}
+
+// DecodeMint parses raw SPL Token or Token-2022 mint account data.
+func DecodeMint(data []byte) (Mint, error) {
+	if len(data) < mintLen {
+		return Mint{}, fmt.Errorf("mint account too short: %d bytes", len(data))
+	}
+	if data[45] == 0 {
+		return Mint{}, fmt.Errorf("mint account not initialized")
+	}
+	return Mint{
+		MintAuthority:   optionalKey(data[0:36]),
+		Supply:          binary.LittleEndian.Uint64(data[36:44]),
+		Decimals:        data[44],
+		FreezeAuthority: optionalKey(data[46:82]),
+	}, nil
+}
+
+// RaydiumPool holds the Raydium AMM v4 pool accounts used for safety checks.
+type RaydiumPool struct {
+	CoinVault solana.PublicKey
+	PCVault   solana.PublicKey
+	CoinMint  solana.PublicKey
+	PCMint    solana.PublicKey
+	LPMint    solana.PublicKey
+	LPReserve uint64 // LP tokens minted to liquidity providers; burning them shrinks the LP mint supply below this
+}
+
+// DecodeRaydiumPool parses raw Raydium AMM v4 pool account data.
+func DecodeRaydiumPool(data []byte) (RaydiumPool, error) {
+	if len(data) < raydiumPoolLen {
+		return RaydiumPool{}, fmt.Errorf("raydium pool account too short: %d bytes", len(data))
+	}
+	key := func(offset int) solana.PublicKey { return solana.PublicKeyFromBytes(data[offset : offset+32]) }
+	return RaydiumPool{
+		CoinVault: key(raydiumCoinVaultOffset),
+		PCVault:   key(raydiumPCVaultOffset),
+		CoinMint:  key(raydiumCoinMintOffset),
+		PCMint:    key(raydiumPCMintOffset),
+		LPMint:    key(raydiumLPMintOffset),
+		LPReserve: binary.LittleEndian.Uint64(data[raydiumLPReserveOffset:]),
+	}, nil
+}
+
+// WhirlpoolVaults returns the token A and token B vaults of a Whirlpool account.
+func WhirlpoolVaults(data []byte) (solana.PublicKey, solana.PublicKey, error) {
+	if len(data) < whirlpoolVaultBOffset+32 {
+		return solana.PublicKey{}, solana.PublicKey{}, fmt.Errorf("whirlpool account too short: %d bytes", len(data))
+	}
+	return solana.PublicKeyFromBytes(data[whirlpoolVaultAOffset : whirlpoolVaultAOffset+32]),
+		solana.PublicKeyFromBytes(data[whirlpoolVaultBOffset : whirlpoolVaultBOffset+32]), nil
+}
+
+// optionalKey decodes an SPL COption<Pubkey>: a u32 tag followed by the key.
+func optionalKey(raw []byte) *solana.PublicKey {
+	if binary.LittleEndian.Uint32(raw[:4]) == 0 {
+		return nil
+	}
+	key := solana.PublicKeyFromBytes(raw[4:36])
+	return &key
+}
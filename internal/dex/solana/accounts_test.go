@@ -0,0 +1,44 @@
+package solana
+
+import (
+	"testing"
+
+	solana "github.com/gagliardetto/solana-go"
+
+	"memebot-go/internal/dex/solana/solanatest"
+)
+
+func TestDecodeMint(t *testing.T) {
+	authority := solana.NewWallet().PublicKey()
+	mint, err := DecodeMint(solanatest.MintData(&authority, nil, 1_000_000, 6))
+	if err != nil {
+		t.Fatalf("DecodeMint returned error: %v", err)
+	}
+	if mint.MintAuthority == nil || !mint.MintAuthority.Equals(authority) || mint.FreezeAuthority != nil {
+		t.Fatalf("unexpected authorities %+v", mint)
+	}
+	if mint.Supply != 1_000_000 || mint.Decimals != 6 {
+		t.Fatalf("unexpected supply %d/%d", mint.Supply, mint.Decimals)
+	}
+	if _, err := DecodeMint(make([]byte, 40)); err == nil {
+		t.Fatalf("expected short mint data to fail")
+	}
+	if _, err := DecodeMint(make([]byte, mintLen)); err == nil {
+		t.Fatalf("expected uninitialized mint to fail")
+	}
+}
+
+func TestDecodeRaydiumPool(t *testing.T) {
+	keys := make([]solana.PublicKey, 5)
+	for i := range keys {
+		keys[i] = solana.NewWallet().PublicKey()
+	}
+	pool, err := DecodeRaydiumPool(solanatest.RaydiumPoolData(keys[0], keys[1], keys[2], keys[3], keys[4], 42))
+	if err != nil {
+		t.Fatalf("DecodeRaydiumPool returned error: %v", err)
+	}
+	want := RaydiumPool{CoinVault: keys[0], PCVault: keys[1], CoinMint: keys[2], PCMint: keys[3], LPMint: keys[4], LPReserve: 42}
+	if pool != want {
+		t.Fatalf("unexpected pool %+v", pool)
+	}
+}
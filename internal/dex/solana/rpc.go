@@ -0,0 +1,148 @@
+package solana
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	solana "github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// AwaitConfirmation polls signature status until the transaction reaches the client's commitment or fails.
+func (jupiterClient *JupiterClient) AwaitConfirmation(ctx context.Context, sig solana.Signature, poll time.Duration) error {
+	if poll <= 0 {
+		poll = 500 * time.Millisecond
+	}
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+	for {
+		out, err := jupiterClient.RPC.GetSignatureStatuses(ctx, false, sig)
+		if err == nil && len(out.Value) > 0 && out.Value[0] != nil {
+			status := out.Value[0]
+			if status.Err != nil {
+				return fmt.Errorf("transaction %s failed: %v", sig, status.Err)
+			}
+			if commitmentReached(status.ConfirmationStatus, jupiterClient.Commit) {
+				return nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("await confirmation %s: %w", sig, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// TokenDeltas returns the owner's net change in raw base units per mint for a landed transaction, read from
+// its pre/post token balances; mints whose balance did not change are absent. It polls until the transaction
+// is visible at the client's commitment (at least confirmed) or ctx ends.
+func (jupiterClient *JupiterClient) TokenDeltas(ctx context.Context, sig solana.Signature, poll time.Duration) (map[string]int64, error) {
+	if poll <= 0 {
+		poll = 500 * time.Millisecond
+	}
+	commit := jupiterClient.Commit
+	if commit == rpc.CommitmentProcessed {
+		commit = rpc.CommitmentConfirmed // getTransaction does not serve processed
+	}
+	maxVersion := uint64(0)
+	opts := &rpc.GetTransactionOpts{Encoding: solana.EncodingBase64, Commitment: commit, MaxSupportedTransactionVersion: &maxVersion}
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+	for {
+		out, err := jupiterClient.RPC.GetTransaction(ctx, sig, opts)
+		if err == nil && out.Meta != nil {
+			return ownerTokenDeltas(jupiterClient.Owner.PublicKey(), out.Meta)
+		}
+		if err != nil && !errors.Is(err, rpc.ErrNotFound) {
+			return nil, fmt.Errorf("get transaction %s: %w", sig, err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("get transaction %s: %w", sig, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func ownerTokenDeltas(owner solana.PublicKey, meta *rpc.TransactionMeta) (map[string]int64, error) {
+	totals := func(balances []rpc.TokenBalance) (map[string]uint64, error) {
+		out := make(map[string]uint64)
+		for _, balance := range balances {
+			if balance.Owner == nil || !balance.Owner.Equals(owner) || balance.UiTokenAmount == nil {
+				continue
+			}
+			amount, err := strconv.ParseUint(balance.UiTokenAmount.Amount, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("parse token balance: %w", err)
+			}
+			out[balance.Mint.String()] += amount
+		}
+		return out, nil
+	}
+	pre, err := totals(meta.PreTokenBalances)
+	if err != nil {
+		return nil, err
+	}
+	post, err := totals(meta.PostTokenBalances)
+	if err != nil {
+		return nil, err
+	}
+	deltas := make(map[string]int64)
+	for mint, amount := range post {
+		if amount != pre[mint] {
+			// Wrapping subtraction yields the signed change; wallet deltas are far below 2^63.
+			deltas[mint] = int64(amount - pre[mint])
+		}
+	}
+	for mint, amount := range pre {
+		if _, ok := post[mint]; !ok && amount > 0 {
+			deltas[mint] = -int64(amount)
+		}
+	}
+	return deltas, nil
+}
+
+// TokenBalance returns the raw base-unit balance held in the owner's associated token account for mint.
+func (jupiterClient *JupiterClient) TokenBalance(ctx context.Context, mint solana.PublicKey) (uint64, error) {
+	ata, _, err := solana.FindAssociatedTokenAddress(jupiterClient.Owner.PublicKey(), mint)
+	if err != nil {
+		return 0, fmt.Errorf("derive token account: %w", err)
+	}
+	out, err := jupiterClient.RPC.GetTokenAccountBalance(ctx, ata, jupiterClient.Commit)
+	if err != nil {
+		// A wallet that never held the mint has no token account yet.
+		if strings.Contains(err.Error(), "could not find account") {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if out == nil || out.Value == nil {
+		return 0, nil
+	}
+	return strconv.ParseUint(out.Value.Amount, 10, 64)
+}
+
+// NativeBalance returns the owner's SOL balance in lamports.
+func (jupiterClient *JupiterClient) NativeBalance(ctx context.Context) (uint64, error) {
+	out, err := jupiterClient.RPC.GetBalance(ctx, jupiterClient.Owner.PublicKey(), jupiterClient.Commit)
+	if err != nil {
+		return 0, err
+	}
+	return out.Value, nil
+}
+
+func commitmentReached(status rpc.ConfirmationStatusType, want rpc.CommitmentType) bool {
+	switch want {
+	case rpc.CommitmentProcessed:
+		return status != ""
+	case rpc.CommitmentFinalized:
+		return status == rpc.ConfirmationStatusFinalized
+	default:
+		return status == rpc.ConfirmationStatusConfirmed || status == rpc.ConfirmationStatusFinalized
+	}
+}
@@ -0,0 +1,62 @@
+package solana
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	solana "github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+
+	"memebot-go/internal/dex/solana/solanatest"
+)
+
+func TestCommitmentReached(t *testing.T) {
+	if commitmentReached(rpc.ConfirmationStatusProcessed, rpc.CommitmentConfirmed) {
+		t.Fatalf("processed should not satisfy confirmed")
+	}
+	if !commitmentReached(rpc.ConfirmationStatusFinalized, rpc.CommitmentConfirmed) {
+		t.Fatalf("finalized should satisfy confirmed")
+	}
+	if commitmentReached(rpc.ConfirmationStatusConfirmed, rpc.CommitmentFinalized) {
+		t.Fatalf("confirmed should not satisfy finalized")
+	}
+}
+
+func TestSwapConfirmationAndBalances(t *testing.T) {
+	server := solanatest.NewServer()
+	defer server.Close()
+	wallet := solana.NewWallet()
+	mint := solana.NewWallet().PublicKey()
+	server.SetNativeBalance(42)
+	server.SetTokenBalance(wallet.PublicKey(), mint, 1500)
+
+	client := NewJupiterClient(server.RPCURL(), server.JupiterBase(), wallet.PrivateKey, "confirmed")
+	ctx := context.Background()
+	quote, err := client.GetQuote(ctx, mint.String(), solana.SolMint.String(), 10, 50)
+	if err != nil {
+		t.Fatalf("GetQuote returned error: %v", err)
+	}
+	sig, err := client.BuildAndSendSwap(ctx, quote)
+	if err != nil {
+		t.Fatalf("BuildAndSendSwap returned error: %v", err)
+	}
+	if err := client.AwaitConfirmation(ctx, sig, time.Millisecond); err != nil {
+		t.Fatalf("AwaitConfirmation returned error: %v", err)
+	}
+
+	if lamports, err := client.NativeBalance(ctx); err != nil || lamports != 42 {
+		t.Fatalf("unexpected native balance %d (err %v)", lamports, err)
+	}
+	if amount, err := client.TokenBalance(ctx, mint); err != nil || amount != 1500 {
+		t.Fatalf("unexpected token balance %d (err %v)", amount, err)
+	}
+	if amount, err := client.TokenBalance(ctx, solana.NewWallet().PublicKey()); err != nil || amount != 0 {
+		t.Fatalf("expected missing token account to read as zero, got %d (err %v)", amount, err)
+	}
+
+	server.FailSwaps(true)
+	if err := client.AwaitConfirmation(ctx, sig, time.Millisecond); err == nil {
+		t.Fatalf("expected failed transaction to surface an error")
+	}
+}
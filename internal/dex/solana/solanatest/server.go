@@ -0,0 +1,410 @@
+// Package solanatest provides an in-process stand-in for the Jupiter API and Solana JSON-RPC used by tests.
+package solanatest
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+)
+
+// QuoteFunc returns the output amount Jupiter would quote for an exact-in swap.
+type QuoteFunc func(inputMint, outputMint string, amount uint64) uint64
+
+// Server answers /v6/quote and /v6/swap like Jupiter and JSON-RPC POSTs on /rpc like a Solana node.
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	quote         QuoteFunc
+	lamports      uint64
+	tokenAccounts map[string]uint64
+	accounts      map[string]account
+	largest       map[string][]Holder
+	failSwaps     bool
+	unconfirmed   bool
+	sent          []solana.Signature
+	execute       func(quotedOut uint64) uint64
+	built         *settlement                     // swap built by the latest /v6/swap call, claimed by the next send
+	settled       map[solana.Signature]settlement // token movements reported by getTransaction
+}
+
+// settlement is the wallet-side result of one swap: inAmount of inputMint spent, outAmount of outputMint received.
+type settlement struct {
+	owner      solana.PublicKey
+	inputMint  string
+	outputMint string
+	inAmount   uint64
+	outAmount  uint64
+}
+
+// NewServer starts a stand-in whose quotes echo the input amount until SetQuote is called.
+func NewServer() *Server {
+	s := &Server{
+		quote:         func(_, _ string, amount uint64) uint64 { return amount },
+		tokenAccounts: make(map[string]uint64),
+		accounts:      make(map[string]account),
+		largest:       make(map[string][]Holder),
+		execute:       func(quotedOut uint64) uint64 { return quotedOut },
+		settled:       make(map[solana.Signature]settlement),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v6/quote", s.handleQuote)
+	mux.HandleFunc("/v6/swap", s.handleSwap)
+	mux.HandleFunc("/rpc", s.handleRPC)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// JupiterBase is the base URL to hand to dex.NewJupiterClient.
+func (s *Server) JupiterBase() string { return s.URL }
+
+// RPCURL is the JSON-RPC endpoint to hand to dex.NewJupiterClient.
+func (s *Server) RPCURL() string { return s.URL + "/rpc" }
+
+// SetQuote overrides how quotes are priced.
+func (s *Server) SetQuote(fn QuoteFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.quote = fn
+}
+
+// SetExecution overrides the output a landed swap actually delivers for a quoted output, e.g. to model slippage.
+func (s *Server) SetExecution(fn func(quotedOut uint64) uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.execute = fn
+}
+
+// SetNativeBalance sets the lamports reported by getBalance.
+func (s *Server) SetNativeBalance(lamports uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lamports = lamports
+}
+
+// SetTokenBalance funds owner's associated token account for mint with a raw base-unit amount.
+func (s *Server) SetTokenBalance(owner, mint solana.PublicKey, amount uint64) {
+	ata, _, err := solana.FindAssociatedTokenAddress(owner, mint)
+	if err != nil {
+		panic(err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokenAccounts[ata.String()] = amount
+}
+
+type account struct {
+	owner solana.PublicKey
+	data  []byte
+}
+
+// Holder is one token account reported by getTokenLargestAccounts.
+type Holder struct {
+	Address solana.PublicKey
+	Amount  uint64
+}
+
+// SetAccount stores raw account data returned by getAccountInfo.
+func (s *Server) SetAccount(address, owner solana.PublicKey, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accounts[address.String()] = account{owner: owner, data: append([]byte(nil), data...)}
+}
+
+// SetLargestAccounts sets the holders getTokenLargestAccounts reports for mint, largest first.
+func (s *Server) SetLargestAccounts(mint solana.PublicKey, holders ...Holder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.largest[mint.String()] = append([]Holder(nil), holders...)
+}
+
+// MintData encodes an initialized SPL mint; nil authorities are stored as revoked.
+func MintData(mintAuthority, freezeAuthority *solana.PublicKey, supply uint64, decimals uint8) []byte {
+	data := make([]byte, 82)
+	putOptionalKey(data[0:36], mintAuthority)
+	binary.LittleEndian.PutUint64(data[36:44], supply)
+	data[44] = decimals
+	data[45] = 1
+	putOptionalKey(data[46:82], freezeAuthority)
+	return data
+}
+
+// RaydiumPoolData encodes the Raydium AMM v4 pool fields read by the discovery screener.
+func RaydiumPoolData(coinVault, pcVault, coinMint, pcMint, lpMint solana.PublicKey, lpReserve uint64) []byte {
+	data := make([]byte, 752)
+	copy(data[336:], coinVault[:])
+	copy(data[368:], pcVault[:])
+	copy(data[400:], coinMint[:])
+	copy(data[432:], pcMint[:])
+	copy(data[464:], lpMint[:])
+	binary.LittleEndian.PutUint64(data[720:], lpReserve)
+	return data
+}
+
+func putOptionalKey(dst []byte, key *solana.PublicKey) {
+	if key == nil {
+		return
+	}
+	binary.LittleEndian.PutUint32(dst[:4], 1)
+	copy(dst[4:], key[:])
+}
+
+// FailSwaps makes every subsequently sent transaction report an on-chain error.
+func (s *Server) FailSwaps(fail bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failSwaps = fail
+}
+
+// HoldConfirmations makes sent transactions invisible to getSignatureStatuses and getTransaction until released,
+// as if they had not landed yet.
+func (s *Server) HoldConfirmations(hold bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.unconfirmed = hold
+}
+
+// Sent lists the signatures of transactions submitted via sendTransaction.
+func (s *Server) Sent() []solana.Signature {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]solana.Signature(nil), s.sent...)
+}
+
+func (s *Server) handleQuote(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	amount, err := strconv.ParseUint(query.Get("amount"), 10, 64)
+	if err != nil {
+		http.Error(w, "bad amount", http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	out := s.quote(query.Get("inputMint"), query.Get("outputMint"), amount)
+	s.mu.Unlock()
+	slippage, _ := strconv.Atoi(query.Get("slippageBps"))
+	writeJSON(w, map[string]any{
+		"inputMint":            query.Get("inputMint"),
+		"outputMint":           query.Get("outputMint"),
+		"inAmount":             strconv.FormatUint(amount, 10),
+		"outAmount":            strconv.FormatUint(out, 10),
+		"otherAmountThreshold": strconv.FormatUint(out, 10),
+		"slippageBps":          slippage,
+		"routePlan":            []any{},
+		"priceImpactPct":       0,
+	})
+}
+
+// handleSwap returns an unsigned transfer-to-self paid by the requesting wallet.
+func (s *Server) handleSwap(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserPublicKey string `json:"userPublicKey"`
+		QuoteResponse struct {
+			InputMint  string `json:"inputMint"`
+			OutputMint string `json:"outputMint"`
+			InAmount   string `json:"inAmount"`
+			OutAmount  string `json:"outAmount"`
+		} `json:"quoteResponse"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	owner, err := solana.PublicKeyFromBase58(req.UserPublicKey)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	in, _ := strconv.ParseUint(req.QuoteResponse.InAmount, 10, 64)
+	out, _ := strconv.ParseUint(req.QuoteResponse.OutAmount, 10, 64)
+	s.mu.Lock()
+	s.built = &settlement{owner: owner, inputMint: req.QuoteResponse.InputMint, outputMint: req.QuoteResponse.OutputMint, inAmount: in, outAmount: s.execute(out)}
+	s.mu.Unlock()
+	tx, err := solana.NewTransaction(
+		[]solana.Instruction{system.NewTransferInstruction(1, owner, owner).Build()},
+		solana.Hash{},
+		solana.TransactionPayer(owner),
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, map[string]any{"swapTransaction": base64.StdEncoding.EncodeToString(raw)})
+}
+
+type rpcRequest struct {
+	ID     any               `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+func (s *Server) handleRPC(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req rpcRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	result, rpcErr := s.dispatch(req)
+	resp := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+	if rpcErr != nil {
+		resp["error"] = map[string]any{"code": -32602, "message": rpcErr.Error()}
+	} else {
+		resp["result"] = result
+	}
+	writeJSON(w, resp)
+}
+
+func (s *Server) dispatch(req rpcRequest) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rpcContext := map[string]any{"slot": 1}
+	switch req.Method {
+	case "sendTransaction":
+		var encoded string
+		if len(req.Params) == 0 || json.Unmarshal(req.Params[0], &encoded) != nil {
+			return nil, fmt.Errorf("Invalid params: missing transaction")
+		}
+		raw, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid params: %v", err)
+		}
+		tx, err := solana.TransactionFromDecoder(bin.NewBinDecoder(raw))
+		if err != nil || len(tx.Signatures) == 0 {
+			return nil, fmt.Errorf("Invalid params: unsigned transaction")
+		}
+		s.sent = append(s.sent, tx.Signatures[0])
+		if s.built != nil {
+			s.settled[tx.Signatures[0]] = *s.built
+			s.built = nil
+		}
+		return tx.Signatures[0].String(), nil
+	case "getTransaction":
+		var sig string
+		if len(req.Params) > 0 {
+			_ = json.Unmarshal(req.Params[0], &sig)
+		}
+		key, err := solana.SignatureFromBase58(sig)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid param: %v", err)
+		}
+		swap, ok := s.settled[key]
+		if !ok || s.unconfirmed {
+			return nil, nil
+		}
+		// Index 1 is the input token account, index 2 the output one; the input is spent in full.
+		pre := []any{tokenBalance(1, swap.owner, swap.inputMint, swap.inAmount)}
+		post := []any{tokenBalance(1, swap.owner, swap.inputMint, 0), tokenBalance(2, swap.owner, swap.outputMint, swap.outAmount)}
+		return map[string]any{"slot": 1, "blockTime": nil, "transaction": nil, "meta": map[string]any{
+			"err": nil, "fee": 5000, "preBalances": []any{}, "postBalances": []any{},
+			"preTokenBalances": pre, "postTokenBalances": post,
+		}}, nil
+	case "getSignatureStatuses":
+		var sigs []string
+		if len(req.Params) > 0 {
+			_ = json.Unmarshal(req.Params[0], &sigs)
+		}
+		statuses := make([]any, len(sigs))
+		for i := range sigs {
+			if s.unconfirmed {
+				continue
+			}
+			status := map[string]any{"slot": 1, "confirmations": nil, "err": nil, "confirmationStatus": "confirmed"}
+			if s.failSwaps {
+				status["err"] = map[string]any{"InstructionError": []any{0, "Custom"}}
+			}
+			statuses[i] = status
+		}
+		return map[string]any{"context": rpcContext, "value": statuses}, nil
+	case "getTokenAccountBalance":
+		var account string
+		if len(req.Params) > 0 {
+			_ = json.Unmarshal(req.Params[0], &account)
+		}
+		amount, ok := s.tokenAccounts[account]
+		if !ok {
+			return nil, fmt.Errorf("Invalid param: could not find account")
+		}
+		return map[string]any{"context": rpcContext, "value": map[string]any{
+			"amount":         strconv.FormatUint(amount, 10),
+			"decimals":       0,
+			"uiAmountString": strconv.FormatUint(amount, 10),
+		}}, nil
+	case "getAccountInfo":
+		var address string
+		if len(req.Params) > 0 {
+			_ = json.Unmarshal(req.Params[0], &address)
+		}
+		acct, ok := s.accounts[address]
+		if !ok {
+			return map[string]any{"context": rpcContext, "value": nil}, nil
+		}
+		return map[string]any{"context": rpcContext, "value": map[string]any{
+			"data":       []string{base64.StdEncoding.EncodeToString(acct.data), "base64"},
+			"executable": false,
+			"lamports":   1,
+			"owner":      acct.owner.String(),
+			"rentEpoch":  0,
+			"space":      len(acct.data),
+		}}, nil
+	case "getTokenLargestAccounts":
+		var mint string
+		if len(req.Params) > 0 {
+			_ = json.Unmarshal(req.Params[0], &mint)
+		}
+		holders, ok := s.largest[mint]
+		if !ok {
+			return nil, fmt.Errorf("Invalid param: not a Token mint")
+		}
+		value := make([]any, len(holders))
+		for i, holder := range holders {
+			value[i] = map[string]any{
+				"address":        holder.Address.String(),
+				"amount":         strconv.FormatUint(holder.Amount, 10),
+				"decimals":       0,
+				"uiAmountString": strconv.FormatUint(holder.Amount, 10),
+			}
+		}
+		return map[string]any{"context": rpcContext, "value": value}, nil
+	case "getBalance":
+		return map[string]any{"context": rpcContext, "value": s.lamports}, nil
+	default:
+		return nil, fmt.Errorf("method %s not supported by stand-in", req.Method)
+	}
+}
+
+func tokenBalance(index int, owner solana.PublicKey, mint string, amount uint64) map[string]any {
+	return map[string]any{
+		"accountIndex": index,
+		"owner":        owner.String(),
+		"mint":         mint,
+		"uiTokenAmount": map[string]any{
+			"amount":         strconv.FormatUint(amount, 10),
+			"decimals":       0,
+			"uiAmountString": strconv.FormatUint(amount, 10),
+		},
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,137 @@
+package solana
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"math"
+	"math/big"
+	"strings"
+
+	solana "github.com/gagliardetto/solana-go"
+)
+
+const (
+	// RaydiumAMMProgramID is the Raydium AMM v4 program whose swaps emit ray_log lines.
+	RaydiumAMMProgramID = "675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8"
+	// WhirlpoolProgramID is the Orca Whirlpool program that emits Traded events.
+	WhirlpoolProgramID = "whirLbMiicVdio4qvUfM5KAg6Ct8VwpYzGff3uctyCc"
+
+	rayLogPrefix      = "Program log: ray_log: "
+	programDataPrefix = "Program data: "
+
+	raydiumSwapBaseIn  = 3
+	raydiumSwapBaseOut = 4
+	// raydiumPC2Coin is the swap direction where the trader pays the pc (quote) token for coin.
+	raydiumPC2Coin = 1
+
+	// whirlpoolSqrtPriceOffset locates sqrt_price (u128, Q64.64) in a Whirlpool account:
+	// 8 discriminator + 32 config + 1 bump + 2 tick spacing + 2 seed + 2 fee + 2 protocol fee + 16 liquidity.
+	whirlpoolSqrtPriceOffset = 65
+)
+
+// whirlpoolTradedDiscriminator is the Anchor event discriminator for Whirlpool's Traded event.
+var whirlpoolTradedDiscriminator = func() [8]byte {
+	sum := sha256.Sum256([]byte("event:Traded"))
+	var out [8]byte
+	copy(out[:], sum[:8])
+	return out
+}()
+
+// PoolSwap is a decoded swap in pool token order: token A is the Raydium coin or Whirlpool token A.
+type PoolSwap struct {
+	Pool    solana.PublicKey // zero for Raydium, whose logs do not name the pool
+	AmountA uint64
+	AmountB uint64
+	AToB    bool // trader paid token A and received token B
+}
+
+// DecodeRaydiumSwaps extracts SwapBaseIn/SwapBaseOut events from the ray_log lines of a transaction.
+func DecodeRaydiumSwaps(logs []string) []PoolSwap {
+	var out []PoolSwap
+	for _, line := range logs {
+		encoded, ok := strings.CutPrefix(line, rayLogPrefix)
+		if !ok {
+			continue
+		}
+		raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil || len(raw) < 57 {
+			continue
+		}
+		// Both swap logs are log_type u8 followed by seven u64 fields.
+		field := func(i int) uint64 { return binary.LittleEndian.Uint64(raw[1+8*i:]) }
+		direction := field(2)
+		swap := PoolSwap{AToB: direction != raydiumPC2Coin}
+		switch raw[0] {
+		case raydiumSwapBaseIn: // amount_in, minimum_out, direction, user_source, pool_coin, pool_pc, out_amount
+			in, outAmt := field(0), field(6)
+			if swap.AToB {
+				swap.AmountA, swap.AmountB = in, outAmt
+			} else {
+				swap.AmountA, swap.AmountB = outAmt, in
+			}
+		case raydiumSwapBaseOut: // max_in, amount_out, direction, user_source, pool_coin, pool_pc, deduct_in
+			in, outAmt := field(6), field(1)
+			if swap.AToB {
+				swap.AmountA, swap.AmountB = in, outAmt
+			} else {
+				swap.AmountA, swap.AmountB = outAmt, in
+			}
+		default:
+			continue
+		}
+		if swap.AmountA == 0 || swap.AmountB == 0 {
+			continue
+		}
+		out = append(out, swap)
+	}
+	return out
+}
+
+// DecodeWhirlpoolTrades extracts Traded events from the "Program data:" lines of a transaction.
+func DecodeWhirlpoolTrades(logs []string) []PoolSwap {
+	var out []PoolSwap
+	for _, line := range logs {
+		encoded, ok := strings.CutPrefix(line, programDataPrefix)
+		if !ok {
+			continue
+		}
+		raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		// discriminator 8 + whirlpool 32 + a_to_b 1 + pre/post sqrt price 32 + input/output amounts 16.
+		if err != nil || len(raw) < 89 || [8]byte(raw[:8]) != whirlpoolTradedDiscriminator {
+			continue
+		}
+		swap := PoolSwap{Pool: solana.PublicKeyFromBytes(raw[8:40]), AToB: raw[40] == 1}
+		input := binary.LittleEndian.Uint64(raw[73:81])
+		output := binary.LittleEndian.Uint64(raw[81:89])
+		if swap.AToB {
+			swap.AmountA, swap.AmountB = input, output
+		} else {
+			swap.AmountA, swap.AmountB = output, input
+		}
+		if swap.AmountA == 0 || swap.AmountB == 0 {
+			continue
+		}
+		out = append(out, swap)
+	}
+	return out
+}
+
+// WhirlpoolPrice reads sqrt_price from raw Whirlpool account data and returns the raw token B per token A price.
+func WhirlpoolPrice(data []byte) (float64, bool) {
+	if len(data) < whirlpoolSqrtPriceOffset+16 {
+		return 0, false
+	}
+	lo := binary.LittleEndian.Uint64(data[whirlpoolSqrtPriceOffset:])
+	hi := binary.LittleEndian.Uint64(data[whirlpoolSqrtPriceOffset+8:])
+	sqrt := new(big.Float).SetUint64(hi)
+	sqrt.Mul(sqrt, new(big.Float).SetFloat64(math.Exp2(64)))
+	sqrt.Add(sqrt, new(big.Float).SetUint64(lo))
+	ratio, _ := sqrt.Float64()
+	ratio /= math.Exp2(64)
+	price := ratio * ratio
+	if price <= 0 || math.IsInf(price, 0) {
+		return 0, false
+	}
+	return price, true
+}
@@ -0,0 +1,65 @@
+package solana
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	solana "github.com/gagliardetto/solana-go"
+)
+
+func rayLog(logType byte, fields ...uint64) string {
+	raw := []byte{logType}
+	for _, field := range fields {
+		raw = binary.LittleEndian.AppendUint64(raw, field)
+	}
+	return rayLogPrefix + base64.StdEncoding.EncodeToString(raw)
+}
+
+func TestDecodeRaydiumSwaps(t *testing.T) {
+	logs := []string{
+		"Program 675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8 invoke [1]",
+		rayLog(raydiumSwapBaseIn, 2_000_000_000, 0, raydiumPC2Coin, 0, 1, 1, 1_000_000_000),
+		rayLog(raydiumSwapBaseOut, 300, 500_000_000, 2, 0, 1, 1, 250_000_000),
+		rayLog(1, 1, 2, 3, 4, 5, 6, 7), // deposit
+		"Program log: ray_log: not-base64!",
+	}
+	swaps := DecodeRaydiumSwaps(logs)
+	if len(swaps) != 2 {
+		t.Fatalf("expected two swaps, got %+v", swaps)
+	}
+	if buy := swaps[0]; buy.AToB || buy.AmountA != 1_000_000_000 || buy.AmountB != 2_000_000_000 {
+		t.Fatalf("unexpected pc->coin swap %+v", buy)
+	}
+	if sell := swaps[1]; !sell.AToB || sell.AmountA != 250_000_000 || sell.AmountB != 500_000_000 {
+		t.Fatalf("unexpected coin->pc swap %+v", sell)
+	}
+}
+
+func TestDecodeWhirlpoolTradesAndPrice(t *testing.T) {
+	pool := solana.MustPublicKeyFromBase58("Czfq3xZZDmsdGdUyrNLtRhGc47cXcZtLG4crryfu44zE")
+	sqrt := uint64(math.Sqrt(0.15) * math.Exp2(32)) // Q64.64 low word only, upper word zero
+	raw := append(whirlpoolTradedDiscriminator[:], pool[:]...)
+	raw = append(raw, 0) // b -> a
+	for _, v := range []uint64{sqrt << 32, 0, sqrt << 32, 0, 150_000_000, 1_000_000_000, 0, 0, 3000, 300} {
+		raw = binary.LittleEndian.AppendUint64(raw, v)
+	}
+	swaps := DecodeWhirlpoolTrades([]string{"Program log: Instruction: Swap", programDataPrefix + base64.StdEncoding.EncodeToString(raw)})
+	if len(swaps) != 1 {
+		t.Fatalf("expected one trade, got %+v", swaps)
+	}
+	if got := swaps[0]; got.Pool != pool || got.AToB || got.AmountA != 1_000_000_000 || got.AmountB != 150_000_000 {
+		t.Fatalf("unexpected trade %+v", got)
+	}
+
+	account := make([]byte, 653)
+	binary.LittleEndian.PutUint64(account[whirlpoolSqrtPriceOffset:], sqrt<<32)
+	price, ok := WhirlpoolPrice(account)
+	if !ok || math.Abs(price-0.15) > 1e-6 {
+		t.Fatalf("expected raw price 0.15, got %.8f (ok=%v)", price, ok)
+	}
+	if _, ok := WhirlpoolPrice(account[:70]); ok {
+		t.Fatalf("expected short account data to be rejected")
+	}
+}
@@ -0,0 +1,637 @@
+// Package engine runs the tick -> signal -> sizing -> risk -> execution -> account pipeline shared by every binary.
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"memebot-go/internal/bars"
+	"memebot-go/internal/execution"
+	"memebot-go/internal/metrics"
+	"memebot-go/internal/paper"
+	"memebot-go/internal/risk"
+	"memebot-go/internal/signal"
+	"memebot-go/internal/strategy"
+)
+
+// ErrHalted is returned by Run once a risk kill switch has flattened the book and paused trading.
+var ErrHalted = errors.New("engine halted")
+
+// Source streams market data into the engine (satisfied by *exchange.Feed).
+type Source interface {
+	Run(ctx context.Context, out chan<- signal.Tick) error
+}
+
+// Submitter routes orders and returns the resulting fills (satisfied by any execution.Venue).
+type Submitter interface {
+	Submit(ctx context.Context, order execution.Order) ([]execution.Fill, error)
+}
+
+// Observer receives notifications as the engine processes ticks.
+type Observer interface {
+	OnSignal(sig signal.Signal)
+	OnFill(order execution.Order, fill execution.Fill, realizedPnL float64)
+	OnOrder(order execution.Order, filledQty float64)
+	OnHalt(reason string)
+}
+
+// Freshness reports when market data last arrived for a symbol (satisfied by *exchange.Feed and *exchange.Composite).
+type Freshness interface {
+	LastTick(symbol string) (time.Time, bool)
+}
+
+// BarObserver is an optional Observer extension notified of every closed bar.
+type BarObserver interface {
+	OnBar(bar signal.Bar)
+}
+
+// Option configures optional engine collaborators.
+type Option func(*Engine)
+
+// WithLedger stores every accepted fill in the in-memory ledger.
+func WithLedger(ledger *paper.Ledger) Option {
+	return func(e *Engine) { e.ledger = ledger }
+}
+
+// WithFillRecorder persists every accepted fill (e.g. paper.JSONLRecorder).
+func WithFillRecorder(rec paper.FillRecorder) Option {
+	return func(e *Engine) { e.recorder = rec }
+}
+
+// WithObserver registers a listener for signals, fills, orders, and halts.
+func WithObserver(obs Observer) Option {
+	return func(e *Engine) {
+		if obs != nil {
+			e.observers = append(e.observers, obs)
+		}
+	}
+}
+
+// WithTickBuffer sizes the channel between the feed and the trading loop.
+func WithTickBuffer(n int) Option {
+	return func(e *Engine) {
+		if n > 0 {
+			e.tickBuffer = n
+		}
+	}
+}
+
+// WithBars aggregates ticks into OHLCV bars for the given timeframes so observers implementing
+// BarObserver receive them; timeframes requested by a strategy.BarConsumer are added automatically.
+func WithBars(timeframes ...time.Duration) Option {
+	return func(e *Engine) { e.barTimeframes = append(e.barTimeframes, timeframes...) }
+}
+
+// WithStaleAfter refuses new entries on symbols whose latest tick in src is older than after;
+// with flagPositions the trading loop also flags open positions whose data goes stale.
+func WithStaleAfter(src Freshness, after time.Duration, flagPositions bool) Option {
+	return func(e *Engine) {
+		if src == nil || after <= 0 {
+			return
+		}
+		e.freshness = src
+		e.staleAfter = after
+		e.flagStale = flagPositions
+	}
+}
+
+// Engine owns trading state (marks, peak equity, halt flag) and drives the pipeline tick by tick.
+type Engine struct {
+	log        zerolog.Logger
+	feed       Source
+	strat      strategy.Strategy
+	limits     risk.Limits
+	exec       Submitter
+	account    *paper.Account
+	ledger     *paper.Ledger
+	recorder   paper.FillRecorder
+	observers  []Observer
+	tickBuffer int
+	freshness  Freshness
+	staleAfter time.Duration
+	flagStale  bool
+	now        func() time.Time
+
+	barTimeframes []time.Duration
+	bars          *bars.Builder
+	barStrat      strategy.BarConsumer
+
+	mu         sync.RWMutex
+	marks      map[string]float64
+	peakEquity float64
+	halted     bool
+	haltReason string
+	stale      map[string]bool // open positions currently flagged as stale
+	pending    map[string]int  // orders in flight per symbol
+}
+
+// New wires the engine dependencies; feed may be nil when ticks are pushed through Process directly.
+func New(log zerolog.Logger, feed Source, strat strategy.Strategy, limits risk.Limits, exec Submitter, account *paper.Account, opts ...Option) *Engine {
+	e := &Engine{
+		log:        log,
+		feed:       feed,
+		strat:      strat,
+		limits:     limits,
+		exec:       exec,
+		account:    account,
+		tickBuffer: 1024,
+		now:        time.Now,
+		marks:      make(map[string]float64),
+		pending:    make(map[string]int),
+		peakEquity: account.StartingCash(),
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if consumer, ok := strat.(strategy.BarConsumer); ok {
+		e.barStrat = consumer
+		e.barTimeframes = append(e.barTimeframes, consumer.Timeframes()...)
+	}
+	if len(e.barTimeframes) > 0 {
+		e.bars = bars.NewBuilder(e.barTimeframes...)
+	}
+	return e
+}
+
+// Run streams the feed into the trading loop until the context ends, the feed stops, or a kill switch trips.
+func (e *Engine) Run(ctx context.Context) error {
+	if e.feed == nil {
+		return errors.New("engine has no feed")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ticks := make(chan signal.Tick, e.tickBuffer)
+	feedErr := make(chan error, 1)
+	go func() {
+		err := e.feed.Run(ctx, ticks)
+		close(ticks)
+		feedErr <- err
+	}()
+
+	var staleCheck <-chan time.Time
+	if e.flagStale {
+		ticker := time.NewTicker(e.staleAfter / 2)
+		defer ticker.Stop()
+		staleCheck = ticker.C
+	}
+
+	e.log.Info().Str("strategy", e.strat.Name()).Msg("trading engine started")
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-staleCheck:
+			e.flagStalePositions()
+		case tk, ok := <-ticks:
+			if !ok {
+				err := <-feedErr
+				if err != nil && !errors.Is(err, context.Canceled) {
+					return fmt.Errorf("feed stopped: %w", err)
+				}
+				return nil
+			}
+			e.Process(ctx, tk)
+			if halted, reason := e.Halted(); halted {
+				return fmt.Errorf("%w: %s", ErrHalted, reason)
+			}
+		}
+	}
+}
+
+// Process runs a single tick through the pipeline; once halted only marks are updated.
+func (e *Engine) Process(ctx context.Context, tk signal.Tick) {
+	if tk.Price <= 0 {
+		return
+	}
+	e.setMark(tk.Symbol, tk.Price)
+	closedBars := e.updateBars(tk)
+	if e.halted {
+		return
+	}
+
+	// Check drawdowns before trading.
+	currentSnap := e.account.Snapshot(e.marks)
+	e.observeEquity(currentSnap.Equity)
+	if e.limits.DailyLossBreached(e.account.RealizedPnL()) {
+		e.terminate(ctx, "daily loss limit reached")
+		return
+	}
+	if e.limits.Breached(e.account.StartingCash(), currentSnap.Equity) {
+		e.terminate(ctx, "drawdown limit reached")
+		return
+	}
+	if e.limits.IntraTradeBreached(e.peakEquity, currentSnap.Equity) {
+		e.terminate(ctx, "intratrade drawdown reached")
+		return
+	}
+
+	// Strategy -> Signal, first from the tick, then from any bars it closed.
+	e.act(ctx, tk, e.strat.OnTick(tk), currentSnap)
+	if e.barStrat == nil {
+		return
+	}
+	for _, bar := range closedBars {
+		if e.halted {
+			return
+		}
+		barTick := signal.Tick{Provider: bar.Provider, Symbol: bar.Symbol, Price: bar.Close, Ts: bar.End()}
+		e.act(ctx, barTick, e.barStrat.OnBar(bar), e.account.Snapshot(e.marks))
+	}
+}
+
+// updateBars folds the tick into the bar builder and notifies bar observers of every bar it closed.
+func (e *Engine) updateBars(tk signal.Tick) []signal.Bar {
+	if e.bars == nil {
+		return nil
+	}
+	closed := e.bars.Update(tk)
+	for _, bar := range closed {
+		for _, obs := range e.observers {
+			if barObs, ok := obs.(BarObserver); ok {
+				barObs.OnBar(bar)
+			}
+		}
+	}
+	return closed
+}
+
+// act sizes, risk-checks, and submits the order implied by sig, priced at tk.
+func (e *Engine) act(ctx context.Context, tk signal.Tick, sig *signal.Signal, currentSnap paper.Snapshot) {
+	if sig == nil {
+		return
+	}
+	for _, obs := range e.observers {
+		obs.OnSignal(*sig)
+	}
+
+	side := execution.Buy
+	if sig.Score < 0 {
+		side = execution.Sell
+	}
+	if side == execution.Buy && e.isStale(tk.Symbol) {
+		e.log.Debug().Str("symbol", tk.Symbol).Dur("stale_after", e.staleAfter).Msg("market data stale; skipping entry")
+		return
+	}
+
+	qty := e.size(tk, side)
+	if qty <= 0 {
+		return
+	}
+
+	if side == execution.Buy && e.limits.MaxPortfolioNotional > 0 {
+		grossBefore, _ := risk.Exposure(extractQtys(currentSnap.Positions), e.marks)
+		projected := grossBefore + qty*tk.Price
+		if e.limits.PortfolioBreached(grossBefore, projected) {
+			e.log.Debug().Float64("projected", projected).Float64("limit", e.limits.MaxPortfolioNotional).Str("symbol", tk.Symbol).Msg("portfolio notional limit reached; skipping buy")
+			return
+		}
+	}
+
+	order := execution.Order{
+		Symbol: tk.Symbol,
+		Side:   side,
+		Qty:    qty,
+		Price:  tk.Price,
+	}
+	if side == execution.Buy && !e.limits.Allow(order.Qty*order.Price) {
+		e.log.Warn().Str("symbol", order.Symbol).Msg("risk rejected order over notional limit")
+		return
+	}
+
+	totalFilled, err := e.submit(ctx, order)
+	if err != nil {
+		e.log.Error().Err(err).Str("symbol", order.Symbol).Msg("executor submit failed")
+		return
+	}
+	if totalFilled <= 0 {
+		return
+	}
+
+	snap := e.account.Snapshot(e.marks)
+	e.publishSnapshot(snap, order.Symbol)
+	e.logFills(order, totalFilled, sig.Score, snap)
+
+	e.observeEquity(snap.Equity)
+	if e.limits.Breached(e.account.StartingCash(), snap.Equity) {
+		e.terminate(ctx, "drawdown limit reached after fill")
+		return
+	}
+	if e.limits.DailyLossBreached(e.account.RealizedPnL()) {
+		e.terminate(ctx, "daily loss limit reached after fill")
+	}
+}
+
+// Snapshot returns the account marked to the latest prices seen by the engine.
+func (e *Engine) Snapshot() paper.Snapshot {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.account.Snapshot(e.marks)
+}
+
+// Marks returns a copy of the latest price per symbol.
+func (e *Engine) Marks() map[string]float64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	out := make(map[string]float64, len(e.marks))
+	for sym, px := range e.marks {
+		out[sym] = px
+	}
+	return out
+}
+
+// Halted reports whether a kill switch fired and why.
+func (e *Engine) Halted() (bool, string) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.halted, e.haltReason
+}
+
+// PeakEquity returns the high-water mark used by the intratrade drawdown check.
+func (e *Engine) PeakEquity() float64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.peakEquity
+}
+
+// StalePositions lists open positions currently flagged as having stale market data.
+func (e *Engine) StalePositions() []string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	out := make([]string, 0, len(e.stale))
+	for sym := range e.stale {
+		out = append(out, sym)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// HeldSymbols lists symbols with an open position or an order in flight, sorted; discovery pins these.
+func (e *Engine) HeldSymbols() []string {
+	set := make(map[string]struct{})
+	for sym := range e.account.Snapshot(nil).Positions {
+		set[sym] = struct{}{}
+	}
+	e.mu.RLock()
+	for sym := range e.pending {
+		set[sym] = struct{}{}
+	}
+	e.mu.RUnlock()
+	out := make([]string, 0, len(set))
+	for sym := range set {
+		out = append(out, sym)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// isStale reports whether symbol's latest tick is older than the staleness threshold; symbols the
+// freshness source has never seen are treated as fresh.
+func (e *Engine) isStale(symbol string) bool {
+	if e.freshness == nil {
+		return false
+	}
+	last, ok := e.freshness.LastTick(symbol)
+	return ok && e.now().Sub(last) > e.staleAfter
+}
+
+// flagStalePositions re-evaluates open positions against the staleness threshold, logging transitions.
+func (e *Engine) flagStalePositions() {
+	snap := e.account.Snapshot(e.marks)
+	stale := make(map[string]bool)
+	for sym, pos := range snap.Positions {
+		if math.Abs(pos.Qty) > 1e-9 && e.isStale(sym) {
+			stale[sym] = true
+		}
+	}
+	e.mu.Lock()
+	prev := e.stale
+	e.stale = stale
+	e.mu.Unlock()
+
+	for sym := range stale {
+		if prev[sym] {
+			continue
+		}
+		metrics.StalePositions.WithLabelValues(sym).Set(1)
+		last, _ := e.freshness.LastTick(sym)
+		e.log.Warn().Str("symbol", sym).Time("last_tick", last).Float64("mark", e.marks[sym]).Msg("position market data stale; mark may be outdated")
+	}
+	for sym := range prev {
+		if stale[sym] {
+			continue
+		}
+		metrics.StalePositions.WithLabelValues(sym).Set(0)
+		e.log.Info().Str("symbol", sym).Msg("position market data fresh again")
+	}
+}
+
+func (e *Engine) setMark(symbol string, price float64) {
+	e.mu.Lock()
+	e.marks[symbol] = price
+	e.mu.Unlock()
+}
+
+func (e *Engine) observeEquity(equity float64) {
+	if equity <= e.peakEquity {
+		return
+	}
+	e.mu.Lock()
+	e.peakEquity = equity
+	e.mu.Unlock()
+}
+
+// size converts a directional signal into an order quantity honouring cash, per-trade, and per-symbol caps.
+func (e *Engine) size(tk signal.Tick, side execution.Side) float64 {
+	switch side {
+	case execution.Buy:
+		cashBudget := e.account.AvailableCash()
+		if cashBudget <= 0 {
+			e.log.Warn().Msg("account out of cash; waiting for positions to unwind")
+			return 0
+		}
+		notional := e.limits.MaxNotionalPerTrade
+		if notional <= 0 {
+			notional = cashBudget
+		} else {
+			notional = math.Min(notional, cashBudget)
+		}
+		if notional <= 0 {
+			return 0
+		}
+		capacity := e.account.MaxAdditionalLong(tk.Symbol, tk.Price)
+		if capacity <= 0 {
+			e.log.Debug().Str("symbol", tk.Symbol).Msg("position cap reached; skipping buy")
+			return 0
+		}
+		return math.Min(notional/tk.Price, capacity)
+	case execution.Sell:
+		return e.account.Position(tk.Symbol)
+	}
+	return 0
+}
+
+// submit routes the order and books accepted fills, returning the filled quantity.
+func (e *Engine) submit(ctx context.Context, order execution.Order) (float64, error) {
+	e.mu.Lock()
+	e.pending[order.Symbol]++
+	e.mu.Unlock()
+	defer func() {
+		e.mu.Lock()
+		if e.pending[order.Symbol]--; e.pending[order.Symbol] <= 0 {
+			delete(e.pending, order.Symbol)
+		}
+		e.mu.Unlock()
+	}()
+	fills, err := e.exec.Submit(ctx, order)
+	if err != nil {
+		return 0, err
+	}
+	var totalFilled float64
+	for _, fill := range fills {
+		price := fill.Price
+		if price <= 0 {
+			price = order.Price
+		}
+		// Fees move the account's effective price so cash and realised PnL include them.
+		accountPrice := price
+		if fill.Fee > 0 && fill.Qty > 0 {
+			if order.Side == execution.Buy {
+				accountPrice += fill.Fee / fill.Qty
+			} else {
+				accountPrice -= fill.Fee / fill.Qty
+			}
+		}
+		realizedBefore := e.account.RealizedPnL()
+		if err := e.account.MarketFill(order.Symbol, order.Side, fill.Qty, accountPrice); err != nil {
+			e.log.Warn().Err(err).Str("symbol", order.Symbol).Msg("fill rejected by account")
+			continue
+		}
+		fill.Price = price
+		fill.Side = order.Side
+		fill.Symbol = order.Symbol
+		totalFilled += fill.Qty
+		if e.ledger != nil {
+			e.ledger.Record(fill)
+		}
+		if e.recorder != nil {
+			e.recorder.Record(fill)
+		}
+		realized := e.account.RealizedPnL() - realizedBefore
+		for _, obs := range e.observers {
+			obs.OnFill(order, fill, realized)
+		}
+	}
+	for _, obs := range e.observers {
+		obs.OnOrder(order, totalFilled)
+	}
+	return totalFilled, nil
+}
+
+func (e *Engine) terminate(ctx context.Context, reason string) {
+	if e.halted {
+		return
+	}
+	e.mu.Lock()
+	e.halted = true
+	e.haltReason = reason
+	e.mu.Unlock()
+
+	e.log.Warn().Str("reason", reason).Msg("risk limit triggered; flattening positions and pausing trading")
+	e.flattenPositions(ctx)
+	snap := e.account.Snapshot(e.marks)
+	metrics.PaperEquity.Set(snap.Equity)
+	for sym := range e.marks {
+		metrics.PaperPositions.WithLabelValues(sym).Set(0)
+	}
+	for sym, pos := range snap.Positions {
+		metrics.PaperPositions.WithLabelValues(sym).Set(pos.Qty)
+	}
+	e.mu.Lock()
+	e.peakEquity = snap.Equity
+	e.mu.Unlock()
+	for _, obs := range e.observers {
+		obs.OnHalt(reason)
+	}
+}
+
+func (e *Engine) flattenPositions(ctx context.Context) {
+	snap := e.account.Snapshot(e.marks)
+	for sym, pos := range snap.Positions {
+		qty := pos.Qty
+		if math.Abs(qty) <= 1e-9 {
+			continue
+		}
+		side := execution.Sell
+		if qty < 0 {
+			side = execution.Buy
+			qty = -qty
+		}
+		price := e.marks[sym]
+		if price <= 0 {
+			price = pos.AvgCost
+			if price <= 0 {
+				price = 1
+			}
+		}
+		order := execution.Order{Symbol: sym, Side: side, Qty: qty, Price: price}
+		if _, err := e.submit(ctx, order); err != nil {
+			e.log.Warn().Err(err).Str("symbol", sym).Msg("flatten submit failed")
+			continue
+		}
+		e.setMark(sym, price)
+	}
+}
+
+func (e *Engine) publishSnapshot(snap paper.Snapshot, symbol string) {
+	metrics.PaperEquity.Set(snap.Equity)
+	for sym, pos := range snap.Positions {
+		metrics.PaperPositions.WithLabelValues(sym).Set(pos.Qty)
+	}
+	if _, ok := snap.Positions[symbol]; !ok {
+		metrics.PaperPositions.WithLabelValues(symbol).Set(0)
+	}
+}
+
+func (e *Engine) logFills(order execution.Order, filled, score float64, snap paper.Snapshot) {
+	gross, net := risk.Exposure(extractQtys(snap.Positions), e.marks)
+	logEvent := e.log.Info().Str("symbol", order.Symbol).
+		Str("side", string(order.Side)).
+		Float64("qty", filled).
+		Float64("signal_score", score).
+		Float64("cash", snap.Cash).
+		Float64("equity", snap.Equity).
+		Float64("realized", snap.RealizedPnL).
+		Float64("gross_exposure", gross).
+		Float64("net_exposure", net).
+		Float64("unrealized", aggregateUnrealized(snap.Positions))
+	if pos, ok := snap.Positions[order.Symbol]; ok {
+		logEvent = logEvent.Float64("position", pos.Qty).Float64("avg_cost", pos.AvgCost)
+	} else {
+		logEvent = logEvent.Float64("position", 0).Float64("avg_cost", 0)
+	}
+	logEvent.Msg("fills processed")
+}
+
+func extractQtys(pos map[string]paper.PositionSnapshot) map[string]float64 {
+	out := make(map[string]float64, len(pos))
+	for sym, snapshot := range pos {
+		out[sym] = snapshot.Qty
+	}
+	return out
+}
+
+func aggregateUnrealized(pos map[string]paper.PositionSnapshot) float64 {
+	total := 0.0
+	for _, snapshot := range pos {
+		total += snapshot.Unrealized
+	}
+	return total
+}
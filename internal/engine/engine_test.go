@@ -0,0 +1,291 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"memebot-go/internal/execution"
+	"memebot-go/internal/paper"
+	"memebot-go/internal/risk"
+	"memebot-go/internal/signal"
+)
+
+type sliceFeed struct {
+	ticks []signal.Tick
+}
+
+func (f *sliceFeed) Run(ctx context.Context, out chan<- signal.Tick) error {
+	for _, tk := range f.ticks {
+		select {
+		case out <- tk:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+type exactSubmitter struct {
+	orders []execution.Order
+	fee    float64 // quote-currency fee charged on every fill
+}
+
+func (s *exactSubmitter) Submit(_ context.Context, order execution.Order) ([]execution.Fill, error) {
+	s.orders = append(s.orders, order)
+	return []execution.Fill{{Symbol: order.Symbol, Side: order.Side, Qty: order.Qty, Price: order.Price, Fee: s.fee}}, nil
+}
+
+// followStrategy emits the tick side as the signal score so tests control direction.
+type followStrategy struct{}
+
+func (followStrategy) Name() string { return "follow" }
+
+func (followStrategy) OnTick(tk signal.Tick) *signal.Signal {
+	if tk.Side == 0 {
+		return nil
+	}
+	return &signal.Signal{Symbol: tk.Symbol, Score: float64(tk.Side), Ts: tk.Ts}
+}
+
+type recordingObserver struct {
+	signals, fills, orders int
+	realized               float64
+	halts                  []string
+}
+
+func (o *recordingObserver) OnSignal(signal.Signal) { o.signals++ }
+func (o *recordingObserver) OnFill(_ execution.Order, _ execution.Fill, realized float64) {
+	o.fills++
+	o.realized += realized
+}
+func (o *recordingObserver) OnOrder(execution.Order, float64) { o.orders++ }
+func (o *recordingObserver) OnHalt(reason string)             { o.halts = append(o.halts, reason) }
+
+func TestProcessSizesBuysAndSellsFullPosition(t *testing.T) {
+	exec := &exactSubmitter{}
+	obs := &recordingObserver{}
+	account := paper.NewAccount(1000, 0, 0)
+	eng := New(zerolog.Nop(), nil, followStrategy{}, risk.Limits{MaxNotionalPerTrade: 100}, exec, account, WithObserver(obs))
+
+	now := time.Now()
+	eng.Process(context.Background(), signal.Tick{Symbol: "WIF", Price: 10, Side: 1, Ts: now})
+	if got := account.Position("WIF"); got != 10 {
+		t.Fatalf("expected 10 units bought with $100 notional, got %.4f", got)
+	}
+	eng.Process(context.Background(), signal.Tick{Symbol: "WIF", Price: 12, Side: -1, Ts: now.Add(time.Second)})
+	if got := account.Position("WIF"); got != 0 {
+		t.Fatalf("expected position closed, got %.4f", got)
+	}
+	if obs.signals != 2 || obs.orders != 2 || obs.fills != 2 {
+		t.Fatalf("unexpected observer counts %+v", obs)
+	}
+	if obs.realized != 20 {
+		t.Fatalf("expected realized pnl 20, got %.2f", obs.realized)
+	}
+	if marks := eng.Marks(); marks["WIF"] != 12 {
+		t.Fatalf("expected mark 12, got %.2f", marks["WIF"])
+	}
+}
+
+func TestProcessChargesFillFees(t *testing.T) {
+	exec := &exactSubmitter{fee: 0.5}
+	account := paper.NewAccount(1000, 0, 0)
+	eng := New(zerolog.Nop(), nil, followStrategy{}, risk.Limits{MaxNotionalPerTrade: 100}, exec, account)
+
+	now := time.Now()
+	eng.Process(context.Background(), signal.Tick{Symbol: "WIF", Price: 10, Side: 1, Ts: now})
+	if got := account.AvailableCash(); math.Abs(got-899.5) > 1e-9 {
+		t.Fatalf("expected buy notional plus fee debited, got cash %.4f", got)
+	}
+	eng.Process(context.Background(), signal.Tick{Symbol: "WIF", Price: 10, Side: -1, Ts: now.Add(time.Second)})
+	if got := account.AvailableCash(); math.Abs(got-999) > 1e-9 {
+		t.Fatalf("expected both fees charged after a flat round trip, got cash %.4f", got)
+	}
+	if got := account.RealizedPnL(); math.Abs(got+1) > 1e-9 {
+		t.Fatalf("expected realized pnl to include fees, got %.4f", got)
+	}
+}
+
+func TestProcessRespectsPortfolioCap(t *testing.T) {
+	exec := &exactSubmitter{}
+	account := paper.NewAccount(1000, 0, 0)
+	eng := New(zerolog.Nop(), nil, followStrategy{}, risk.Limits{MaxNotionalPerTrade: 100, MaxPortfolioNotional: 150}, exec, account)
+
+	now := time.Now()
+	eng.Process(context.Background(), signal.Tick{Symbol: "A", Price: 1, Side: 1, Ts: now})
+	eng.Process(context.Background(), signal.Tick{Symbol: "B", Price: 1, Side: 1, Ts: now})
+	if len(exec.orders) != 1 {
+		t.Fatalf("expected second buy blocked by portfolio cap, got %d orders", len(exec.orders))
+	}
+}
+
+func TestRunHaltsAndFlattensOnDrawdown(t *testing.T) {
+	now := time.Now()
+	feed := &sliceFeed{ticks: []signal.Tick{
+		{Symbol: "WIF", Price: 10, Side: 1, Ts: now},
+		{Symbol: "WIF", Price: 5, Side: 0, Ts: now.Add(time.Second)},
+		{Symbol: "WIF", Price: 20, Side: 1, Ts: now.Add(2 * time.Second)},
+	}}
+	exec := &exactSubmitter{}
+	obs := &recordingObserver{}
+	account := paper.NewAccount(1000, 0, 0)
+	limits := risk.Limits{MaxNotionalPerTrade: 500, MaxDrawdownPct: 0.2}
+	eng := New(zerolog.Nop(), feed, followStrategy{}, limits, exec, account, WithObserver(obs))
+
+	err := eng.Run(context.Background())
+	if !errors.Is(err, ErrHalted) {
+		t.Fatalf("expected ErrHalted, got %v", err)
+	}
+	if halted, reason := eng.Halted(); !halted || reason != "drawdown limit reached" {
+		t.Fatalf("unexpected halt state %v %q", halted, reason)
+	}
+	if len(obs.halts) != 1 {
+		t.Fatalf("expected one halt notification, got %+v", obs.halts)
+	}
+	if account.Position("WIF") != 0 {
+		t.Fatalf("expected flattened position")
+	}
+	if len(exec.orders) != 2 || exec.orders[1].Side != execution.Sell {
+		t.Fatalf("expected entry plus flatten orders, got %+v", exec.orders)
+	}
+}
+
+func TestRunReturnsNilWhenFeedEnds(t *testing.T) {
+	feed := &sliceFeed{ticks: []signal.Tick{{Symbol: "WIF", Price: 1, Ts: time.Now()}}}
+	eng := New(zerolog.Nop(), feed, followStrategy{}, risk.Limits{}, &exactSubmitter{}, paper.NewAccount(100, 0, 0))
+	if err := eng.Run(context.Background()); err != nil {
+		t.Fatalf("expected clean exit when feed finishes, got %v", err)
+	}
+	if eng.Marks()["WIF"] != 1 {
+		t.Fatalf("expected tick to be processed before exit")
+	}
+}
+
+// fixedFreshness reports preset last-tick times per symbol.
+type fixedFreshness map[string]time.Time
+
+func (f fixedFreshness) LastTick(symbol string) (time.Time, bool) {
+	at, ok := f[symbol]
+	return at, ok
+}
+
+func TestStaleSymbolsRefuseEntriesButAllowExits(t *testing.T) {
+	exec := &exactSubmitter{}
+	account := paper.NewAccount(1000, 0, 0)
+	now := time.Unix(1700000000, 0)
+	fresh := fixedFreshness{"WIF": now.Add(-time.Second), "BODEN": now.Add(-time.Minute)}
+	eng := New(zerolog.Nop(), nil, followStrategy{}, risk.Limits{MaxNotionalPerTrade: 100}, exec, account,
+		WithStaleAfter(fresh, 10*time.Second, true))
+	eng.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	eng.Process(ctx, signal.Tick{Symbol: "WIF", Price: 10, Side: 1, Ts: now})
+	eng.Process(ctx, signal.Tick{Symbol: "BODEN", Price: 5, Side: 1, Ts: now})
+	eng.Process(ctx, signal.Tick{Symbol: "UNSEEN", Price: 1, Side: 1, Ts: now})
+	if account.Position("WIF") != 10 || account.Position("BODEN") != 0 || account.Position("UNSEEN") != 100 {
+		t.Fatalf("expected only the stale symbol to be skipped, got WIF=%.2f BODEN=%.2f UNSEEN=%.2f",
+			account.Position("WIF"), account.Position("BODEN"), account.Position("UNSEEN"))
+	}
+
+	// WIF goes quiet while held: it is flagged, and exits still go through.
+	now = now.Add(time.Minute)
+	eng.flagStalePositions()
+	if got := eng.StalePositions(); len(got) != 1 || got[0] != "WIF" {
+		t.Fatalf("expected WIF flagged stale, got %v", got)
+	}
+	eng.Process(ctx, signal.Tick{Symbol: "WIF", Price: 11, Side: -1, Ts: now})
+	if got := account.Position("WIF"); got != 0 {
+		t.Fatalf("expected stale position to remain exitable, got %.2f", got)
+	}
+	eng.flagStalePositions()
+	if got := eng.StalePositions(); len(got) != 0 {
+		t.Fatalf("expected flag cleared once the position closed, got %v", got)
+	}
+}
+
+// barBreakout buys when a 1s bar closes above its open with buy volume dominating; ticks alone never signal.
+type barBreakout struct{ bars []signal.Bar }
+
+func (*barBreakout) Name() string                      { return "bar-breakout" }
+func (*barBreakout) OnTick(signal.Tick) *signal.Signal { return nil }
+func (*barBreakout) Timeframes() []time.Duration       { return []time.Duration{time.Second} }
+func (s *barBreakout) OnBar(bar signal.Bar) *signal.Signal {
+	s.bars = append(s.bars, bar)
+	if bar.Close > bar.Open && bar.BuyVolume > bar.SellVolume {
+		return &signal.Signal{Symbol: bar.Symbol, Score: 1, Ts: bar.End()}
+	}
+	return nil
+}
+
+type barRecorder struct {
+	recordingObserver
+	bars []signal.Bar
+}
+
+func (o *barRecorder) OnBar(bar signal.Bar) { o.bars = append(o.bars, bar) }
+
+func TestBarConsumerTradesOnClosedBars(t *testing.T) {
+	exec := &exactSubmitter{}
+	strat := &barBreakout{}
+	obs := &barRecorder{}
+	account := paper.NewAccount(1000, 0, 0)
+	eng := New(zerolog.Nop(), nil, strat, risk.Limits{MaxNotionalPerTrade: 100}, exec, account,
+		WithObserver(obs), WithBars(time.Minute))
+
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+	eng.Process(ctx, signal.Tick{Symbol: "WIF", Price: 2, Size: 10, Side: 1, Ts: base})
+	eng.Process(ctx, signal.Tick{Symbol: "WIF", Price: 2.5, Size: 5, Side: 1, Ts: base.Add(500 * time.Millisecond)})
+	if len(exec.orders) != 0 {
+		t.Fatalf("expected no orders before the bar closes")
+	}
+
+	eng.Process(ctx, signal.Tick{Symbol: "WIF", Price: 2.4, Size: 1, Side: -1, Ts: base.Add(1200 * time.Millisecond)})
+	if len(strat.bars) != 1 || len(exec.orders) != 1 {
+		t.Fatalf("expected one closed bar and one order, got %d bars and %d orders", len(strat.bars), len(exec.orders))
+	}
+	if order := exec.orders[0]; order.Side != execution.Buy || order.Price != 2.5 {
+		t.Fatalf("expected a buy at the bar close, got %+v", order)
+	}
+	// Observers see the strategy's 1s bars; the extra 1m timeframe is still open.
+	if len(obs.bars) != 1 || obs.bars[0].Timeframe != time.Second {
+		t.Fatalf("unexpected observed bars %+v", obs.bars)
+	}
+}
+
+// heldProbe records HeldSymbols while an order is in flight.
+type heldProbe struct {
+	exactSubmitter
+	eng      *Engine
+	inFlight []string
+}
+
+func (p *heldProbe) Submit(ctx context.Context, order execution.Order) ([]execution.Fill, error) {
+	p.inFlight = p.eng.HeldSymbols()
+	return p.exactSubmitter.Submit(ctx, order)
+}
+
+func TestHeldSymbolsCoverPositionsAndOrdersInFlight(t *testing.T) {
+	exec := &heldProbe{}
+	account := paper.NewAccount(1000, 0, 0)
+	eng := New(zerolog.Nop(), nil, followStrategy{}, risk.Limits{MaxNotionalPerTrade: 100}, exec, account)
+	exec.eng = eng
+
+	now := time.Now()
+	eng.Process(context.Background(), signal.Tick{Symbol: "WIF", Price: 10, Side: 1, Ts: now})
+	if len(exec.inFlight) != 1 || exec.inFlight[0] != "WIF" {
+		t.Fatalf("expected pending order to pin WIF, got %v", exec.inFlight)
+	}
+	if held := eng.HeldSymbols(); len(held) != 1 || held[0] != "WIF" {
+		t.Fatalf("expected open position to pin WIF, got %v", held)
+	}
+	eng.Process(context.Background(), signal.Tick{Symbol: "WIF", Price: 10, Side: -1, Ts: now.Add(time.Second)})
+	if held := eng.HeldSymbols(); len(held) != 0 {
+		t.Fatalf("expected nothing held once flat, got %v", held)
+	}
+}
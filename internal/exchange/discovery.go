@@ -3,11 +3,13 @@ package exchange
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
 	"net/url"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -15,6 +17,8 @@ import (
 	"github.com/rs/zerolog"
 
 	"memebot-go/internal/config"
+	"memebot-go/internal/instrument"
+	"memebot-go/internal/metrics"
 )
 
 // DexScreenerDiscovery continuously enriches the feed symbol list using Dexscreener search endpoints.
@@ -28,14 +32,89 @@ type DexScreenerDiscovery struct {
 	cfg          config.Discovery
 	mu           sync.Mutex
 	lastSet      []string
+	onChange     func(current, previous []string)
+	screeners    []Screener
+	scorer       Scorer
+	holdings     Holdings
+	admitted     map[string]time.Time // when each discovered symbol last entered the universe
+	kept         map[string]string    // symbols outside the discovered set and why they stay
+	origins      map[string]string    // why each symbol of the current universe is in it
+	lists        *SymbolLists
+	allowed      map[string]string // allow-list key -> resolved feed symbol
+	alternates   map[string][]PoolAlternate
+	ranked       map[string]candidatePair // last discovery metrics of every symbol in the universe
+	history      UniverseHistory
+	now          func() time.Time
+	mode         string
+	listings     []string
+	minAge       time.Duration
+	maxAge       time.Duration
+}
+
+// Holdings reports symbols discovery must keep polling: open positions and orders in flight
+// (satisfied by *engine.Engine).
+type Holdings interface {
+	HeldSymbols() []string
+}
+
+// Origins reported by Universe; held and min_residency symbols stay after dropping out of the discovered set.
+const (
+	OriginManual     = "manual"
+	OriginDiscovered = "discovered"
+	OriginPinned     = "pinned"
+	keepHeld         = "held"
+	keepResidency    = "min_residency"
+)
+
+// UniverseEntry is one polled symbol and why it is in the universe.
+type UniverseEntry struct {
+	Symbol     string          `json:"symbol"`
+	Origin     string          `json:"origin"` // manual, discovered, pinned (allow list), held, or min_residency
+	Alternates []PoolAlternate `json:"alternates,omitempty"`
+}
+
+// Discovery modes selecting which Dexscreener queries feed the candidate list.
+const (
+	DiscoveryModeKeywords    = "keywords"
+	DiscoveryModeNewListings = "new_listings"
+	DiscoveryModeBoth        = "both"
+)
+
+// listingEndpoints maps new_listings.sources entries to Dexscreener's latest token feeds.
+var listingEndpoints = map[string]string{
+	"token_profiles": "/token-profiles/latest/v1",
+	"token_boosts":   "/token-boosts/latest/v1",
+	"top_boosts":     "/token-boosts/top/v1",
+}
+
+// dexscreenerTokenListing is an entry of the token profile and boost feeds.
+type dexscreenerTokenListing struct {
+	ChainID      string `json:"chainId"`
+	TokenAddress string `json:"tokenAddress"`
+}
+
+// pairSource is one discovery query: a keyword search or the new-listing token feeds.
+type pairSource struct {
+	label string
+	fresh bool // admit only pairs inside the new-listing age window
+	fetch func(ctx context.Context) ([]dexscreenerPair, error)
 }
 
 type candidatePair struct {
-	symbol    string
-	liquidity float64
-	volume    float64
-	change24  float64
-	score     float64
+	symbol     string
+	token      string // chain/base mint shared by every pool of the same token
+	dexID      string
+	quote      string
+	quoteMint  string
+	liquidity  float64
+	volume     float64
+	change24   float64
+	priceUSD   float64
+	score      float64
+	createdAt  time.Time
+	features   CandidateFeatures
+	flags      []string        // soft screening failures that scaled the score down
+	alternates []PoolAlternate // other pools of the same token
 }
 
 // NewDexScreenerDiscovery constructs a discovery service; returns nil if disabled or nil feed.
@@ -48,7 +127,7 @@ func NewDexScreenerDiscovery(log zerolog.Logger, feed *Feed, manual []string, de
 		baseURL = defaultDexScreenerBaseURL
 	}
 	baseURL = strings.TrimSuffix(baseURL, "/")
-	return &DexScreenerDiscovery{
+	d := &DexScreenerDiscovery{
 		log:          log,
 		feed:         feed,
 		manual:       append([]string(nil), manual...),
@@ -56,7 +135,121 @@ func NewDexScreenerDiscovery(log zerolog.Logger, feed *Feed, manual []string, de
 		baseURL:      baseURL,
 		defaultChain: strings.ToLower(dexCfg.DefaultChain),
 		cfg:          cfg,
+		admitted:     make(map[string]time.Time),
+		kept:         make(map[string]string),
+		origins:      make(map[string]string),
+		allowed:      make(map[string]string),
+		ranked:       make(map[string]candidatePair),
+		now:          time.Now,
+		scorer:       NewWeightedScorer(log, cfg.Scoring),
+		mode:         DiscoveryModeKeywords,
+		minAge:       time.Duration(cfg.NewListings.MinAgeMs) * time.Millisecond,
+		maxAge:       time.Duration(cfg.NewListings.MaxAgeMs) * time.Millisecond,
+	}
+	switch mode := strings.ToLower(strings.TrimSpace(cfg.Mode)); mode {
+	case "", DiscoveryModeKeywords:
+	case DiscoveryModeNewListings, DiscoveryModeBoth:
+		d.mode = mode
+	default:
+		log.Warn().Str("mode", cfg.Mode).Msg("unknown discovery mode; using keywords")
+	}
+	for _, source := range cfg.NewListings.Sources {
+		source = strings.ToLower(strings.TrimSpace(source))
+		if _, ok := listingEndpoints[source]; !ok {
+			log.Warn().Str("source", source).Msg("unknown new listing source ignored")
+			continue
+		}
+		d.listings = append(d.listings, source)
+	}
+	if len(d.listings) == 0 {
+		d.listings = []string{"token_profiles", "token_boosts", "top_boosts"}
+	}
+	if d.minAge <= 0 {
+		d.minAge = 10 * time.Minute
+	}
+	if d.maxAge <= 0 {
+		d.maxAge = 6 * time.Hour
+	}
+	if screening := cfg.Screening; screening.Enabled {
+		if screening.MinPairAgeMs > 0 {
+			d.AddScreener(NewPairAgeScreener(time.Duration(screening.MinPairAgeMs)*time.Millisecond, rejectSet(screening.Reject)[CheckPairAge]))
+		}
+		d.AddScreener(NewSolanaScreener(log, screening))
+	}
+	return d
+}
+
+// AddScreener appends a safety screen run on every candidate that passes the liquidity and volume filters.
+func (d *DexScreenerDiscovery) AddScreener(s Screener) {
+	if d == nil || s == nil {
+		return
+	}
+	d.screeners = append(d.screeners, s)
+}
+
+// OnChange registers a callback invoked after each refresh that changes the symbol universe.
+func (d *DexScreenerDiscovery) OnChange(fn func(current, previous []string)) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	d.onChange = fn
+	d.mu.Unlock()
+}
+
+// PinHeld keeps symbols reported by src in the universe until they are flat, even after they fall out of the top pairs.
+func (d *DexScreenerDiscovery) PinHeld(src Holdings) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	d.holdings = src
+	d.mu.Unlock()
+}
+
+// SetLists applies allow/deny lists: allowed pairs and mints are always polled, denied ones never
+// admitted and dropped from the universe once no longer held.
+func (d *DexScreenerDiscovery) SetLists(lists *SymbolLists) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	d.lists = lists
+	d.mu.Unlock()
+}
+
+// SetHistory records every symbol entering or leaving the universe with its metrics at the time.
+func (d *DexScreenerDiscovery) SetHistory(h UniverseHistory) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	d.history = h
+	d.mu.Unlock()
+}
+
+// Universe lists the symbols of the last refresh with their origin, sorted by symbol.
+func (d *DexScreenerDiscovery) Universe() []UniverseEntry {
+	if d == nil {
+		return nil
 	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	out := make([]UniverseEntry, 0, len(d.lastSet))
+	for _, sym := range d.lastSet {
+		out = append(out, UniverseEntry{Symbol: sym, Origin: d.origins[sym], Alternates: d.alternates[sym]})
+	}
+	return out
+}
+
+// Alternates returns the other pools of a discovered symbol's base token from the last refresh.
+func (d *DexScreenerDiscovery) Alternates(symbol string) []PoolAlternate {
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]PoolAlternate(nil), d.alternates[symbol]...)
 }
 
 // Start launches the discovery loop in a goroutine.
@@ -98,13 +291,14 @@ func (d *DexScreenerDiscovery) Refresh(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	discovered := make([]string, len(candidates))
-	for i, cand := range candidates {
-		discovered[i] = cand.symbol
+	d.mu.Lock()
+	holdings := d.holdings
+	d.mu.Unlock()
+	var held []string
+	if holdings != nil {
+		held = d.feed.FeedSymbols(holdings.HeldSymbols())
 	}
-	combined := mergeSymbols(d.manual, discovered)
-	d.feed.SetSymbols(combined)
-	d.logDiscoveryChange(combined, candidates)
+	d.applyUniverse(candidates, held, d.resolveAllowed(ctx))
 	return nil
 }
 
@@ -122,32 +316,45 @@ func (d *DexScreenerDiscovery) discover(ctx context.Context) ([]candidatePair, e
 	if len(chainAllow) == 0 && d.defaultChain != "" {
 		chainAllow[d.defaultChain] = struct{}{}
 	}
-	keywords := d.cfg.Keywords
-	if len(keywords) == 0 {
-		keywords = []string{"wif", "boden", "pepe", "doge"}
-	}
 
 	seen := make(map[string]struct{})
+	for _, sym := range d.manual {
+		// Manually configured pairs are already polled, whatever alias they were given.
+		seen[symbolAddress(sym)] = struct{}{}
+	}
+	tokens := make(map[string]struct{}) // distinct base tokens; extra pools of one token do not use up max_pairs
 	candidates := make([]candidatePair, 0, limits*2)
 	perKeywordLimit := d.cfg.MaxPairsPerKeyword
 	if perKeywordLimit <= 0 {
 		perKeywordLimit = limits
 	}
-	for _, keyword := range keywords {
-		if len(candidates) >= limits {
+	d.mu.Lock()
+	lists := d.lists
+	d.mu.Unlock()
+	penalty := d.cfg.Screening.ScorePenalty
+	if penalty <= 0 {
+		penalty = 0.5
+	}
+	for _, src := range d.pairSources(chainAllow) {
+		if len(tokens) >= limits {
 			break
 		}
 		added := 0
-		pairs, err := d.search(ctx, keyword)
+		pairs, err := src.fetch(ctx)
 		if err != nil {
-			d.log.Debug().Err(err).Str("keyword", keyword).Msg("dexscreener search failed")
+			d.log.Debug().Err(err).Str("source", src.label).Msg("dexscreener discovery query failed")
 			continue
 		}
+		sourceLimit := perKeywordLimit
+		if src.fresh {
+			sourceLimit = limits
+		}
+		now := time.Now()
 		for _, pair := range pairs {
-			if len(candidates) >= limits {
+			if len(tokens) >= limits {
 				break
 			}
-			if perKeywordLimit > 0 && added >= perKeywordLimit {
+			if sourceLimit > 0 && added >= sourceLimit {
 				break
 			}
 			chain := strings.ToLower(pair.ChainID)
@@ -156,6 +363,13 @@ func (d *DexScreenerDiscovery) discover(ctx context.Context) ([]candidatePair, e
 					continue
 				}
 			}
+			var createdAt time.Time
+			if pair.PairCreatedAt > 0 {
+				createdAt = time.UnixMilli(pair.PairCreatedAt)
+			}
+			if src.fresh && !d.inListingWindow(createdAt, now) {
+				continue
+			}
 			if minLiquidity > 0 && pair.Liquidity.USD < minLiquidity {
 				continue
 			}
@@ -163,46 +377,59 @@ func (d *DexScreenerDiscovery) discover(ctx context.Context) ([]candidatePair, e
 			if address == "" {
 				continue
 			}
+			// Registering before the seen check records the base mint of manual pairs too, so mint-keyed
+			// deny entries reach them.
+			sym := d.pairSymbol(pair, chain)
 			if _, ok := seen[address]; ok {
 				continue
 			}
-			volumeUSD := pair.Volume.H24
-			if volumeUSD <= 0 {
-				volumeUSD = pair.Volume.H6
-			}
-			if volumeUSD <= 0 {
-				volumeUSD = pair.Volume.H1
-			}
+			volumeUSD := pairVolume(pair)
 			if minVolume > 0 && volumeUSD < minVolume {
 				continue
 			}
-			aliasBase := pair.BaseToken.Symbol
-			if aliasBase == "" {
-				aliasBase = pair.BaseToken.Name
+			seen[address] = struct{}{}
+			if lists.Denied(address, pair.BaseToken.Address) {
+				d.log.Debug().Str("symbol", sym).Msg("discovery candidate denied by list")
+				continue
 			}
-			quote := pair.QuoteToken.Symbol
-			if quote == "" {
-				quote = pair.QuoteToken.Name
+			screen := ScreenCandidate{
+				Symbol:      sym,
+				Chain:       chain,
+				DexID:       pair.DexID,
+				PairAddress: address,
+				BaseMint:    pair.BaseToken.Address,
+				CreatedAt:   createdAt,
 			}
-			aliasBase = aliasBase + quote
-			sym := fmt.Sprintf("%s@%s/%s", composeDexAlias(aliasBase, address), chain, address)
-			score := pair.Liquidity.USD*0.6 + volumeUSD*0.35
-			if pair.PriceChange.H24 > 0 {
-				score += pair.PriceChange.H24 * 1000
+			flags, ok := d.screen(ctx, screen)
+			if !ok {
+				continue
 			}
+			price, _ := strconv.ParseFloat(pair.PriceUsd, 64)
+			token := tokenKey(chain, pair)
 			candidates = append(candidates, candidatePair{
 				symbol:    sym,
+				token:     token,
+				dexID:     pair.DexID,
+				quote:     pair.QuoteToken.Symbol,
+				quoteMint: pair.QuoteToken.Address,
 				liquidity: pair.Liquidity.USD,
 				volume:    volumeUSD,
 				change24:  pair.PriceChange.H24,
-				score:     score,
+				priceUSD:  price,
+				createdAt: createdAt,
+				features:  extractFeatures(pair, now),
+				flags:     flags,
 			})
-			seen[address] = struct{}{}
-			added++
+			if _, ok := tokens[token]; !ok {
+				tokens[token] = struct{}{}
+				added++
+			}
 		}
 	}
-	sort.Slice(candidates, func(i, j int) bool {
-		if math.Abs(candidates[i].score-candidates[j].score) > 1 {
+	candidates = d.selectPools(candidates)
+	d.scoreCandidates(candidates, penalty)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
 			return candidates[i].score > candidates[j].score
 		}
 		return candidates[i].liquidity > candidates[j].liquidity
@@ -213,64 +440,534 @@ func (d *DexScreenerDiscovery) discover(ctx context.Context) ([]candidatePair, e
 	return candidates, nil
 }
 
+// pairSymbol registers a Dexscreener pair as an instrument and returns its SYMBOL@chain/pair feed symbol.
+func (d *DexScreenerDiscovery) pairSymbol(pair dexscreenerPair, chain string) string {
+	base := pair.BaseToken.Symbol
+	if base == "" {
+		base = pair.BaseToken.Name
+	}
+	quote := pair.QuoteToken.Symbol
+	if quote == "" {
+		quote = pair.QuoteToken.Name
+	}
+	inst := d.feed.Instruments().RegisterPair(instrument.Instrument{
+		Venue:       ProviderDexScreener,
+		Chain:       chain,
+		PairAddress: pair.PairAddress,
+		BaseSymbol:  base,
+		QuoteSymbol: quote,
+		BaseMint:    pair.BaseToken.Address,
+		QuoteMint:   pair.QuoteToken.Address,
+	}, base+quote)
+	return inst.FeedSymbol()
+}
+
+// resolveAllowed maps allow-list entries to feed symbols. Keys are tried as pair addresses first, then as
+// token mints resolved to their most liquid pair; resolutions are cached and failures retried next refresh.
+func (d *DexScreenerDiscovery) resolveAllowed(ctx context.Context) []string {
+	d.mu.Lock()
+	entries := d.lists.Entries(ListAllow)
+	cache := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		if sym, ok := d.allowed[entry.Key]; ok {
+			cache[entry.Key] = sym
+		}
+	}
+	d.mu.Unlock()
+
+	pending := make(map[string][]string) // chain -> unresolved keys
+	for _, entry := range entries {
+		if _, ok := cache[entry.Key]; ok {
+			continue
+		}
+		chain := entry.Chain
+		if chain == "" {
+			chain = d.defaultChain
+		}
+		if chain == "" {
+			chain = "solana"
+		}
+		pending[chain] = append(pending[chain], entry.Key)
+	}
+	for chain, keys := range pending {
+		for start := 0; start < len(keys); start += dexscreenerMaxBatch {
+			batch := keys[start:min(start+dexscreenerMaxBatch, len(keys))]
+			var byPair, byToken dexscreenerPairsResponse
+			joined := strings.Join(batch, ",")
+			if err := d.getJSON(ctx, fmt.Sprintf("%s/latest/dex/pairs/%s/%s", d.baseURL, chain, joined), &byPair); err != nil {
+				d.log.Warn().Err(err).Strs("keys", batch).Msg("resolve allow-listed pairs failed")
+			}
+			for _, pair := range byPair.Pairs {
+				cache[pair.PairAddress] = d.pairSymbol(pair, chain)
+			}
+			var mints []string
+			for _, key := range batch {
+				if _, ok := cache[key]; !ok {
+					mints = append(mints, key)
+				}
+			}
+			if len(mints) == 0 {
+				continue
+			}
+			if err := d.getJSON(ctx, fmt.Sprintf("%s/latest/dex/tokens/%s", d.baseURL, strings.Join(mints, ",")), &byToken); err != nil {
+				d.log.Warn().Err(err).Strs("keys", mints).Msg("resolve allow-listed tokens failed")
+				continue
+			}
+			best := make(map[string]dexscreenerPair)
+			for _, pair := range byToken.Pairs {
+				if strings.ToLower(pair.ChainID) != chain || pair.PairAddress == "" {
+					continue
+				}
+				if cur, ok := best[pair.BaseToken.Address]; !ok || pair.Liquidity.USD > cur.Liquidity.USD {
+					best[pair.BaseToken.Address] = pair
+				}
+			}
+			for _, mint := range mints {
+				if pair, ok := best[mint]; ok {
+					cache[mint] = d.pairSymbol(pair, chain)
+				} else {
+					d.log.Warn().Str("key", mint).Str("chain", chain).Msg("allow-listed key matches no pair")
+				}
+			}
+		}
+	}
+
+	out := make([]string, 0, len(entries))
+	d.mu.Lock()
+	d.allowed = make(map[string]string, len(entries))
+	for _, entry := range entries {
+		if sym, ok := cache[entry.Key]; ok {
+			d.allowed[entry.Key] = sym
+			out = append(out, sym)
+		}
+	}
+	d.mu.Unlock()
+	return out
+}
+
+// scoreCandidates ranks the refresh's candidates with the scoring model, scales soft screening failures
+// down by penalty each, and logs every candidate's feature breakdown.
+func (d *DexScreenerDiscovery) scoreCandidates(candidates []candidatePair, penalty float64) {
+	features := make([]CandidateFeatures, len(candidates))
+	for i, cand := range candidates {
+		features[i] = cand.features
+	}
+	scores := d.scorer.Score(features)
+	level := zerolog.DebugLevel
+	if d.cfg.Scoring.LogFeatures {
+		level = zerolog.InfoLevel
+	}
+	for i := range candidates {
+		cand := &candidates[i]
+		cand.score = scores[i].Total
+		if factor := math.Pow(penalty, float64(len(cand.flags))); cand.score >= 0 {
+			cand.score *= factor
+		} else {
+			// A penalty must push negative (normalized) scores further down, not toward zero.
+			cand.score /= factor
+		}
+		raw := zerolog.Dict()
+		weighted := zerolog.Dict()
+		for name, contribution := range scores[i].Contributions {
+			raw.Float64(name, cand.features[name])
+			weighted.Float64(name, contribution)
+		}
+		d.log.WithLevel(level).
+			Str("symbol", cand.symbol).
+			Float64("score", cand.score).
+			Float64("model_score", scores[i].Total).
+			Int("screen_flags", len(cand.flags)).
+			Dict("features", raw).
+			Dict("contributions", weighted).
+			Msg("discovery candidate scored")
+	}
+}
+
+// SetScorer replaces the weighted scoring model built from the discovery config.
+func (d *DexScreenerDiscovery) SetScorer(s Scorer) {
+	if d == nil || s == nil {
+		return
+	}
+	d.scorer = s
+}
+
+// pairSources lists the queries for the configured mode; new listings go first so keyword hits cannot starve them.
+func (d *DexScreenerDiscovery) pairSources(chainAllow map[string]struct{}) []pairSource {
+	var sources []pairSource
+	if d.mode == DiscoveryModeNewListings || d.mode == DiscoveryModeBoth {
+		sources = append(sources, pairSource{
+			label: DiscoveryModeNewListings,
+			fresh: true,
+			fetch: func(ctx context.Context) ([]dexscreenerPair, error) { return d.newListings(ctx, chainAllow) },
+		})
+	}
+	if d.mode == DiscoveryModeNewListings {
+		return sources
+	}
+	keywords := d.cfg.Keywords
+	if len(keywords) == 0 {
+		keywords = []string{"wif", "boden", "pepe", "doge"}
+	}
+	for _, keyword := range keywords {
+		sources = append(sources, pairSource{
+			label: keyword,
+			fetch: func(ctx context.Context) ([]dexscreenerPair, error) { return d.search(ctx, keyword) },
+		})
+	}
+	return sources
+}
+
+// inListingWindow reports whether a pair created at createdAt is inside the new-listing age window.
+func (d *DexScreenerDiscovery) inListingWindow(createdAt, now time.Time) bool {
+	if createdAt.IsZero() {
+		return false
+	}
+	age := now.Sub(createdAt)
+	return age >= d.minAge && age <= d.maxAge
+}
+
+// newListings gathers tokens from the latest profile/boost feeds and returns their pairs, newest first.
+func (d *DexScreenerDiscovery) newListings(ctx context.Context, chainAllow map[string]struct{}) ([]dexscreenerPair, error) {
+	var (
+		tokens []string
+		seen   = make(map[string]struct{})
+		errs   []error
+	)
+	for _, source := range d.listings {
+		var listings []dexscreenerTokenListing
+		if err := d.getJSON(ctx, d.baseURL+listingEndpoints[source], &listings); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", source, err))
+			continue
+		}
+		for _, listing := range listings {
+			if len(chainAllow) > 0 {
+				if _, ok := chainAllow[strings.ToLower(listing.ChainID)]; !ok {
+					continue
+				}
+			}
+			if listing.TokenAddress == "" {
+				continue
+			}
+			if _, ok := seen[listing.TokenAddress]; ok {
+				continue
+			}
+			seen[listing.TokenAddress] = struct{}{}
+			tokens = append(tokens, listing.TokenAddress)
+		}
+	}
+
+	var pairs []dexscreenerPair
+	for start := 0; start < len(tokens); start += dexscreenerMaxBatch {
+		end := min(start+dexscreenerMaxBatch, len(tokens))
+		var payload dexscreenerPairsResponse
+		endpoint := fmt.Sprintf("%s/latest/dex/tokens/%s", d.baseURL, strings.Join(tokens[start:end], ","))
+		if err := d.getJSON(ctx, endpoint, &payload); err != nil {
+			errs = append(errs, fmt.Errorf("token pairs: %w", err))
+			continue
+		}
+		pairs = append(pairs, payload.Pairs...)
+	}
+	if len(pairs) == 0 && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].PairCreatedAt > pairs[j].PairCreatedAt })
+	return pairs, nil
+}
+
+// screen runs every screener on cand, returning its soft failures, or false when a check rejects it.
+func (d *DexScreenerDiscovery) screen(ctx context.Context, cand ScreenCandidate) ([]string, bool) {
+	var flags []string
+	for _, screener := range d.screeners {
+		findings, err := screener.Screen(ctx, cand)
+		if err != nil {
+			d.log.Warn().Err(err).Str("symbol", cand.Symbol).Msg("discovery screening failed")
+			findings = []ScreenFinding{{Check: CheckUnverified, Reason: err.Error()}}
+		}
+		for _, finding := range findings {
+			if finding.Reject {
+				metrics.DiscoveryScreenFindings.WithLabelValues(finding.Check, "reject").Inc()
+				d.log.Info().Str("symbol", cand.Symbol).Str("check", finding.Check).Str("reason", finding.Reason).Msg("discovery candidate rejected")
+				return nil, false
+			}
+			metrics.DiscoveryScreenFindings.WithLabelValues(finding.Check, "penalize").Inc()
+			flags = append(flags, finding.String())
+		}
+	}
+	if len(flags) > 0 {
+		d.log.Info().Str("symbol", cand.Symbol).Strs("findings", flags).Msg("discovery candidate down-scored")
+	}
+	return flags, true
+}
+
 func (d *DexScreenerDiscovery) search(ctx context.Context, keyword string) ([]dexscreenerPair, error) {
 	endpoint := fmt.Sprintf("%s/latest/dex/search?q=%s", d.baseURL, url.QueryEscape(keyword))
+	var payload dexscreenerPairsResponse
+	if err := d.getJSON(ctx, endpoint, &payload); err != nil {
+		return nil, err
+	}
+	if len(payload.Pairs) > 0 {
+		return payload.Pairs, nil
+	}
+	if payload.Pair != nil {
+		return []dexscreenerPair{*payload.Pair}, nil
+	}
+	return nil, nil
+}
+
+func (d *DexScreenerDiscovery) getJSON(ctx context.Context, endpoint string, out any) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	req.Header.Set("User-Agent", "memebot-go/1.0 (discovery)")
 	resp, err := d.client.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
 	}
-	var payload dexscreenerPairsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-		return nil, err
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// applyUniverse merges manual and discovered symbols with those that must stay (held, or still inside
+// their minimum residency), pushes the result to the feed, and logs additions and evictions separately.
+func (d *DexScreenerDiscovery) applyUniverse(candidates []candidatePair, held, pinned []string) {
+	now := d.now()
+	discovered := make([]string, len(candidates))
+	for i, cand := range candidates {
+		discovered[i] = cand.symbol
 	}
-	if len(payload.Pairs) > 0 {
-		return payload.Pairs, nil
+	heldSet := make(map[string]struct{}, len(held))
+	heldPairs := make(map[string]struct{}, len(held))
+	for _, sym := range held {
+		heldSet[sym] = struct{}{}
+		heldPairs[symbolAddress(sym)] = struct{}{}
 	}
-	if payload.Pair != nil {
-		return []dexscreenerPair{*payload.Pair}, nil
+	residency := time.Duration(d.cfg.MinResidencyMs) * time.Millisecond
+	reg := d.feed.Instruments()
+	inSet := func(set []string, sym string) bool {
+		for _, member := range set {
+			if member == sym {
+				return true
+			}
+		}
+		return false
 	}
-	return nil, nil
-}
 
-func (d *DexScreenerDiscovery) logDiscoveryChange(combined []string, discovered []candidatePair) {
 	d.mu.Lock()
-	defer d.mu.Unlock()
-	if slicesEqual(combined, d.lastSet) {
-		return
-	}
 	prev := append([]string(nil), d.lastSet...)
+	var manual []string
+	for _, sym := range d.manual {
+		if _, ok := heldPairs[symbolAddress(sym)]; ok || !d.lists.DeniesSymbol(reg, sym) {
+			manual = append(manual, sym)
+		}
+	}
+	kept := make(map[string]string)
+	var keep []string
+	for _, sym := range prev {
+		if inSet(manual, sym) || inSet(discovered, sym) || inSet(pinned, sym) {
+			continue
+		}
+		if _, ok := heldSet[sym]; ok {
+			kept[sym] = keepHeld
+		} else if admitted, ok := d.admitted[sym]; ok && residency > 0 && now.Sub(admitted) < residency && !d.lists.DeniesSymbol(reg, sym) {
+			// A deny entry added at runtime overrides residency; only held symbols outlast it.
+			kept[sym] = keepResidency
+		} else {
+			continue
+		}
+		keep = append(keep, sym)
+	}
+	// One pool per token: a discovered pool whose token is already polled through a manual, pinned, held, or
+	// residency-kept pool stays out until that pool leaves, so a preferred-pool switch cannot double the token.
+	occupied := make(map[string]string)
+	for _, sym := range mergeSymbols(manual, pinned, keep) {
+		if token := d.symbolTokenLocked(sym); token != "" {
+			occupied[token] = sym
+		}
+	}
+	var refused []string
+	if len(occupied) > 0 {
+		admitted := make([]candidatePair, 0, len(candidates))
+		discovered = discovered[:0]
+		for _, cand := range candidates {
+			if other, ok := occupied[cand.token]; ok && other != cand.symbol {
+				refused = append(refused, cand.symbol+" (token polled as "+other+")")
+				continue
+			}
+			admitted = append(admitted, cand)
+			discovered = append(discovered, cand.symbol)
+		}
+		candidates = admitted
+	}
+	combined := mergeSymbols(manual, pinned, discovered, keep)
+	origins := make(map[string]string, len(combined))
+	for _, sym := range combined {
+		switch {
+		case inSet(manual, sym):
+			origins[sym] = OriginManual
+		case inSet(pinned, sym):
+			origins[sym] = OriginPinned
+		case inSet(discovered, sym):
+			origins[sym] = OriginDiscovered
+		default:
+			origins[sym] = kept[sym]
+		}
+	}
+	prevOrigins := d.origins
+	d.origins = origins
+	d.alternates = make(map[string][]PoolAlternate)
+	for _, cand := range candidates {
+		if len(cand.alternates) > 0 {
+			d.alternates[cand.symbol] = cand.alternates
+		}
+	}
+	var newlyKept []string
+	for _, sym := range keep {
+		if d.kept[sym] != kept[sym] {
+			newlyKept = append(newlyKept, sym+" ("+kept[sym]+")")
+		}
+	}
+	d.kept = kept
+	added, evicted := diffSymbols(combined, prev)
+	for _, sym := range added {
+		d.admitted[sym] = now
+	}
+	for _, cand := range candidates {
+		d.ranked[cand.symbol] = cand
+	}
+	var changes []UniverseChange
+	for _, sym := range added {
+		changes = append(changes, d.universeChange(now, UniverseAdded, sym, origins[sym]))
+	}
+	for _, sym := range evicted {
+		reason := RemovedDropped
+		switch {
+		case d.lists.DeniesSymbol(reg, sym):
+			reason = RemovedDenied
+		case prevOrigins[sym] == OriginPinned:
+			reason = RemovedUnpinned
+		case prevOrigins[sym] == keepHeld:
+			reason = RemovedReleased
+		case prevOrigins[sym] == keepResidency:
+			reason = RemovedResidencyElapsed
+		}
+		changes = append(changes, d.universeChange(now, UniverseRemoved, sym, reason))
+		delete(d.admitted, sym)
+		delete(d.ranked, sym)
+	}
+	history := d.history
+	changed := !slicesEqual(combined, prev)
 	d.lastSet = append([]string(nil), combined...)
-	detail := make([]string, len(discovered))
-	for i, cand := range discovered {
-		detail[i] = fmt.Sprintf("%s(liq=%.0f vol=%.0f Δ24=%.2f)", cand.symbol, cand.liquidity, cand.volume, cand.change24)
+	onChange := d.onChange
+	d.mu.Unlock()
+
+	d.feed.SetSymbols(combined)
+	if history != nil {
+		for _, change := range changes {
+			history.Record(change)
+		}
+	}
+	if len(newlyKept) > 0 {
+		d.log.Info().Strs("kept", newlyKept).Msg("discovery keeping symbols outside the discovered set")
+	}
+	if len(refused) > 0 {
+		d.log.Debug().Strs("refused", refused).Msg("discovery skipped pools of tokens already in the universe")
+	}
+	if !changed {
+		return
 	}
-	d.log.Info().
-		Strs("symbols", combined).
-		Strs("discovered", detail).
-		Strs("manual", d.manual).
-		Strs("previous", prev).
-		Msg("updated symbol universe")
+	if onChange != nil {
+		defer onChange(append([]string(nil), combined...), prev)
+	}
+
+	if len(added) > 0 {
+		byName := make(map[string]candidatePair, len(candidates))
+		for _, cand := range candidates {
+			byName[cand.symbol] = cand
+		}
+		detail := make([]string, 0, len(added))
+		for _, sym := range added {
+			cand, ok := byName[sym]
+			if !ok {
+				detail = append(detail, sym+"(manual)")
+				continue
+			}
+			entry := fmt.Sprintf("%s(liq=%.0f vol=%.0f Δ24=%.2f)", cand.symbol, cand.liquidity, cand.volume, cand.change24)
+			if !cand.createdAt.IsZero() {
+				entry += fmt.Sprintf(" age=%s", now.Sub(cand.createdAt).Round(time.Minute))
+			}
+			if len(cand.alternates) > 0 {
+				entry += fmt.Sprintf(" alt_pools=%d", len(cand.alternates))
+			}
+			if len(cand.flags) > 0 {
+				entry += fmt.Sprintf(" flags=[%s]", strings.Join(cand.flags, "; "))
+			}
+			detail = append(detail, entry)
+		}
+		d.log.Info().Strs("added", detail).Int("universe", len(combined)).Msg("discovery added symbols")
+	}
+	if len(evicted) > 0 {
+		d.log.Info().Strs("evicted", evicted).Int("universe", len(combined)).Msg("discovery evicted symbols")
+	}
+}
+
+// universeChange describes sym entering or leaving the universe using its last ranked metrics; d.mu must be held.
+func (d *DexScreenerDiscovery) universeChange(now time.Time, action, sym, reason string) UniverseChange {
+	change := UniverseChange{Time: now, Action: action, Symbol: sym, Reason: reason}
+	if cand, ok := d.ranked[sym]; ok {
+		change.Score = cand.score
+		change.LiquidityUSD = cand.liquidity
+		change.VolumeUSD = cand.volume
+		change.Change24 = cand.change24
+		change.Flags = cand.flags
+	}
+	return change
 }
 
-func mergeSymbols(manual, discovered []string) []string {
-	set := make(map[string]struct{}, len(manual)+len(discovered))
-	for _, sym := range manual {
-		if sym = strings.TrimSpace(sym); sym != "" {
-			set[sym] = struct{}{}
+// diffSymbols returns the symbols in current but not previous, and in previous but not current.
+func diffSymbols(current, previous []string) (added, removed []string) {
+	prev := make(map[string]struct{}, len(previous))
+	for _, sym := range previous {
+		prev[sym] = struct{}{}
+	}
+	cur := make(map[string]struct{}, len(current))
+	for _, sym := range current {
+		cur[sym] = struct{}{}
+		if _, ok := prev[sym]; !ok {
+			added = append(added, sym)
+		}
+	}
+	for _, sym := range previous {
+		if _, ok := cur[sym]; !ok {
+			removed = append(removed, sym)
 		}
 	}
-	for _, sym := range discovered {
-		if sym = strings.TrimSpace(sym); sym != "" {
-			set[sym] = struct{}{}
+	return added, removed
+}
+
+// symbolTokenLocked is the chain/base-mint key of a feed symbol, taken from its last ranking or its registered
+// instrument; it is empty when the mint is unknown. d.mu must be held.
+func (d *DexScreenerDiscovery) symbolTokenLocked(sym string) string {
+	if cand, ok := d.ranked[sym]; ok {
+		return cand.token
+	}
+	inst, ok := symbolInstrument(d.feed.Instruments(), sym)
+	if !ok || inst.BaseMint == "" {
+		return ""
+	}
+	return inst.Chain + "/" + inst.BaseMint
+}
+
+func mergeSymbols(lists ...[]string) []string {
+	set := make(map[string]struct{})
+	for _, list := range lists {
+		for _, sym := range list {
+			if sym = strings.TrimSpace(sym); sym != "" {
+				set[sym] = struct{}{}
+			}
 		}
 	}
 	out := make([]string, 0, len(set))
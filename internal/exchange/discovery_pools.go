@@ -0,0 +1,95 @@
+package exchange
+
+import (
+	"sort"
+	"strings"
+)
+
+// PoolAlternate is another pool trading a discovered token, kept for pricing cross-checks.
+type PoolAlternate struct {
+	Symbol       string  `json:"symbol"`
+	DexID        string  `json:"dex_id"`
+	Quote        string  `json:"quote"`
+	LiquidityUSD float64 `json:"liquidity_usd"`
+	VolumeUSD    float64 `json:"volume_usd"`
+	PriceUSD     float64 `json:"price_usd"`
+}
+
+// tokenKey identifies a candidate's base token; pairs without a base mint stand alone.
+func tokenKey(chain string, pair dexscreenerPair) string {
+	if pair.BaseToken.Address == "" {
+		return chain + "/pair/" + pair.PairAddress
+	}
+	return chain + "/" + pair.BaseToken.Address
+}
+
+// quoteRank is the position of the candidate's quote in prefer_quotes (by symbol or mint); unlisted quotes rank last.
+func (d *DexScreenerDiscovery) quoteRank(cand candidatePair) int {
+	for i, quote := range d.cfg.Pools.PreferQuotes {
+		quote = strings.TrimSpace(quote)
+		if strings.EqualFold(quote, cand.quote) || quote == cand.quoteMint {
+			return i
+		}
+	}
+	return len(d.cfg.Pools.PreferQuotes)
+}
+
+// selectPools keeps one pool per base token, in first-seen order, and records the others as its alternates.
+// Pools within min_liquidity_share of the deepest are eligible; among them the preferred quote wins,
+// then liquidity, then volume.
+func (d *DexScreenerDiscovery) selectPools(candidates []candidatePair) []candidatePair {
+	share := d.cfg.Pools.MinLiquidityShare
+	if share <= 0 {
+		share = 0.5
+	}
+	groups := make(map[string][]candidatePair)
+	var order []string
+	for _, cand := range candidates {
+		if _, ok := groups[cand.token]; !ok {
+			order = append(order, cand.token)
+		}
+		groups[cand.token] = append(groups[cand.token], cand)
+	}
+	out := make([]candidatePair, 0, len(order))
+	for _, token := range order {
+		pools := groups[token]
+		deepest := 0.0
+		for _, pool := range pools {
+			deepest = max(deepest, pool.liquidity)
+		}
+		eligible := func(c candidatePair) bool { return c.liquidity >= share*deepest }
+		sort.SliceStable(pools, func(i, j int) bool {
+			a, b := pools[i], pools[j]
+			if ea, eb := eligible(a), eligible(b); ea != eb {
+				return ea
+			}
+			if ra, rb := d.quoteRank(a), d.quoteRank(b); ra != rb {
+				return ra < rb
+			}
+			if a.liquidity != b.liquidity {
+				return a.liquidity > b.liquidity
+			}
+			return a.volume > b.volume
+		})
+		best := pools[0]
+		for _, alt := range pools[1:] {
+			best.alternates = append(best.alternates, PoolAlternate{
+				Symbol:       alt.symbol,
+				DexID:        alt.dexID,
+				Quote:        alt.quote,
+				LiquidityUSD: alt.liquidity,
+				VolumeUSD:    alt.volume,
+				PriceUSD:     alt.priceUSD,
+			})
+		}
+		if len(best.alternates) > 0 {
+			d.log.Debug().
+				Str("token", token).
+				Str("symbol", best.symbol).
+				Int("alternates", len(best.alternates)).
+				Msg("discovery kept best pool for token")
+		}
+		out = append(out, best)
+	}
+	return out
+}
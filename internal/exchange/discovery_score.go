@@ -0,0 +1,235 @@
+package exchange
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"memebot-go/internal/config"
+)
+
+// Normalizations accepted by config.ScoreFeature.Normalize.
+const (
+	NormalizeNone   = "none"
+	NormalizeLog    = "log"
+	NormalizeMinMax = "minmax"
+	NormalizeZScore = "zscore"
+	NormalizeRank   = "rank"
+)
+
+// CandidateFeatures holds the raw feature values of one discovery candidate; absent keys were not reported.
+type CandidateFeatures map[string]float64
+
+// ScoreBreakdown is a candidate's total score and each feature's weighted contribution to it.
+type ScoreBreakdown struct {
+	Total         float64
+	Contributions map[string]float64
+}
+
+// Scorer ranks the candidates of one discovery refresh; the result is index-aligned with cands.
+type Scorer interface {
+	Score(cands []CandidateFeatures) []ScoreBreakdown
+}
+
+// pairFeatures extracts every scoring feature a Dexscreener pair can report.
+var pairFeatures = map[string]func(p dexscreenerPair, now time.Time) (float64, bool){
+	"liquidity_usd": func(p dexscreenerPair, _ time.Time) (float64, bool) { return p.Liquidity.USD, true },
+	"volume": func(p dexscreenerPair, _ time.Time) (float64, bool) {
+		return pairVolume(p), true
+	},
+	"volume_m5":        func(p dexscreenerPair, _ time.Time) (float64, bool) { return p.Volume.M5, true },
+	"volume_h1":        func(p dexscreenerPair, _ time.Time) (float64, bool) { return p.Volume.H1, true },
+	"volume_h6":        func(p dexscreenerPair, _ time.Time) (float64, bool) { return p.Volume.H6, true },
+	"volume_h24":       func(p dexscreenerPair, _ time.Time) (float64, bool) { return p.Volume.H24, true },
+	"txns_m5":          func(p dexscreenerPair, _ time.Time) (float64, bool) { return txnCount(p.Txns.M5), true },
+	"txns_h1":          func(p dexscreenerPair, _ time.Time) (float64, bool) { return txnCount(p.Txns.H1), true },
+	"txns_h6":          func(p dexscreenerPair, _ time.Time) (float64, bool) { return txnCount(p.Txns.H6), true },
+	"txns_h24":         func(p dexscreenerPair, _ time.Time) (float64, bool) { return txnCount(p.Txns.H24), true },
+	"buy_ratio_m5":     func(p dexscreenerPair, _ time.Time) (float64, bool) { return buyRatio(p.Txns.M5) },
+	"buy_ratio_h1":     func(p dexscreenerPair, _ time.Time) (float64, bool) { return buyRatio(p.Txns.H1) },
+	"buy_ratio_h6":     func(p dexscreenerPair, _ time.Time) (float64, bool) { return buyRatio(p.Txns.H6) },
+	"buy_ratio_h24":    func(p dexscreenerPair, _ time.Time) (float64, bool) { return buyRatio(p.Txns.H24) },
+	"price_change_m5":  func(p dexscreenerPair, _ time.Time) (float64, bool) { return p.PriceChange.M5, true },
+	"price_change_h1":  func(p dexscreenerPair, _ time.Time) (float64, bool) { return p.PriceChange.H1, true },
+	"price_change_h6":  func(p dexscreenerPair, _ time.Time) (float64, bool) { return p.PriceChange.H6, true },
+	"price_change_h24": func(p dexscreenerPair, _ time.Time) (float64, bool) { return p.PriceChange.H24, true },
+	"age_hours": func(p dexscreenerPair, now time.Time) (float64, bool) {
+		if p.PairCreatedAt <= 0 {
+			return 0, false
+		}
+		return now.Sub(time.UnixMilli(p.PairCreatedAt)).Hours(), true
+	},
+	"fdv_usd":        func(p dexscreenerPair, _ time.Time) (float64, bool) { return p.FDV, p.FDV > 0 },
+	"market_cap_usd": func(p dexscreenerPair, _ time.Time) (float64, bool) { return p.MarketCap, p.MarketCap > 0 },
+}
+
+// extractFeatures reads every known feature from pair.
+func extractFeatures(pair dexscreenerPair, now time.Time) CandidateFeatures {
+	out := make(CandidateFeatures, len(pairFeatures))
+	for name, extract := range pairFeatures {
+		if v, ok := extract(pair, now); ok {
+			out[name] = v
+		}
+	}
+	return out
+}
+
+// pairVolume is the longest reported volume window, falling back from 24h to 6h to 1h.
+func pairVolume(p dexscreenerPair) float64 {
+	switch {
+	case p.Volume.H24 > 0:
+		return p.Volume.H24
+	case p.Volume.H6 > 0:
+		return p.Volume.H6
+	default:
+		return p.Volume.H1
+	}
+}
+
+func txnCount(t dexscreenerTxn) float64 { return float64(t.Buys + t.Sells) }
+
+// buyRatio is buys / (buys + sells), unreported for windows without trades.
+func buyRatio(t dexscreenerTxn) (float64, bool) {
+	total := t.Buys + t.Sells
+	if total == 0 {
+		return 0, false
+	}
+	return float64(t.Buys) / float64(total), true
+}
+
+func floatPtr(v float64) *float64 { return &v }
+
+// defaultScoreFeatures reproduces the original hard-coded ranking.
+var defaultScoreFeatures = []config.ScoreFeature{
+	{Name: "liquidity_usd", Weight: 0.6},
+	{Name: "volume", Weight: 0.35},
+	{Name: "price_change_h24", Weight: 1000, Floor: floatPtr(0)},
+}
+
+// WeightedScorer sums weighted, clipped, and normalized features.
+type WeightedScorer struct {
+	features []config.ScoreFeature
+}
+
+// NewWeightedScorer builds a scorer from cfg, dropping unknown features and normalizations with a warning.
+func NewWeightedScorer(log zerolog.Logger, cfg config.Scoring) *WeightedScorer {
+	features := cfg.Features
+	if len(features) == 0 {
+		features = defaultScoreFeatures
+	}
+	s := &WeightedScorer{}
+	for _, feature := range features {
+		feature.Name = strings.ToLower(strings.TrimSpace(feature.Name))
+		if _, ok := pairFeatures[feature.Name]; !ok {
+			log.Warn().Str("feature", feature.Name).Msg("unknown discovery score feature ignored")
+			continue
+		}
+		switch feature.Normalize = strings.ToLower(strings.TrimSpace(feature.Normalize)); feature.Normalize {
+		case "", NormalizeNone, NormalizeLog, NormalizeMinMax, NormalizeZScore, NormalizeRank:
+		default:
+			log.Warn().Str("feature", feature.Name).Str("normalize", feature.Normalize).Msg("unknown normalization; using raw values")
+			feature.Normalize = NormalizeNone
+		}
+		s.features = append(s.features, feature)
+	}
+	return s
+}
+
+// Score implements Scorer.
+func (s *WeightedScorer) Score(cands []CandidateFeatures) []ScoreBreakdown {
+	out := make([]ScoreBreakdown, len(cands))
+	for i := range out {
+		out[i].Contributions = make(map[string]float64, len(s.features))
+	}
+	for _, feature := range s.features {
+		values := make([]float64, len(cands))
+		present := make([]bool, len(cands))
+		for i, cand := range cands {
+			v, ok := cand[feature.Name]
+			if !ok {
+				continue
+			}
+			if feature.Floor != nil && v < *feature.Floor {
+				v = *feature.Floor
+			}
+			if feature.Cap != nil && v > *feature.Cap {
+				v = *feature.Cap
+			}
+			values[i], present[i] = v, true
+		}
+		normalized := normalize(feature.Normalize, values, present)
+		for i := range cands {
+			if !present[i] {
+				continue
+			}
+			contribution := feature.Weight * normalized[i]
+			out[i].Contributions[feature.Name] = contribution
+			out[i].Total += contribution
+		}
+	}
+	return out
+}
+
+// normalize rescales the present values; relative methods only consider candidates reporting the feature.
+func normalize(method string, values []float64, present []bool) []float64 {
+	out := make([]float64, len(values))
+	var set []float64
+	for i, v := range values {
+		if present[i] {
+			set = append(set, v)
+		}
+	}
+	if len(set) == 0 {
+		return out
+	}
+	switch method {
+	case NormalizeLog:
+		for i, v := range values {
+			out[i] = math.Copysign(math.Log10(1+math.Abs(v)), v)
+		}
+	case NormalizeMinMax:
+		lo, hi := set[0], set[0]
+		for _, v := range set {
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+		}
+		for i, v := range values {
+			if hi > lo {
+				out[i] = (v - lo) / (hi - lo)
+			}
+		}
+	case NormalizeZScore:
+		var mean, variance float64
+		for _, v := range set {
+			mean += v
+		}
+		mean /= float64(len(set))
+		for _, v := range set {
+			variance += (v - mean) * (v - mean)
+		}
+		std := math.Sqrt(variance / float64(len(set)))
+		for i, v := range values {
+			if std > 0 {
+				out[i] = (v - mean) / std
+			}
+		}
+	case NormalizeRank:
+		sorted := append([]float64(nil), set...)
+		sort.Float64s(sorted)
+		for i, v := range values {
+			if len(sorted) == 1 {
+				out[i] = 1
+				continue
+			}
+			// Ties share the average of their positions.
+			lo := sort.SearchFloat64s(sorted, v)
+			hi := sort.Search(len(sorted), func(j int) bool { return sorted[j] > v })
+			out[i] = (float64(lo+hi-1) / 2) / float64(len(sorted)-1)
+		}
+	default:
+		copy(out, values)
+	}
+	return out
+}
@@ -0,0 +1,96 @@
+package exchange
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"memebot-go/internal/config"
+)
+
+func TestExtractFeatures(t *testing.T) {
+	now := time.Now()
+	pair := dexscreenerPair{
+		Txns:          dexscreenerTxns{H1: dexscreenerTxn{Buys: 30, Sells: 10}},
+		Volume:        dexscreenerVolumes{H6: 5000, H1: 1200},
+		Liquidity:     dexscreenerLiquidity{USD: 40000},
+		PriceChange:   dexscreenerPriceChange{H24: 350},
+		FDV:           2_000_000,
+		PairCreatedAt: now.Add(-3 * time.Hour).UnixMilli(),
+	}
+	features := extractFeatures(pair, now)
+	want := map[string]float64{
+		"liquidity_usd":    40000,
+		"volume":           5000, // no 24h volume, falls back to 6h
+		"txns_h1":          40,
+		"buy_ratio_h1":     0.75,
+		"price_change_h24": 350,
+		"fdv_usd":          2_000_000,
+		"age_hours":        3,
+	}
+	for name, v := range want {
+		if got, ok := features[name]; !ok || math.Abs(got-v) > 1e-6 {
+			t.Fatalf("feature %s = %v (present %v), want %v", name, got, ok, v)
+		}
+	}
+	for _, absent := range []string{"buy_ratio_m5", "market_cap_usd"} {
+		if _, ok := features[absent]; ok {
+			t.Fatalf("feature %s should be absent without data", absent)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	values := []float64{10, 20, 40, 0}
+	present := []bool{true, true, true, false}
+	cases := map[string][]float64{
+		NormalizeNone:   {10, 20, 40, 0},
+		NormalizeMinMax: {0, 1.0 / 3, 1, 0},
+		NormalizeRank:   {0, 0.5, 1, 0},
+		NormalizeLog:    {math.Log10(11), math.Log10(21), math.Log10(41), 0},
+	}
+	for method, want := range cases {
+		got := normalize(method, values, present)
+		for i := range want {
+			if present[i] && math.Abs(got[i]-want[i]) > 1e-9 {
+				t.Fatalf("%s: got %v, want %v", method, got, want)
+			}
+		}
+	}
+	z := normalize(NormalizeZScore, values, present)
+	if math.Abs(z[0]+z[1]+z[2]) > 1e-9 || z[2] <= z[1] || z[1] <= z[0] {
+		t.Fatalf("zscore should be centred and ordered, got %v", z)
+	}
+	if tied := normalize(NormalizeRank, []float64{5, 5, 9}, []bool{true, true, true}); tied[0] != 0.25 || tied[1] != 0.25 || tied[2] != 1 {
+		t.Fatalf("ties should share the average rank, got %v", tied)
+	}
+}
+
+func TestWeightedScorerCapsPumps(t *testing.T) {
+	steady := CandidateFeatures{"liquidity_usd": 80000, "price_change_h24": 20}
+	pump := CandidateFeatures{"liquidity_usd": 20000, "price_change_h24": 900}
+
+	// The default model reproduces the legacy formula, where the pump wins on its 24h change alone.
+	legacy := NewWeightedScorer(zerolog.Nop(), config.Scoring{}).Score([]CandidateFeatures{steady, pump})
+	if legacy[1].Total <= legacy[0].Total {
+		t.Fatalf("expected legacy model to favour the pump, got %+v", legacy)
+	}
+
+	model := NewWeightedScorer(zerolog.Nop(), config.Scoring{Features: []config.ScoreFeature{
+		{Name: "liquidity_usd", Weight: 0.7, Normalize: NormalizeMinMax},
+		{Name: "price_change_h24", Weight: 0.3, Normalize: NormalizeMinMax, Cap: floatPtr(50)},
+		{Name: "no_such_feature", Weight: 10},
+	}})
+	scores := model.Score([]CandidateFeatures{steady, pump})
+	if scores[0].Total <= scores[1].Total {
+		t.Fatalf("expected capped model to favour liquidity, got %+v", scores)
+	}
+	if got := scores[0].Contributions["liquidity_usd"]; math.Abs(got-0.7) > 1e-9 {
+		t.Fatalf("unexpected liquidity contribution %v", got)
+	}
+	if _, ok := scores[0].Contributions["no_such_feature"]; ok {
+		t.Fatalf("unknown features should be dropped")
+	}
+}
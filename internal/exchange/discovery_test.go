@@ -2,8 +2,12 @@ package exchange
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -108,3 +112,330 @@ func TestDexScreenerDiscoveryVolumeFilter(t *testing.T) {
 		t.Fatalf("expected only manual symbol, got %+v", symbols)
 	}
 }
+
+func TestDexScreenerDiscoveryNewListings(t *testing.T) {
+	now := time.Now()
+	pair := func(address string, age time.Duration, liquidity float64) string {
+		return fmt.Sprintf(`{"chainId": "solana", "pairAddress": %q, "baseToken": {"address": "TOKA", "symbol": "NEW"},
+			"quoteToken": {"symbol": "SOL"}, "volume": {"h1": 9000}, "liquidity": {"usd": %f}, "pairCreatedAt": %d}`,
+			address, liquidity, now.Add(-age).UnixMilli())
+	}
+	var tokenPaths []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token-profiles/latest/v1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"chainId": "solana", "tokenAddress": "TOKA"}, {"chainId": "ethereum", "tokenAddress": "0xETH"}]`))
+	})
+	mux.HandleFunc("/token-boosts/latest/v1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"chainId": "solana", "tokenAddress": "TOKA", "amount": 10}, {"chainId": "solana", "tokenAddress": "TOKB", "amount": 50}]`))
+	})
+	mux.HandleFunc("/token-boosts/top/v1", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	})
+	mux.HandleFunc("/latest/dex/tokens/", func(w http.ResponseWriter, r *http.Request) {
+		tokenPaths = append(tokenPaths, r.URL.Path)
+		_, _ = fmt.Fprintf(w, `{"pairs": [%s, %s, %s, %s]}`,
+			pair("FRESH", 30*time.Minute, 20000),
+			pair("NEWBORN", 2*time.Minute, 20000),
+			pair("STALE", 48*time.Hour, 90000),
+			pair("THIN", time.Hour, 100))
+	})
+	mux.HandleFunc("/latest/dex/search", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("new_listings mode should not run keyword searches")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	feed := NewFeed(ProviderDexScreener, nil, zerolog.Nop())
+	discCfg := config.Discovery{
+		Enabled:         true,
+		Mode:            DiscoveryModeNewListings,
+		Chains:          []string{"solana"},
+		MaxPairs:        5,
+		MinLiquidityUSD: 5000,
+		NewListings:     config.NewListings{MinAgeMs: int((10 * time.Minute).Milliseconds()), MaxAgeMs: int((6 * time.Hour).Milliseconds())},
+	}
+	disc := NewDexScreenerDiscovery(zerolog.Nop(), feed, nil, config.DexScreener{BaseURL: server.URL}, discCfg)
+	disc.client = server.Client()
+
+	if err := disc.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+	if len(tokenPaths) != 1 || tokenPaths[0] != "/latest/dex/tokens/TOKA,TOKB" {
+		t.Fatalf("unexpected token lookups %v", tokenPaths)
+	}
+	symbols := feed.snapshotSymbols()
+	if len(symbols) != 1 || !strings.HasSuffix(symbols[0], "@solana/FRESH") {
+		t.Fatalf("expected only the pair inside the age window, got %v", symbols)
+	}
+}
+
+type staticHoldings []string
+
+func (h *staticHoldings) HeldSymbols() []string { return *h }
+
+func TestDexScreenerDiscoveryPinsHeldSymbolsAndResidency(t *testing.T) {
+	var served atomic.Value
+	served.Store([]string{"AAA", "BBB"})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var pairs []string
+		for _, address := range served.Load().([]string) {
+			pairs = append(pairs, fmt.Sprintf(`{"chainId": "solana", "pairAddress": %q, "baseToken": {"symbol": %q},
+				"quoteToken": {"symbol": "SOL"}, "volume": {"h24": 10000}, "liquidity": {"usd": 10000}}`, address, address))
+		}
+		_, _ = fmt.Fprintf(w, `{"pairs": [%s]}`, strings.Join(pairs, ","))
+	}))
+	defer server.Close()
+
+	feed := NewFeed(ProviderDexScreener, nil, zerolog.Nop())
+	discCfg := config.Discovery{
+		Enabled:        true,
+		Keywords:       []string{"any"},
+		Chains:         []string{"solana"},
+		MaxPairs:       5,
+		MinResidencyMs: int((5 * time.Minute).Milliseconds()),
+	}
+	disc := NewDexScreenerDiscovery(zerolog.Nop(), feed, nil, config.DexScreener{BaseURL: server.URL}, discCfg)
+	disc.client = server.Client()
+	held := &staticHoldings{}
+	disc.PinHeld(held)
+	lists, _ := LoadSymbolLists("")
+	disc.SetLists(lists)
+	clock := time.Now()
+	disc.now = func() time.Time { return clock }
+	var removed []string
+	disc.OnChange(func(current, previous []string) {
+		_, gone := diffSymbols(current, previous)
+		removed = append(removed, gone...)
+	})
+
+	universe := func() string {
+		var short []string
+		for _, sym := range feed.snapshotSymbols() {
+			short = append(short, sym[strings.LastIndex(sym, "/")+1:])
+		}
+		return strings.Join(short, ",")
+	}
+	refresh := func(step string, want string) {
+		t.Helper()
+		if err := disc.Refresh(context.Background()); err != nil {
+			t.Fatalf("%s: Refresh returned error: %v", step, err)
+		}
+		if got := universe(); got != want {
+			t.Fatalf("%s: universe %s, want %s", step, got, want)
+		}
+	}
+
+	refresh("initial", "AAA,BBB")
+
+	// Both drop out of the top pairs: AAA is held (positions carry the instrument symbol), BBB is still inside its residency.
+	inst, ok := feed.Instruments().ByPair("solana", "AAA")
+	if !ok {
+		t.Fatalf("discovered pair AAA was not registered")
+	}
+	*held = []string{inst.Symbol}
+	served.Store([]string{"CCC"})
+	clock = clock.Add(time.Minute)
+	refresh("within residency", "AAA,BBB,CCC")
+
+	// A deny entry cuts residency short.
+	if _, err := lists.Add(ListDeny, ListEntry{Key: "BBB"}); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	refresh("denied within residency", "AAA,CCC")
+
+	clock = clock.Add(10 * time.Minute)
+	refresh("residency elapsed", "AAA,CCC")
+
+	*held = nil
+	refresh("flat", "CCC")
+
+	if len(removed) != 2 || !strings.HasSuffix(removed[0], "/BBB") || !strings.HasSuffix(removed[1], "/AAA") {
+		t.Fatalf("unexpected evictions %v", removed)
+	}
+}
+
+func TestDexScreenerDiscoveryAppliesSymbolLists(t *testing.T) {
+	pair := func(address, base, mint string, liquidity float64) string {
+		return fmt.Sprintf(`{"chainId": "solana", "pairAddress": %q, "baseToken": {"address": %q, "symbol": %q},
+			"quoteToken": {"symbol": "SOL"}, "volume": {"h24": 10000}, "liquidity": {"usd": %v}}`, address, mint, base, liquidity)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var pairs []string
+		switch {
+		case r.URL.Path == "/latest/dex/search":
+			pairs = []string{pair("AAA", "AAA", "MINTA", 10000), pair("BBB", "BBB", "MINTB", 20000)}
+		case r.URL.Path == "/latest/dex/pairs/solana/MINTX,PINNED":
+			pairs = []string{pair("PINNED", "PIN", "MINTP", 500)}
+		case r.URL.Path == "/latest/dex/tokens/MINTX":
+			pairs = []string{pair("XSHALLOW", "X", "MINTX", 100), pair("XDEEP", "X", "MINTX", 900)}
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		_, _ = fmt.Fprintf(w, `{"pairs": [%s]}`, strings.Join(pairs, ","))
+	}))
+	defer server.Close()
+
+	lists, err := LoadSymbolLists("")
+	if err != nil {
+		t.Fatalf("LoadSymbolLists returned error: %v", err)
+	}
+	// MINTB denies pair BBB by its base mint; MINTX is allowed by mint and resolves to its deepest pair.
+	for _, e := range []struct{ list, key string }{{ListDeny, "MINTB"}, {ListDeny, "MANUALBAD"}, {ListAllow, "PINNED"}, {ListAllow, "MINTX"}} {
+		if _, err := lists.Add(e.list, ListEntry{Key: e.key}); err != nil {
+			t.Fatalf("Add returned error: %v", err)
+		}
+	}
+
+	feed := NewFeed(ProviderDexScreener, nil, zerolog.Nop())
+	manual := []string{"KEEP@solana/MANUAL", "BAD@solana/MANUALBAD"}
+	discCfg := config.Discovery{Enabled: true, Keywords: []string{"any"}, Chains: []string{"solana"}, MaxPairs: 5}
+	disc := NewDexScreenerDiscovery(zerolog.Nop(), feed, manual, config.DexScreener{BaseURL: server.URL}, discCfg)
+	disc.client = server.Client()
+	disc.SetLists(lists)
+	if err := disc.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+
+	origins := make(map[string]string)
+	for _, entry := range disc.Universe() {
+		origins[entry.Symbol[strings.LastIndex(entry.Symbol, "/")+1:]] = entry.Origin
+	}
+	want := map[string]string{"MANUAL": OriginManual, "AAA": OriginDiscovered, "PINNED": OriginPinned, "XDEEP": OriginPinned}
+	if fmt.Sprint(origins) != fmt.Sprint(want) {
+		t.Fatalf("universe origins %v, want %v", origins, want)
+	}
+	if got := len(feed.snapshotSymbols()); got != len(want) {
+		t.Fatalf("feed polls %d symbols, want %d", got, len(want))
+	}
+}
+
+func TestDexScreenerDiscoveryKeepsBestPoolPerToken(t *testing.T) {
+	pair := func(address, dexID, mint, quote string, liquidity float64, price string) string {
+		return fmt.Sprintf(`{"chainId": "solana", "dexId": %q, "pairAddress": %q, "baseToken": {"address": %q, "symbol": "MEME"},
+			"quoteToken": {"symbol": %q}, "priceUsd": %q, "volume": {"h24": 10000}, "liquidity": {"usd": %v}}`,
+			dexID, address, mint, quote, price, liquidity)
+	}
+	pairs := []string{
+		pair("ASOL", "raydium", "MINTA", "SOL", 10000, "0.010"),
+		pair("AUSDC", "orca", "MINTA", "USDC", 6000, "0.011"),
+		pair("AMETEORA", "meteora", "MINTA", "SOL", 2000, "0.012"),
+		// The USDC pool is too shallow to beat the deep SOL pool.
+		pair("BSOL", "raydium", "MINTB", "SOL", 10000, "1"),
+		pair("BUSDC", "orca", "MINTB", "USDC", 1000, "1"),
+		pair("CSOL", "raydium", "MINTC", "SOL", 50000, "2"),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"pairs": [%s]}`, strings.Join(pairs, ","))
+	}))
+	defer server.Close()
+
+	feed := NewFeed(ProviderDexScreener, nil, zerolog.Nop())
+	discCfg := config.Discovery{
+		Enabled:  true,
+		Keywords: []string{"meme"},
+		Chains:   []string{"solana"},
+		MaxPairs: 2, // extra pools of one token must not crowd out the second token
+		Pools:    config.Pools{PreferQuotes: []string{"USDC", "SOL"}, MinLiquidityShare: 0.5},
+	}
+	disc := NewDexScreenerDiscovery(zerolog.Nop(), feed, nil, config.DexScreener{BaseURL: server.URL}, discCfg)
+	disc.client = server.Client()
+	if err := disc.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+
+	var got []string
+	for _, sym := range feed.snapshotSymbols() {
+		got = append(got, sym[strings.LastIndex(sym, "/")+1:])
+	}
+	sort.Strings(got)
+	if strings.Join(got, ",") != "AUSDC,BSOL" {
+		t.Fatalf("unexpected universe %v", got)
+	}
+	var chosen string
+	for _, entry := range disc.Universe() {
+		if strings.HasSuffix(entry.Symbol, "/AUSDC") {
+			chosen = entry.Symbol
+			if len(entry.Alternates) != 2 {
+				t.Fatalf("expected two alternates in the universe entry, got %+v", entry.Alternates)
+			}
+		}
+	}
+	alts := disc.Alternates(chosen)
+	if len(alts) != 2 || !strings.HasSuffix(alts[0].Symbol, "/ASOL") || alts[0].DexID != "raydium" || alts[0].PriceUSD != 0.01 ||
+		!strings.HasSuffix(alts[1].Symbol, "/AMETEORA") {
+		t.Fatalf("unexpected alternates %+v", alts)
+	}
+}
+
+func TestDexScreenerDiscoveryKeepsOnePoolPerTokenAcrossOrigins(t *testing.T) {
+	pair := func(address, mint, quote string, liquidity float64) string {
+		return fmt.Sprintf(`{"chainId": "solana", "pairAddress": %q, "baseToken": {"address": %q, "symbol": "MEME"},
+			"quoteToken": {"symbol": %q}, "volume": {"h24": 10000}, "liquidity": {"usd": %v}}`, address, mint, quote, liquidity)
+	}
+	var usdcLiquidity atomic.Int64
+	usdcLiquidity.Store(8000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var pairs []string
+		switch r.URL.Path {
+		case "/latest/dex/search":
+			pairs = []string{
+				pair("AUSDC", "MINTA", "USDC", float64(usdcLiquidity.Load())), pair("ASOL", "MINTA", "SOL", 10000),
+				pair("CUSDC", "MINTC", "USDC", 900), pair("CSOL", "MINTC", "SOL", 1000),
+			}
+		case "/latest/dex/tokens/MINTC":
+			pairs = []string{pair("CUSDC", "MINTC", "USDC", 900), pair("CSOL", "MINTC", "SOL", 1000)}
+		}
+		_, _ = fmt.Fprintf(w, `{"pairs": [%s]}`, strings.Join(pairs, ","))
+	}))
+	defer server.Close()
+
+	lists, err := LoadSymbolLists("")
+	if err != nil {
+		t.Fatalf("LoadSymbolLists returned error: %v", err)
+	}
+	// MINTC is pinned through its deepest pool, CSOL, while discovery prefers its USDC pool.
+	if _, err := lists.Add(ListAllow, ListEntry{Key: "MINTC"}); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	feed := NewFeed(ProviderDexScreener, nil, zerolog.Nop())
+	discCfg := config.Discovery{
+		Enabled:  true,
+		Keywords: []string{"meme"},
+		Chains:   []string{"solana"},
+		MaxPairs: 5,
+		Pools:    config.Pools{PreferQuotes: []string{"USDC", "SOL"}, MinLiquidityShare: 0.5},
+	}
+	disc := NewDexScreenerDiscovery(zerolog.Nop(), feed, nil, config.DexScreener{BaseURL: server.URL}, discCfg)
+	disc.client = server.Client()
+	disc.SetLists(lists)
+	held := &staticHoldings{}
+	disc.PinHeld(held)
+
+	refresh := func(step, want string) {
+		t.Helper()
+		if err := disc.Refresh(context.Background()); err != nil {
+			t.Fatalf("%s: Refresh returned error: %v", step, err)
+		}
+		var got []string
+		for _, sym := range feed.snapshotSymbols() {
+			got = append(got, sym[strings.LastIndex(sym, "/")+1:])
+		}
+		sort.Strings(got)
+		if strings.Join(got, ",") != want {
+			t.Fatalf("%s: universe %v, want %s", step, got, want)
+		}
+	}
+	refresh("initial", "AUSDC,CSOL")
+
+	// The USDC pool thins out so the SOL pool becomes preferred, but a position still holds the USDC pool.
+	inst, ok := feed.Instruments().ByPair("solana", "AUSDC")
+	if !ok {
+		t.Fatalf("discovered pair AUSDC was not registered")
+	}
+	*held = []string{inst.Symbol}
+	usdcLiquidity.Store(1000)
+	refresh("preferred pool changed while held", "AUSDC,CSOL")
+
+	*held = nil
+	refresh("flat", "ASOL,CSOL")
+}
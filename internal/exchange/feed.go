@@ -10,6 +10,8 @@ import (
 
 	"github.com/rs/zerolog"
 
+	"memebot-go/internal/config"
+	"memebot-go/internal/instrument"
 	"memebot-go/internal/metrics"
 	"memebot-go/internal/signal"
 )
@@ -21,6 +23,10 @@ const (
 	ProviderBinance = "binance"
 	// ProviderDexScreener polls the Dexscreener HTTP API for on-chain meme coin pairs.
 	ProviderDexScreener = "dexscreener"
+	// ProviderReplay re-emits ticks previously captured by a TickRecorder.
+	ProviderReplay = "replay"
+	// ProviderSolana streams on-chain swaps for Raydium/Orca pools over Solana RPC websocket subscriptions.
+	ProviderSolana = "solana"
 )
 
 // Feed represents a pluggable market data stream implementation.
@@ -31,7 +37,23 @@ type Feed struct {
 	pollInterval            time.Duration
 	dexscreenerBaseURL      string
 	dexscreenerDefaultChain string
-	lastPrices              map[string]float64
+	dexscreenerBatchSize    int
+	dexscreenerConcurrency  int
+	dexscreenerSeen         map[string]dexscreenerObservation // previous poll per alias, owned by the poll loop
+	lastTicks               map[string]lastTick               // latest tick per emitted symbol, guarded by mu
+	recorder                TickRecorder
+	replayPath              string
+	replaySpeed             float64
+	binanceStreamURL        string
+	binanceDepthLevels      int
+	solanaWSURL             string
+	solanaCommitment        string
+	solanaRPCURL            string                // HTTP RPC for block times; derived from solanaWSURL when empty
+	solanaPools             map[string]SolanaPool // registered pools keyed by symbol
+	binanceTradeIDs         map[string]int64      // last trade ID per symbol for gap detection, guarded by mu
+	symbolsChanged          chan struct{}         // signalled by SetSymbols so streaming providers can resubscribe
+	symbolFilter            func(symbol string) bool
+	instruments             *instrument.Registry
 	mu                      sync.RWMutex
 }
 
@@ -41,6 +63,10 @@ type Option func(*Feed)
 const (
 	defaultPollInterval       = 2 * time.Second
 	defaultDexScreenerBaseURL = "https://api.dexscreener.com"
+	defaultBinanceStreamURL   = "wss://stream.binance.com:9443"
+	// dexscreenerMaxBatch is the most pair addresses the pairs endpoint accepts per request.
+	dexscreenerMaxBatch           = 30
+	defaultDexScreenerConcurrency = 4
 )
 
 // WithPollInterval overrides the default polling cadence for HTTP-based feeds.
@@ -64,6 +90,56 @@ func WithDexScreenerConfig(baseURL, defaultChain string) Option {
 	}
 }
 
+// WithDexScreenerBatching sets how many pair addresses share one request (capped at the API limit of 30)
+// and how many batch requests may be in flight at once.
+func WithDexScreenerBatching(batchSize, concurrency int) Option {
+	return func(f *Feed) {
+		if batchSize > 0 {
+			f.dexscreenerBatchSize = min(batchSize, dexscreenerMaxBatch)
+		}
+		if concurrency > 0 {
+			f.dexscreenerConcurrency = concurrency
+		}
+	}
+}
+
+// WithTickRecorder persists every emitted tick through the supplied recorder.
+func WithTickRecorder(rec TickRecorder) Option {
+	return func(f *Feed) {
+		f.recorder = rec
+	}
+}
+
+// WithReplayConfig points the replay provider at a recorded tick file; speed scales the
+// original pacing (1 = real time, 10 = 10x) and values <= 0 replay as fast as possible.
+func WithReplayConfig(path string, speed float64) Option {
+	return func(f *Feed) {
+		f.replayPath = strings.TrimSpace(path)
+		f.replaySpeed = speed
+	}
+}
+
+// WithBinanceConfig overrides the websocket base URL and enables partial book depth streams;
+// depthLevels is rounded up to 5, 10, or 20 and 0 keeps the feed trade-only.
+func WithBinanceConfig(streamURL string, depthLevels int) Option {
+	return func(f *Feed) {
+		if streamURL != "" {
+			f.binanceStreamURL = strings.TrimSuffix(streamURL, "/")
+		}
+		f.binanceDepthLevels = depthLevels
+	}
+}
+
+// WithInstruments shares one instrument registry between feeds, discovery, and execution so every
+// component agrees on the symbol of each market.
+func WithInstruments(reg *instrument.Registry) Option {
+	return func(f *Feed) {
+		if reg != nil {
+			f.instruments = reg
+		}
+	}
+}
+
 // NewFeed constructs a feed backed by the requested provider.
 func NewFeed(provider string, symbols []string, log zerolog.Logger, opts ...Option) *Feed {
 	if provider == "" {
@@ -75,7 +151,17 @@ func NewFeed(provider string, symbols []string, log zerolog.Logger, opts ...Opti
 		pollInterval:            defaultPollInterval,
 		dexscreenerBaseURL:      defaultDexScreenerBaseURL,
 		dexscreenerDefaultChain: "",
-		lastPrices:              make(map[string]float64),
+		dexscreenerBatchSize:    dexscreenerMaxBatch,
+		dexscreenerConcurrency:  defaultDexScreenerConcurrency,
+		binanceStreamURL:        defaultBinanceStreamURL,
+		dexscreenerSeen:         make(map[string]dexscreenerObservation),
+		lastTicks:               make(map[string]lastTick),
+		solanaWSURL:             defaultSolanaWSURL,
+		solanaCommitment:        "confirmed",
+		solanaPools:             make(map[string]SolanaPool),
+		binanceTradeIDs:         make(map[string]int64),
+		symbolsChanged:          make(chan struct{}, 1),
+		instruments:             instrument.NewRegistry(log),
 	}
 	f.setSymbols(symbols)
 	for _, opt := range opts {
@@ -90,9 +176,21 @@ func NewFeed(provider string, symbols []string, log zerolog.Logger, opts ...Opti
 	return f
 }
 
-// SetSymbols replaces the tracked symbol list (deduplicated, sorted for determinism).
+// SetSymbols replaces the tracked symbol list (deduplicated, sorted for determinism) and
+// notifies a running streaming provider so it can resubscribe without reconnecting.
 func (f *Feed) SetSymbols(symbols []string) {
 	f.setSymbols(symbols)
+	select {
+	case f.symbolsChanged <- struct{}{}:
+	default:
+	}
+}
+
+// SetSymbolFilter installs a predicate applied to every later symbol update; symbols it rejects are not polled.
+func (f *Feed) SetSymbolFilter(keep func(symbol string) bool) {
+	f.mu.Lock()
+	f.symbolFilter = keep
+	f.mu.Unlock()
 }
 
 func (f *Feed) setSymbols(symbols []string) {
@@ -104,6 +202,10 @@ func (f *Feed) setSymbols(symbols []string) {
 		if sym == "" {
 			continue
 		}
+		if f.symbolFilter != nil && !f.symbolFilter(sym) {
+			f.log.Debug().Str("provider", f.provider).Str("symbol", sym).Msg("symbol filtered from feed")
+			continue
+		}
 		unique[sym] = struct{}{}
 	}
 	f.symbols = f.symbols[:0]
@@ -111,6 +213,25 @@ func (f *Feed) setSymbols(symbols []string) {
 		f.symbols = append(f.symbols, sym)
 	}
 	sort.Strings(f.symbols)
+	f.pruneLastTicksLocked()
+}
+
+// Instruments returns the registry assigning this feed's instrument symbols.
+func (f *Feed) Instruments() *instrument.Registry {
+	return f.instruments
+}
+
+// FeedSymbols maps instrument symbols, as carried by ticks and positions, to the subscription symbols
+// SetSymbols accepts; symbols the registry does not know pass through unchanged.
+func (f *Feed) FeedSymbols(symbols []string) []string {
+	out := make([]string, 0, len(symbols))
+	for _, sym := range symbols {
+		if inst, ok := f.instruments.Lookup(sym); ok {
+			sym = inst.FeedSymbol()
+		}
+		out = append(out, sym)
+	}
+	return out
 }
 
 func (f *Feed) snapshotSymbols() []string {
@@ -128,6 +249,10 @@ func (f *Feed) Run(ctx context.Context, out chan<- signal.Tick) error {
 		return f.runBinance(ctx, out)
 	case ProviderDexScreener:
 		return f.runDexScreener(ctx, out)
+	case ProviderReplay:
+		return f.runReplay(ctx, out)
+	case ProviderSolana:
+		return f.runSolana(ctx, out)
 	default:
 		return f.runStub(ctx, out)
 	}
@@ -147,13 +272,61 @@ func (f *Feed) runStub(ctx context.Context, out chan<- signal.Tick) error {
 			symbols := f.snapshotSymbols()
 			for _, s := range symbols {
 				tick := signal.Tick{Symbol: s, Price: px, Size: 1, Side: 1, Ts: ts}
-				select {
-				case out <- tick:
-					metrics.TicksTotal.WithLabelValues(s).Inc()
-				case <-ctx.Done():
-					return ctx.Err()
+				if err := f.emit(ctx, out, tick); err != nil {
+					return err
 				}
 			}
 		}
 	}
 }
+
+// emit tags the tick with its provider and delivers it downstream, bumping metrics, stamping the
+// symbol's last-tick time, and persisting it when recording is enabled.
+func (f *Feed) emit(ctx context.Context, out chan<- signal.Tick, tick signal.Tick) error {
+	if tick.Provider == "" {
+		tick.Provider = f.provider
+	}
+	select {
+	case out <- tick:
+		metrics.TicksTotal.WithLabelValues(tick.Symbol).Inc()
+		f.touch(tick.Provider, tick.Symbol, time.Now())
+		if f.recorder != nil {
+			f.recorder.Record(tick)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ConfigOptions translates the exchange config block into feed options; provider-specific
+// options only affect the provider they target, so the full set is safe for every child feed.
+func ConfigOptions(cfg config.Exchange) []Option {
+	opts := []Option{
+		WithDexScreenerConfig(cfg.DexScreener.BaseURL, cfg.DexScreener.DefaultChain),
+		WithDexScreenerBatching(cfg.DexScreener.BatchSize, cfg.DexScreener.MaxConcurrency),
+		WithBinanceConfig(cfg.Binance.StreamURL, cfg.Binance.DepthLevels),
+		WithReplayConfig(cfg.Replay.Path, cfg.Replay.Speed),
+		WithSolanaConfig(cfg.Solana.WSURL, cfg.Solana.Commitment, solanaPools(cfg.Solana.Pools)),
+		WithSolanaRPCURL(cfg.Solana.RPCURL),
+	}
+	if cfg.DexScreener.PollInterval > 0 {
+		opts = append(opts, WithPollInterval(time.Duration(cfg.DexScreener.PollInterval)*time.Millisecond))
+	}
+	return opts
+}
+
+func solanaPools(cfg []config.SolanaPool) []SolanaPool {
+	pools := make([]SolanaPool, 0, len(cfg))
+	for _, pool := range cfg {
+		pools = append(pools, SolanaPool{
+			Symbol:        pool.Symbol,
+			Address:       pool.Address,
+			Dex:           strings.ToLower(pool.Dex),
+			BaseDecimals:  pool.BaseDecimals,
+			QuoteDecimals: pool.QuoteDecimals,
+			Invert:        pool.Invert,
+		})
+	}
+	return pools
+}
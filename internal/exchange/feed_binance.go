@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -16,29 +17,50 @@ import (
 )
 
 type binanceEnvelope struct {
-	Stream string       `json:"stream"`
-	Data   binanceTrade `json:"data"`
+	Stream string          `json:"stream"`
+	Data   json.RawMessage `json:"data"`
+	// ID, Result, and Error are set on replies to SUBSCRIBE/UNSUBSCRIBE requests instead of Stream/Data.
+	ID     *int64          `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *binanceWSError `json:"error"`
+}
+
+// binanceWSError is the error body Binance returns for a rejected websocket request.
+type binanceWSError struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// binanceRequest is a live SUBSCRIBE/UNSUBSCRIBE control message on the combined stream.
+type binanceRequest struct {
+	Method string   `json:"method"`
+	Params []string `json:"params"`
+	ID     int64    `json:"id"`
 }
 
 type binanceTrade struct {
+	TradeID      int64  `json:"t"`
 	Price        string `json:"p"`
 	Quantity     string `json:"q"`
 	TradeTime    int64  `json:"T"`
 	IsBuyerMaker bool   `json:"m"`
 }
 
+// binanceDepth is the partial book depth payload; levels are [price, qty] string pairs.
+type binanceDepth struct {
+	LastUpdateID int64       `json:"lastUpdateId"`
+	Bids         [][2]string `json:"bids"`
+	Asks         [][2]string `json:"asks"`
+}
+
+// binanceDepthLevels lists the book sizes Binance offers on partial depth streams.
+var binanceDepthLevels = []int{5, 10, 20}
+
 func (f *Feed) runBinance(ctx context.Context, out chan<- signal.Tick) error {
-	symbols := f.snapshotSymbols()
-	if len(symbols) == 0 {
+	if len(f.snapshotSymbols()) == 0 {
 		return fmt.Errorf("binance feed requires at least one symbol")
 	}
 
-	streams := make([]string, len(symbols))
-	for i, sym := range symbols {
-		streams[i] = strings.ToLower(sym) + "@trade"
-	}
-
-	url := fmt.Sprintf("wss://stream.binance.com:9443/stream?streams=%s", strings.Join(streams, "/"))
 	backoff := time.Second
 	const maxBackoff = 30 * time.Second
 
@@ -46,11 +68,25 @@ func (f *Feed) runBinance(ctx context.Context, out chan<- signal.Tick) error {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
-		if err := f.consumeBinanceStream(ctx, url, out); err != nil {
+		// The symbol list may have been emptied by SetSymbols; wait for something to stream.
+		if len(f.snapshotSymbols()) == 0 {
+			select {
+			case <-f.symbolsChanged:
+				continue
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		delivered, err := f.consumeBinanceStream(ctx, out)
+		if err != nil {
 			if ctx.Err() != nil {
 				return ctx.Err()
 			}
-			f.log.Warn().Err(err).Msg("binance feed disconnected, retrying")
+			if delivered {
+				// The connection was healthy for a while; start the next retry cycle from scratch.
+				backoff = time.Second
+			}
+			f.log.Warn().Err(err).Dur("backoff", backoff).Msg("binance feed disconnected, retrying")
 			select {
 			case <-time.After(backoff):
 			case <-ctx.Done():
@@ -63,15 +99,33 @@ func (f *Feed) runBinance(ctx context.Context, out chan<- signal.Tick) error {
 	}
 }
 
-func (f *Feed) consumeBinanceStream(ctx context.Context, url string, out chan<- signal.Tick) error {
+// binanceStreams lists the combined-stream names for symbols: a trade stream plus an optional partial depth stream.
+func binanceStreams(symbols []string, depth int) []string {
+	streams := make([]string, 0, 2*len(symbols))
+	for _, sym := range symbols {
+		streams = append(streams, strings.ToLower(sym)+"@trade")
+		if depth > 0 {
+			streams = append(streams, fmt.Sprintf("%s@depth%d@100ms", strings.ToLower(sym), depth))
+		}
+	}
+	return streams
+}
+
+// consumeBinanceStream streams the tracked symbols over one connection until it fails, reporting whether
+// any tick was delivered.
+func (f *Feed) consumeBinanceStream(ctx context.Context, out chan<- signal.Tick) (bool, error) {
+	symbols := f.snapshotSymbols()
+	depth := depthStreamLevels(f.binanceDepthLevels)
+	streams := binanceStreams(symbols, depth)
+	url := fmt.Sprintf("%s/stream?streams=%s", f.binanceStreamURL, strings.Join(streams, "/"))
+
 	dialer := websocket.Dialer{HandshakeTimeout: 10 * time.Second}
 	conn, _, err := dialer.DialContext(ctx, url, nil)
 	if err != nil {
-		return err
+		return false, err
 	}
 	defer conn.Close()
 
-	symbols := f.snapshotSymbols()
 	f.log.Info().Str("provider", ProviderBinance).Strs("symbols", symbols).Msg("connected market data feed")
 
 	conn.SetReadLimit(1 << 20)
@@ -81,34 +135,32 @@ func (f *Feed) consumeBinanceStream(ctx context.Context, url string, out chan<-
 		return nil
 	})
 
-	pingCtx, pingCancel := context.WithCancel(ctx)
-	defer pingCancel()
+	writerCtx, writerCancel := context.WithCancel(ctx)
+	replies := make(chan binanceEnvelope, 8)
+	writerDone := make(chan struct{})
 	go func() {
-		ticker := time.NewTicker(15 * time.Second)
-		defer ticker.Stop()
-		for {
-			select {
-			case <-ticker.C:
-				conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
-				if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
-					f.log.Warn().Err(err).Msg("binance ping failed")
-					return
-				}
-			case <-pingCtx.Done():
-				return
-			}
-		}
+		defer close(writerDone)
+		f.writeBinanceControl(writerCtx, conn, streams, depth, replies)
+	}()
+	defer func() {
+		// Wait for the writer so it cannot take a symbolsChanged notification meant for the next connection.
+		writerCancel()
+		<-writerDone
 	}()
 
+	// connected tracks symbols that have traded on this connection, so the first trade
+	// after a (re)connect can be told apart from a gap inside a live session.
+	connected := make(map[string]bool, len(symbols))
+	delivered := false
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return delivered, ctx.Err()
 		default:
 		}
 		_, message, err := conn.ReadMessage()
 		if err != nil {
-			return err
+			return delivered, err
 		}
 
 		var env binanceEnvelope
@@ -116,37 +168,267 @@ func (f *Feed) consumeBinanceStream(ctx context.Context, url string, out chan<-
 			f.log.Warn().Err(err).Msg("failed to decode binance message")
 			continue
 		}
+		if env.Stream == "" {
+			// Replies to control requests carry no market data; the writer matches them by id.
+			select {
+			case replies <- env:
+			case <-writerDone:
+			}
+			continue
+		}
 
 		symbol := parseBinanceSymbol(env.Stream)
-		px, err := strconv.ParseFloat(env.Data.Price, 64)
-		if err != nil {
-			f.log.Warn().Err(err).Msg("invalid price from binance")
-			continue
+		var (
+			tick signal.Tick
+			ok   bool
+		)
+		if strings.Contains(env.Stream, "@depth") {
+			tick, ok = f.decodeBinanceDepth(symbol, env.Data)
+		} else {
+			var tradeID int64
+			tick, tradeID, ok = f.decodeBinanceTrade(symbol, env.Data)
+			if ok && tradeID > 0 {
+				ok = f.trackTradeID(symbol, tradeID, !connected[symbol])
+				connected[symbol] = true
+			}
 		}
-		qty, err := strconv.ParseFloat(env.Data.Quantity, 64)
-		if err != nil {
-			f.log.Warn().Err(err).Msg("invalid quantity from binance")
+		if !ok {
 			continue
 		}
-		side := 1
-		if env.Data.IsBuyerMaker {
-			side = -1
+
+		if err := f.emit(ctx, out, tick); err != nil {
+			return delivered, err
 		}
-		tick := signal.Tick{
-			Symbol: symbol,
-			Price:  px,
-			Size:   qty,
-			Side:   side,
-			Ts:     time.UnixMilli(env.Data.TradeTime),
+		delivered = true
+	}
+}
+
+// binanceRetryDelay is how long a rejected SUBSCRIBE/UNSUBSCRIBE waits before the stream diff is re-sent.
+var binanceRetryDelay = 5 * time.Second
+
+// writeBinanceControl owns every write on conn: keepalive pings and SUBSCRIBE/UNSUBSCRIBE requests
+// issued when SetSymbols changes the symbol list. A stream only counts as (un)subscribed once Binance
+// acknowledges the request by id; rejected requests are retried after binanceRetryDelay. A failed
+// write closes conn so the reader reconnects.
+func (f *Feed) writeBinanceControl(ctx context.Context, conn *websocket.Conn, streams []string, depth int, replies <-chan binanceEnvelope) {
+	subscribed := make(map[string]struct{}, len(streams))
+	for _, stream := range streams {
+		subscribed[stream] = struct{}{}
+	}
+	pending := make(map[int64]binanceRequest)
+	var requestID int64
+	send := func(method string, params []string) bool {
+		if len(params) == 0 {
+			return true
+		}
+		requestID++
+		req := binanceRequest{Method: method, Params: params, ID: requestID}
+		conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+		if err := conn.WriteJSON(req); err != nil {
+			f.log.Warn().Err(err).Str("method", method).Msg("binance control request failed")
+			return false
+		}
+		pending[req.ID] = req
+		f.log.Info().Str("method", method).Strs("streams", params).Int64("id", requestID).Msg("binance stream request sent")
+		return true
+	}
+	// sync requests the difference between the wanted streams and those subscribed or already requested.
+	sync := func() bool {
+		expected := make(map[string]struct{}, len(subscribed))
+		for stream := range subscribed {
+			expected[stream] = struct{}{}
+		}
+		for _, req := range pending {
+			for _, stream := range req.Params {
+				if req.Method == "SUBSCRIBE" {
+					expected[stream] = struct{}{}
+				} else {
+					delete(expected, stream)
+				}
+			}
+		}
+		wanted := make(map[string]struct{})
+		for _, stream := range binanceStreams(f.snapshotSymbols(), depth) {
+			wanted[stream] = struct{}{}
+		}
+		var add, drop []string
+		for stream := range wanted {
+			if _, ok := expected[stream]; !ok {
+				add = append(add, stream)
+			}
 		}
+		for stream := range expected {
+			if _, ok := wanted[stream]; !ok {
+				drop = append(drop, stream)
+			}
+		}
+		sort.Strings(add)
+		sort.Strings(drop)
+		return send("UNSUBSCRIBE", drop) && send("SUBSCRIBE", add)
+	}
 
+	var retry <-chan time.Time
+	ticker := time.NewTicker(15 * time.Second)
+	defer ticker.Stop()
+	for {
 		select {
-		case out <- tick:
-			metrics.TicksTotal.WithLabelValues(symbol).Inc()
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				f.log.Warn().Err(err).Msg("binance ping failed")
+				return
+			}
+		case <-f.symbolsChanged:
+			if !sync() {
+				conn.Close()
+				return
+			}
+		case <-retry:
+			retry = nil
+			if !sync() {
+				conn.Close()
+				return
+			}
+		case env := <-replies:
+			if env.ID == nil {
+				continue
+			}
+			req, ok := pending[*env.ID]
+			if !ok {
+				f.log.Debug().Int64("id", *env.ID).Msg("binance reply for unknown request")
+				continue
+			}
+			delete(pending, req.ID)
+			if env.Error != nil {
+				f.log.Warn().Int64("id", req.ID).Str("method", req.Method).Strs("streams", req.Params).
+					Int("code", env.Error.Code).Str("msg", env.Error.Msg).Dur("retry_in", binanceRetryDelay).
+					Msg("binance rejected stream request")
+				if retry == nil {
+					retry = time.After(binanceRetryDelay)
+				}
+				continue
+			}
+			for _, stream := range req.Params {
+				if req.Method == "SUBSCRIBE" {
+					subscribed[stream] = struct{}{}
+				} else {
+					delete(subscribed, stream)
+				}
+			}
+			if req.Method == "UNSUBSCRIBE" {
+				f.forgetTradeIDs(req.Params)
+			}
+			f.log.Info().Int64("id", req.ID).Str("method", req.Method).Strs("streams", req.Params).Msg("binance streams updated")
 		case <-ctx.Done():
-			return ctx.Err()
+			return
+		}
+	}
+}
+
+// trackTradeID records the latest trade ID for symbol, counting skipped IDs as missed trades.
+// It returns false for duplicate or out-of-order trades, which should not be emitted again.
+func (f *Feed) trackTradeID(symbol string, id int64, afterConnect bool) bool {
+	f.mu.Lock()
+	last, seen := f.binanceTradeIDs[symbol]
+	if seen && id <= last {
+		f.mu.Unlock()
+		return false
+	}
+	f.binanceTradeIDs[symbol] = id
+	f.mu.Unlock()
+
+	if missed := id - last - 1; seen && missed > 0 {
+		metrics.FeedMissedTrades.WithLabelValues(f.provider, symbol).Add(float64(missed))
+		f.log.Warn().Str("symbol", symbol).Int64("missed", missed).Int64("last_trade_id", last).Int64("trade_id", id).
+			Bool("after_reconnect", afterConnect).Msg("binance trade gap detected")
+	}
+	return true
+}
+
+// forgetTradeIDs drops gap tracking for unsubscribed streams so a later resubscription is not reported as a gap.
+func (f *Feed) forgetTradeIDs(streams []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, stream := range streams {
+		if strings.HasSuffix(stream, "@trade") {
+			delete(f.binanceTradeIDs, parseBinanceSymbol(stream))
+		}
+	}
+}
+
+// decodeBinanceTrade parses a trade payload into a tick and returns the exchange trade ID alongside it.
+func (f *Feed) decodeBinanceTrade(symbol string, data json.RawMessage) (signal.Tick, int64, bool) {
+	var trade binanceTrade
+	if err := json.Unmarshal(data, &trade); err != nil {
+		f.log.Warn().Err(err).Msg("failed to decode binance trade")
+		return signal.Tick{}, 0, false
+	}
+	px, err := strconv.ParseFloat(trade.Price, 64)
+	if err != nil {
+		f.log.Warn().Err(err).Msg("invalid price from binance")
+		return signal.Tick{}, 0, false
+	}
+	qty, err := strconv.ParseFloat(trade.Quantity, 64)
+	if err != nil {
+		f.log.Warn().Err(err).Msg("invalid quantity from binance")
+		return signal.Tick{}, 0, false
+	}
+	side := 1
+	if trade.IsBuyerMaker {
+		side = -1
+	}
+	return signal.Tick{
+		Symbol: symbol,
+		Price:  px,
+		Size:   qty,
+		Side:   side,
+		Ts:     time.UnixMilli(trade.TradeTime),
+	}, trade.TradeID, true
+}
+
+// decodeBinanceDepth turns a partial depth snapshot into a book tick priced at the mid.
+func (f *Feed) decodeBinanceDepth(symbol string, data json.RawMessage) (signal.Tick, bool) {
+	var depth binanceDepth
+	if err := json.Unmarshal(data, &depth); err != nil {
+		f.log.Warn().Err(err).Msg("failed to decode binance depth")
+		return signal.Tick{}, false
+	}
+	book := &signal.Book{Bids: parseBinanceLevels(depth.Bids), Asks: parseBinanceLevels(depth.Asks)}
+	mid := book.Mid()
+	if mid <= 0 {
+		return signal.Tick{}, false
+	}
+	// Partial depth payloads carry no event time, so stamp on receipt.
+	return signal.Tick{Symbol: symbol, Price: mid, Ts: time.Now(), Book: book}, true
+}
+
+func parseBinanceLevels(raw [][2]string) []signal.BookLevel {
+	levels := make([]signal.BookLevel, 0, len(raw))
+	for _, lvl := range raw {
+		px, err := strconv.ParseFloat(lvl[0], 64)
+		if err != nil {
+			continue
+		}
+		qty, err := strconv.ParseFloat(lvl[1], 64)
+		if err != nil {
+			continue
+		}
+		levels = append(levels, signal.BookLevel{Price: px, Qty: qty})
+	}
+	return levels
+}
+
+// depthStreamLevels rounds a requested book depth up to the nearest size Binance publishes; 0 disables depth.
+func depthStreamLevels(levels int) int {
+	if levels <= 0 {
+		return 0
+	}
+	for _, n := range binanceDepthLevels {
+		if levels <= n {
+			return n
 		}
 	}
+	return binanceDepthLevels[len(binanceDepthLevels)-1]
 }
 
 func parseBinanceSymbol(stream string) string {
@@ -0,0 +1,250 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+
+	"memebot-go/internal/metrics"
+	"memebot-go/internal/signal"
+)
+
+// newBinanceStandIn serves the combined stream endpoint and writes the given frames once a client connects;
+// text messages sent by the client are forwarded to received when it is non-nil and answered with
+// respond's reply when respond is non-nil.
+func newBinanceStandIn(t *testing.T, frames []string, streams, received chan<- string, respond func(string) string) *httptest.Server {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/stream" {
+			http.NotFound(w, r)
+			return
+		}
+		if streams != nil {
+			streams <- r.URL.Query().Get("streams")
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for _, frame := range frames {
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(frame)); err != nil {
+				return
+			}
+		}
+		// Hold the connection open until the client goes away.
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if received != nil {
+				received <- string(msg)
+			}
+			if respond != nil {
+				if err := conn.WriteMessage(websocket.TextMessage, []byte(respond(string(msg)))); err != nil {
+					return
+				}
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRunBinanceEmitsTradesAndBooks(t *testing.T) {
+	frames := []string{
+		`{"stream":"wifusdt@trade","data":{"p":"2.50","q":"4","T":1700000000000,"m":true}}`,
+		`{"stream":"wifusdt@depth10@100ms","data":{"lastUpdateId":1,"bids":[["2.49","10"],["2.48","5"]],"asks":[["2.51","3"],["2.52","2"]]}}`,
+	}
+	streams := make(chan string, 1)
+	server := newBinanceStandIn(t, frames, streams, nil, nil)
+
+	feed := NewFeed(ProviderBinance, []string{"WIFUSDT"}, zerolog.Nop(),
+		WithBinanceConfig("ws"+strings.TrimPrefix(server.URL, "http"), 7))
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	out := make(chan signal.Tick, 4)
+	go func() { _ = feed.Run(ctx, out) }()
+
+	if got := <-streams; got != "wifusdt@trade/wifusdt@depth10@100ms" {
+		t.Fatalf("unexpected stream subscription %q", got)
+	}
+
+	trade := receiveTick(t, ctx, out)
+	if trade.Symbol != "WIFUSDT" || trade.Price != 2.5 || trade.Size != 4 || trade.Side != -1 || trade.Book != nil {
+		t.Fatalf("unexpected trade tick %+v", trade)
+	}
+	book := receiveTick(t, ctx, out)
+	if book.Book == nil || book.Size != 0 {
+		t.Fatalf("expected book tick, got %+v", book)
+	}
+	if book.Price != 2.5 {
+		t.Fatalf("expected mid price 2.5, got %.4f", book.Price)
+	}
+	if len(book.Book.Bids) != 2 || book.Book.Asks[1].Qty != 2 {
+		t.Fatalf("unexpected book levels %+v", book.Book)
+	}
+	if got := book.Book.Imbalance(1); got != (10.0-3.0)/13.0 {
+		t.Fatalf("unexpected top-level imbalance %.4f", got)
+	}
+}
+
+func TestBinanceResubscribesOnSymbolChange(t *testing.T) {
+	frames := []string{
+		`{"stream":"wifusdt@trade","data":{"t":10,"p":"2.50","q":"1","T":1700000000000,"m":false}}`,
+	}
+	received := make(chan string, 4)
+	server := newBinanceStandIn(t, frames, nil, received, nil)
+
+	feed := NewFeed(ProviderBinance, []string{"WIFUSDT"}, zerolog.Nop(),
+		WithBinanceConfig("ws"+strings.TrimPrefix(server.URL, "http"), 0))
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	out := make(chan signal.Tick, 4)
+	go func() { _ = feed.Run(ctx, out) }()
+
+	// Wait for the connection before changing symbols so the change goes out as control messages.
+	receiveTick(t, ctx, out)
+	feed.SetSymbols([]string{"BONKUSDT"})
+
+	want := []string{
+		`{"method":"UNSUBSCRIBE","params":["wifusdt@trade"],"id":1}`,
+		`{"method":"SUBSCRIBE","params":["bonkusdt@trade"],"id":2}`,
+	}
+	for _, w := range want {
+		select {
+		case got := <-received:
+			if strings.TrimSpace(got) != w {
+				t.Fatalf("unexpected control message %s, want %s", got, w)
+			}
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for %s", w)
+		}
+	}
+}
+
+func TestBinanceRetriesRejectedSubscription(t *testing.T) {
+	defer func(delay time.Duration) { binanceRetryDelay = delay }(binanceRetryDelay)
+	binanceRetryDelay = 50 * time.Millisecond
+
+	frames := []string{
+		`{"stream":"wifusdt@trade","data":{"t":10,"p":"2.50","q":"1","T":1700000000000,"m":false}}`,
+	}
+	received := make(chan string, 4)
+	// Reject the first request and acknowledge everything after it.
+	var requests int
+	respond := func(msg string) string {
+		requests++
+		var req binanceRequest
+		if err := json.Unmarshal([]byte(msg), &req); err != nil {
+			t.Errorf("unexpected control message %s", msg)
+		}
+		if requests == 1 {
+			return fmt.Sprintf(`{"error":{"code":2,"msg":"Invalid request"},"id":%d}`, req.ID)
+		}
+		return fmt.Sprintf(`{"result":null,"id":%d}`, req.ID)
+	}
+	server := newBinanceStandIn(t, frames, nil, received, respond)
+
+	feed := NewFeed(ProviderBinance, []string{"WIFUSDT"}, zerolog.Nop(),
+		WithBinanceConfig("ws"+strings.TrimPrefix(server.URL, "http"), 0))
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	out := make(chan signal.Tick, 4)
+	go func() { _ = feed.Run(ctx, out) }()
+
+	receiveTick(t, ctx, out)
+	feed.SetSymbols([]string{"WIFUSDT", "BONKUSDT"})
+
+	want := []string{
+		`{"method":"SUBSCRIBE","params":["bonkusdt@trade"],"id":1}`,
+		`{"method":"SUBSCRIBE","params":["bonkusdt@trade"],"id":2}`,
+	}
+	for _, w := range want {
+		select {
+		case got := <-received:
+			if strings.TrimSpace(got) != w {
+				t.Fatalf("unexpected control message %s, want %s", got, w)
+			}
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for %s", w)
+		}
+	}
+	// Once acknowledged, a later change with the same streams sends nothing new.
+	feed.SetSymbols([]string{"WIFUSDT", "BONKUSDT"})
+	select {
+	case got := <-received:
+		t.Fatalf("unexpected control message after ack %s", got)
+	case <-time.After(3 * binanceRetryDelay):
+	}
+}
+
+func TestBinanceSkipsRepliesAndCountsTradeGaps(t *testing.T) {
+	frames := []string{
+		`{"result":null,"id":1}`,
+		`{"stream":"wifusdt@trade","data":{"t":100,"p":"2.50","q":"1","T":1700000000000,"m":false}}`,
+		`{"stream":"wifusdt@trade","data":{"t":100,"p":"2.50","q":"1","T":1700000000000,"m":false}}`,
+		`{"stream":"wifusdt@trade","data":{"t":104,"p":"2.60","q":"1","T":1700000000100,"m":false}}`,
+	}
+	server := newBinanceStandIn(t, frames, nil, nil, nil)
+
+	feed := NewFeed(ProviderBinance, []string{"WIFUSDT"}, zerolog.Nop(),
+		WithBinanceConfig("ws"+strings.TrimPrefix(server.URL, "http"), 0))
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	out := make(chan signal.Tick, 4)
+	go func() { _ = feed.Run(ctx, out) }()
+
+	before := testutil.ToFloat64(metrics.FeedMissedTrades.WithLabelValues(ProviderBinance, "WIFUSDT"))
+	if first := receiveTick(t, ctx, out); first.Price != 2.5 {
+		t.Fatalf("expected the ack to be skipped, got %+v", first)
+	}
+	if second := receiveTick(t, ctx, out); second.Price != 2.6 {
+		t.Fatalf("expected the duplicate trade to be dropped, got %+v", second)
+	}
+	if missed := testutil.ToFloat64(metrics.FeedMissedTrades.WithLabelValues(ProviderBinance, "WIFUSDT")) - before; missed != 3 {
+		t.Fatalf("expected 3 missed trades, got %.0f", missed)
+	}
+}
+
+func TestTrackTradeIDAcrossResubscribe(t *testing.T) {
+	feed := NewFeed(ProviderBinance, []string{"WIFUSDT"}, zerolog.Nop())
+	if !feed.trackTradeID("WIFUSDT", 5, true) || feed.trackTradeID("WIFUSDT", 4, false) {
+		t.Fatalf("expected first trade accepted and older trade dropped")
+	}
+	feed.forgetTradeIDs([]string{"wifusdt@trade"})
+	if !feed.trackTradeID("WIFUSDT", 2, true) {
+		t.Fatalf("expected a forgotten symbol to restart gap tracking")
+	}
+}
+
+func TestDepthStreamLevels(t *testing.T) {
+	cases := map[int]int{0: 0, 1: 5, 5: 5, 6: 10, 20: 20, 50: 20}
+	for in, want := range cases {
+		if got := depthStreamLevels(in); got != want {
+			t.Fatalf("depthStreamLevels(%d) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func receiveTick(t *testing.T, ctx context.Context, out <-chan signal.Tick) signal.Tick {
+	t.Helper()
+	select {
+	case tk := <-out:
+		return tk
+	case <-ctx.Done():
+		t.Fatalf("timed out waiting for tick")
+	}
+	return signal.Tick{}
+}
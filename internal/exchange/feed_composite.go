@@ -0,0 +1,142 @@
+package exchange
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"memebot-go/internal/signal"
+)
+
+// knownProviders lists the provider names accepted as symbol routing prefixes.
+var knownProviders = map[string]struct{}{
+	ProviderStub:        {},
+	ProviderBinance:     {},
+	ProviderDexScreener: {},
+	ProviderReplay:      {},
+	ProviderSolana:      {},
+}
+
+// SplitProviderSymbol separates a "provider:symbol" entry; symbols without a known provider prefix return an empty provider.
+func SplitProviderSymbol(entry string) (string, string) {
+	entry = strings.TrimSpace(entry)
+	prefix, rest, ok := strings.Cut(entry, ":")
+	if !ok {
+		return "", entry
+	}
+	provider := strings.ToLower(strings.TrimSpace(prefix))
+	if _, known := knownProviders[provider]; !known {
+		return "", entry
+	}
+	return provider, strings.TrimSpace(rest)
+}
+
+// RouteSymbols groups configured symbols by provider, sending unprefixed entries to defaultProvider.
+func RouteSymbols(defaultProvider string, symbols []string) map[string][]string {
+	defaultProvider = strings.ToLower(strings.TrimSpace(defaultProvider))
+	if defaultProvider == "" {
+		defaultProvider = ProviderStub
+	}
+	routes := make(map[string][]string)
+	for _, entry := range symbols {
+		provider, sym := SplitProviderSymbol(entry)
+		if sym == "" {
+			continue
+		}
+		if provider == "" {
+			provider = defaultProvider
+		}
+		routes[provider] = append(routes[provider], sym)
+	}
+	if len(routes) == 0 {
+		routes[defaultProvider] = nil
+	}
+	return routes
+}
+
+// Composite runs one child Feed per provider concurrently and merges their ticks onto a single channel.
+type Composite struct {
+	log             zerolog.Logger
+	defaultProvider string
+	feeds           map[string]*Feed // fixed at construction
+}
+
+// NewComposite routes symbols to per-provider child feeds; every child receives the same options.
+func NewComposite(defaultProvider string, symbols []string, log zerolog.Logger, opts ...Option) *Composite {
+	routes := RouteSymbols(defaultProvider, symbols)
+	c := &Composite{
+		log:             log,
+		defaultProvider: strings.ToLower(strings.TrimSpace(defaultProvider)),
+		feeds:           make(map[string]*Feed, len(routes)),
+	}
+	if c.defaultProvider == "" {
+		c.defaultProvider = ProviderStub
+	}
+	for provider, syms := range routes {
+		c.feeds[provider] = NewFeed(provider, syms, log, opts...)
+	}
+	return c
+}
+
+// Feed returns the child feed for provider, or nil when no symbols route to it.
+func (c *Composite) Feed(provider string) *Feed {
+	return c.feeds[strings.ToLower(provider)]
+}
+
+// Providers lists the providers with a running child feed.
+func (c *Composite) Providers() []string {
+	out := make([]string, 0, len(c.feeds))
+	for provider := range c.feeds {
+		out = append(out, provider)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// SetSymbols re-routes the symbol list across existing children; providers without a child are skipped.
+func (c *Composite) SetSymbols(symbols []string) {
+	routes := RouteSymbols(c.defaultProvider, symbols)
+	for provider, feed := range c.feeds {
+		feed.SetSymbols(routes[provider])
+	}
+	for provider, syms := range routes {
+		if _, ok := c.feeds[provider]; !ok && len(syms) > 0 {
+			c.log.Warn().Str("provider", provider).Strs("symbols", syms).Msg("no feed running for provider, symbols ignored")
+		}
+	}
+}
+
+// Run starts every child and returns once all finish; the first child failure cancels the rest.
+func (c *Composite) Run(ctx context.Context, out chan<- signal.Tick) error {
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for provider, feed := range c.feeds {
+		wg.Add(1)
+		go func(provider string, feed *Feed) {
+			defer wg.Done()
+			err := feed.Run(runCtx, out)
+			if err == nil || runCtx.Err() != nil {
+				return
+			}
+			c.log.Error().Err(err).Str("provider", provider).Msg("feed stopped")
+			errOnce.Do(func() {
+				firstErr = err
+				cancel()
+			})
+		}(provider, feed)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,107 @@
+package exchange
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"memebot-go/internal/signal"
+)
+
+func TestRouteSymbols(t *testing.T) {
+	routes := RouteSymbols("dexscreener", []string{
+		"binance:WIFUSDT",
+		"BINANCE: BTCUSDT ",
+		"WIFSOL@solana/32vFAmd12dTHMwo9g5QuCE9sgvdv72yUfK9PMP2dtBj7",
+		"odd:SYMBOL",
+		"binance:",
+	})
+	want := map[string][]string{
+		ProviderBinance:     {"WIFUSDT", "BTCUSDT"},
+		ProviderDexScreener: {"WIFSOL@solana/32vFAmd12dTHMwo9g5QuCE9sgvdv72yUfK9PMP2dtBj7", "odd:SYMBOL"},
+	}
+	if !reflect.DeepEqual(routes, want) {
+		t.Fatalf("unexpected routes %+v", routes)
+	}
+	if routes := RouteSymbols("", nil); len(routes[ProviderStub]) != 0 || len(routes) != 1 {
+		t.Fatalf("expected a single empty stub route, got %+v", routes)
+	}
+}
+
+func TestCompositeMergesProviders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ticks.jsonl")
+	recorder, err := NewJSONLTickRecorder(zerolog.Nop(), path)
+	if err != nil {
+		t.Fatalf("NewJSONLTickRecorder error: %v", err)
+	}
+	recorder.Record(signal.Tick{Symbol: "WIFSOL", Price: 2, Size: 1, Side: 1, Ts: time.Now()})
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close error: %v", err)
+	}
+
+	feed := NewComposite(ProviderReplay, []string{"WIFSOL", "stub:BTCUSDT"}, zerolog.Nop(), WithReplayConfig(path, 0))
+	if got := feed.Providers(); !reflect.DeepEqual(got, []string{ProviderReplay, ProviderStub}) {
+		t.Fatalf("unexpected providers %+v", got)
+	}
+	if feed.Feed(ProviderBinance) != nil {
+		t.Fatalf("expected no binance child")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	out := make(chan signal.Tick, 8)
+	done := make(chan error, 1)
+	go func() { done <- feed.Run(ctx, out) }()
+
+	seen := map[string]string{}
+	for len(seen) < 2 {
+		select {
+		case tk := <-out:
+			seen[tk.Provider] = tk.Symbol
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for both providers, saw %+v", seen)
+		}
+	}
+	if seen[ProviderReplay] != "WIFSOL" || seen[ProviderStub] != "BTCUSDT" {
+		t.Fatalf("unexpected provider tagging %+v", seen)
+	}
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("expected context cancellation, got %v", err)
+	}
+}
+
+func TestCompositeStopsOnChildFailure(t *testing.T) {
+	// A binance child without symbols fails immediately and should take the stub child down with it.
+	feed := NewComposite(ProviderStub, []string{"BTCUSDT"}, zerolog.Nop())
+	feed.feeds[ProviderBinance] = NewFeed(ProviderBinance, nil, zerolog.Nop())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	err := feed.Run(ctx, make(chan signal.Tick, 16))
+	if err == nil || ctx.Err() != nil {
+		t.Fatalf("expected child error before timeout, got %v", err)
+	}
+}
+
+func TestCompositeTracksTickAges(t *testing.T) {
+	feed := NewComposite(ProviderStub, []string{"BTCUSDT", "binance:WIFUSDT"}, zerolog.Nop())
+	base := time.Unix(1700000000, 0)
+	feed.Feed(ProviderStub).touch(ProviderStub, "BTCUSDT", base)
+	feed.Feed(ProviderBinance).touch(ProviderBinance, "WIFUSDT", base.Add(5*time.Second))
+
+	if _, ok := feed.LastTick("MISSING"); ok {
+		t.Fatalf("expected no last tick for an unseen symbol")
+	}
+	if at, ok := feed.LastTick("WIFUSDT"); !ok || !at.Equal(base.Add(5*time.Second)) {
+		t.Fatalf("unexpected WIFUSDT last tick %v", at)
+	}
+	ages := feed.TickAges(base.Add(10 * time.Second))
+	if len(ages) != 2 || ages[0].Symbol != "BTCUSDT" || ages[0].Age != 10*time.Second || ages[1].Provider != ProviderBinance || ages[1].Age != 5*time.Second {
+		t.Fatalf("unexpected tick ages %+v", ages)
+	}
+}
@@ -5,12 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"math"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	"memebot-go/internal/instrument"
 	"memebot-go/internal/metrics"
 	"memebot-go/internal/signal"
 )
@@ -27,16 +27,20 @@ type dexscreenerPairsResponse struct {
 }
 
 type dexscreenerPair struct {
-	ChainID     string                 `json:"chainId"`
-	PairAddress string                 `json:"pairAddress"`
-	BaseToken   dexscreenerToken       `json:"baseToken"`
-	QuoteToken  dexscreenerToken       `json:"quoteToken"`
-	PriceUsd    string                 `json:"priceUsd"`
-	PriceNative string                 `json:"priceNative"`
-	Txns        dexscreenerTxns        `json:"txns"`
-	Volume      dexscreenerVolumes     `json:"volume"`
-	Liquidity   dexscreenerLiquidity   `json:"liquidity"`
-	PriceChange dexscreenerPriceChange `json:"priceChange"`
+	ChainID       string                 `json:"chainId"`
+	DexID         string                 `json:"dexId"`
+	PairAddress   string                 `json:"pairAddress"`
+	BaseToken     dexscreenerToken       `json:"baseToken"`
+	QuoteToken    dexscreenerToken       `json:"quoteToken"`
+	PriceUsd      string                 `json:"priceUsd"`
+	PriceNative   string                 `json:"priceNative"`
+	Txns          dexscreenerTxns        `json:"txns"`
+	Volume        dexscreenerVolumes     `json:"volume"`
+	Liquidity     dexscreenerLiquidity   `json:"liquidity"`
+	PriceChange   dexscreenerPriceChange `json:"priceChange"`
+	FDV           float64                `json:"fdv"`
+	MarketCap     float64                `json:"marketCap"`
+	PairCreatedAt int64                  `json:"pairCreatedAt"` // unix ms
 }
 
 type dexscreenerToken struct {
@@ -77,16 +81,6 @@ type dexscreenerPriceChange struct {
 	H24 float64 `json:"h24"`
 }
 
-func (r *dexscreenerPairsResponse) firstPair() (*dexscreenerPair, bool) {
-	if len(r.Pairs) > 0 {
-		return &r.Pairs[0], true
-	}
-	if r.Pair != nil {
-		return r.Pair, true
-	}
-	return nil, false
-}
-
 func (f *Feed) runDexScreener(ctx context.Context, out chan<- signal.Tick) error {
 	client := &http.Client{Timeout: 10 * time.Second}
 	if err := f.pollDexScreener(ctx, client, out); err != nil && !errors.Is(err, context.Canceled) {
@@ -109,7 +103,7 @@ func (f *Feed) runDexScreener(ctx context.Context, out chan<- signal.Tick) error
 }
 
 func (f *Feed) pollDexScreener(ctx context.Context, client *http.Client, out chan<- signal.Tick) error {
-	targets, err := parseDexScreenerSymbols(f.snapshotSymbols(), f.dexscreenerDefaultChain)
+	targets, err := parseDexScreenerSymbols(f.instruments, f.snapshotSymbols(), f.dexscreenerDefaultChain)
 	if err != nil {
 		return err
 	}
@@ -119,29 +113,119 @@ func (f *Feed) pollDexScreener(ctx context.Context, client *http.Client, out cha
 	return f.dispatchDexScreener(ctx, client, targets, out)
 }
 
+// dexscreenerBatch is one multi-address request against a single chain.
+type dexscreenerBatch struct {
+	chain   string
+	targets []dexscreenerTarget
+}
+
+type dexscreenerBatchResult struct {
+	batch dexscreenerBatch
+	pairs []*dexscreenerPair // aligned with batch.targets; nil when the pair was not returned
+	err   error
+}
+
 func (f *Feed) dispatchDexScreener(ctx context.Context, client *http.Client, targets []dexscreenerTarget, out chan<- signal.Tick) error {
-	for _, target := range targets {
-		tick, err := f.fetchDexScreener(ctx, client, target)
-		if err != nil {
+	started := time.Now()
+	f.pruneDexScreenerSeen(targets)
+	batches := batchDexScreenerTargets(targets, f.dexscreenerBatchSize)
+	results := make(chan dexscreenerBatchResult, len(batches))
+	sem := make(chan struct{}, f.dexscreenerConcurrency)
+	for _, batch := range batches {
+		go func(batch dexscreenerBatch) {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				results <- dexscreenerBatchResult{batch: batch, err: ctx.Err()}
+				return
+			}
+			defer func() { <-sem }()
+			pairs, err := f.fetchDexScreenerBatch(ctx, client, batch)
+			results <- dexscreenerBatchResult{batch: batch, pairs: pairs, err: err}
+		}(batch)
+	}
+
+	// Ticks are built and emitted on this goroutine so dexscreenerSeen needs no locking.
+	emitted := 0
+	for range batches {
+		res := <-results
+		if res.err != nil {
 			if ctx.Err() != nil {
 				return ctx.Err()
 			}
-			f.log.Warn().Err(err).Str("symbol", target.Alias).Msg("dexscreener fetch failed")
+			f.log.Warn().Err(res.err).Str("chain", res.batch.chain).Int("pairs", len(res.batch.targets)).Msg("dexscreener batch fetch failed")
 			continue
 		}
-		select {
-		case out <- *tick:
-			metrics.TicksTotal.WithLabelValues(tick.Symbol).Inc()
-		case <-ctx.Done():
-			return ctx.Err()
+		for i, target := range res.batch.targets {
+			pair := res.pairs[i]
+			if pair == nil {
+				f.log.Warn().Str("symbol", target.Alias).Msg("dexscreener returned no data for pair")
+				continue
+			}
+			ticks, err := f.dexScreenerTicks(target, pair)
+			if err != nil {
+				f.log.Warn().Err(err).Str("symbol", target.Alias).Msg("dexscreener pair skipped")
+				continue
+			}
+			for _, tick := range ticks {
+				if err := f.emit(ctx, out, tick); err != nil {
+					return err
+				}
+				emitted++
+			}
 		}
 	}
+
+	elapsed := time.Since(started)
+	metrics.DexScreenerPollSeconds.Observe(elapsed.Seconds())
+	event := f.log.Debug()
+	if elapsed > f.pollInterval {
+		event = f.log.Warn()
+	}
+	event.Dur("elapsed", elapsed).Dur("interval", f.pollInterval).Int("pairs", len(targets)).Int("batches", len(batches)).Int("ticks", emitted).Msg("dexscreener poll cycle")
 	return nil
 }
 
-func (f *Feed) fetchDexScreener(ctx context.Context, client *http.Client, target dexscreenerTarget) (*signal.Tick, error) {
+// pruneDexScreenerSeen drops the change-detection state of pairs that are no longer polled, so a rotating
+// universe does not grow the map without bound and a re-added pair starts from a fresh baseline.
+func (f *Feed) pruneDexScreenerSeen(targets []dexscreenerTarget) {
+	polled := make(map[string]struct{}, len(targets))
+	for _, target := range targets {
+		polled[target.Alias] = struct{}{}
+	}
+	for alias := range f.dexscreenerSeen {
+		if _, ok := polled[alias]; !ok {
+			delete(f.dexscreenerSeen, alias)
+		}
+	}
+}
+
+// batchDexScreenerTargets groups targets per chain into requests of at most size addresses.
+func batchDexScreenerTargets(targets []dexscreenerTarget, size int) []dexscreenerBatch {
+	if size <= 0 {
+		size = dexscreenerMaxBatch
+	}
+	var batches []dexscreenerBatch
+	index := make(map[string]int)
+	for _, target := range targets {
+		i, ok := index[target.Chain]
+		if !ok || len(batches[i].targets) >= size {
+			batches = append(batches, dexscreenerBatch{chain: target.Chain})
+			i = len(batches) - 1
+			index[target.Chain] = i
+		}
+		batches[i].targets = append(batches[i].targets, target)
+	}
+	return batches
+}
+
+func (f *Feed) fetchDexScreenerBatch(ctx context.Context, client *http.Client, batch dexscreenerBatch) ([]*dexscreenerPair, error) {
+	addresses := make([]string, len(batch.targets))
+	for i, target := range batch.targets {
+		addresses[i] = target.Address
+	}
 	base := strings.TrimSuffix(f.dexscreenerBaseURL, "/")
-	url := fmt.Sprintf("%s/latest/dex/pairs/%s/%s", base, target.Chain, target.Address)
+	url := fmt.Sprintf("%s/latest/dex/pairs/%s/%s", base, batch.chain, strings.Join(addresses, ","))
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
@@ -160,28 +244,104 @@ func (f *Feed) fetchDexScreener(ctx context.Context, client *http.Client, target
 	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
 		return nil, fmt.Errorf("decode response: %w", err)
 	}
-	pair, ok := payload.firstPair()
-	if !ok {
-		return nil, fmt.Errorf("no pair data returned")
+	return matchDexScreenerPairs(batch.targets, &payload), nil
+}
+
+// matchDexScreenerPairs aligns returned pairs with requested targets by pair address; a lone target
+// accepts a lone pair even without an address so single-pair responses keep working.
+func matchDexScreenerPairs(targets []dexscreenerTarget, payload *dexscreenerPairsResponse) []*dexscreenerPair {
+	pairs := make([]*dexscreenerPair, 0, len(payload.Pairs)+1)
+	for i := range payload.Pairs {
+		pairs = append(pairs, &payload.Pairs[i])
+	}
+	if len(pairs) == 0 && payload.Pair != nil {
+		pairs = append(pairs, payload.Pair)
+	}
+
+	matched := make([]*dexscreenerPair, len(targets))
+	byAddress := make(map[string]*dexscreenerPair, len(pairs))
+	for _, pair := range pairs {
+		if pair.PairAddress != "" {
+			byAddress[strings.ToLower(pair.PairAddress)] = pair
+		}
+	}
+	for i, target := range targets {
+		matched[i] = byAddress[strings.ToLower(target.Address)]
 	}
+	if len(targets) == 1 && matched[0] == nil && len(pairs) == 1 {
+		matched[0] = pairs[0]
+	}
+	return matched
+}
+
+// dexscreenerObservation is the per-pair state kept between polls for change detection.
+type dexscreenerObservation struct {
+	price   float64
+	m5, h24 dexscreenerTxn
+	volM5   float64
+	volH24  float64
+}
+
+// dexScreenerTicks compares the pair against the previous poll and returns ticks only for what changed:
+// a price-only baseline on first sight, buy/sell flow ticks sized from txn and volume deltas, or a
+// price-only tick when the price moved without new trades. Unchanged pairs yield nothing.
+func (f *Feed) dexScreenerTicks(target dexscreenerTarget, pair *dexscreenerPair) ([]signal.Tick, error) {
 	price, err := parseDexScreenerPrice(pair)
 	if err != nil {
 		return nil, err
 	}
-	qty := estimateDexScreenerSize(pair, price)
-	if qty <= 0 {
-		qty = math.Max(1e-6, 10/price)
+	obs := dexscreenerObservation{price: price, m5: pair.Txns.M5, h24: pair.Txns.H24, volM5: pair.Volume.M5, volH24: pair.Volume.H24}
+	prev, seen := f.dexscreenerSeen[target.Alias]
+	f.dexscreenerSeen[target.Alias] = obs
+
+	base := signal.Tick{Symbol: target.Alias, Price: price, Ts: time.Now().UTC()}
+	if !seen {
+		return []signal.Tick{base}, nil
+	}
+
+	// The txn and volume windows roll, so each delta is new trades minus expired ones; the larger of
+	// the m5 and h24 deltas is the tighter lower bound on what traded since the last poll.
+	buys := max(0, obs.m5.Buys-prev.m5.Buys, obs.h24.Buys-prev.h24.Buys)
+	sells := max(0, obs.m5.Sells-prev.m5.Sells, obs.h24.Sells-prev.h24.Sells)
+	volume := max(0, obs.volM5-prev.volM5, obs.volH24-prev.volH24)
+	trades := buys + sells
+	if trades == 0 && volume == 0 {
+		if price != prev.price {
+			return []signal.Tick{base}, nil
+		}
+		return nil, nil
+	}
+	if trades == 0 {
+		// Volume moved before the txn counters did; attribute it to the direction the price moved.
+		if price < prev.price {
+			sells = 1
+		} else {
+			buys = 1
+		}
+		trades = 1
+	}
+	if volume == 0 {
+		volume = float64(trades) * estimateDexScreenerSize(pair, price) * price
+	}
+	if volume <= 0 {
+		return []signal.Tick{base}, nil
 	}
-	side := determineDexScreenerSide(pair, f.lastPrices[target.Alias], price)
-	f.lastPrices[target.Alias] = price
 
-	return &signal.Tick{
-		Symbol: target.Alias,
-		Price:  price,
-		Size:   qty,
-		Side:   side,
-		Ts:     time.Now().UTC(),
-	}, nil
+	buyTick, sellTick := base, base
+	buyTick.Side, buyTick.Trades, buyTick.Size = 1, buys, volume*float64(buys)/float64(trades)/price
+	sellTick.Side, sellTick.Trades, sellTick.Size = -1, sells, volume*float64(sells)/float64(trades)/price
+	// Emit the flow that agrees with the price move last so the final tick reflects the net direction.
+	ordered := []signal.Tick{sellTick, buyTick}
+	if price < prev.price {
+		ordered = []signal.Tick{buyTick, sellTick}
+	}
+	ticks := make([]signal.Tick, 0, 2)
+	for _, tk := range ordered {
+		if tk.Trades > 0 {
+			ticks = append(ticks, tk)
+		}
+	}
+	return ticks, nil
 }
 
 func parseDexScreenerPrice(pair *dexscreenerPair) (float64, error) {
@@ -201,24 +361,6 @@ func parseDexScreenerPrice(pair *dexscreenerPair) (float64, error) {
 	return 0, fmt.Errorf("pair missing price")
 }
 
-func determineDexScreenerSide(pair *dexscreenerPair, lastPrice, price float64) int {
-	if pair != nil {
-		buys := pair.Txns.M5.Buys
-		sells := pair.Txns.M5.Sells
-		total := buys + sells
-		if total > 0 {
-			if buys >= sells {
-				return 1
-			}
-			return -1
-		}
-	}
-	if lastPrice > 0 && price < lastPrice {
-		return -1
-	}
-	return 1
-}
-
 func estimateDexScreenerSize(pair *dexscreenerPair, price float64) float64 {
 	if pair == nil || price <= 0 {
 		return 0
@@ -254,9 +396,12 @@ func estimateDexScreenerSize(pair *dexscreenerPair, price float64) float64 {
 	return 0
 }
 
-func parseDexScreenerSymbols(symbols []string, defaultChain string) ([]dexscreenerTarget, error) {
+// parseDexScreenerSymbols resolves ALIAS@chain/pair symbols to poll targets named by their registered
+// instrument; the alias part only seeds the symbol of a pair the registry has not seen. Each pair is polled once.
+func parseDexScreenerSymbols(reg *instrument.Registry, symbols []string, defaultChain string) ([]dexscreenerTarget, error) {
 	defaultChain = strings.ToLower(strings.TrimSpace(defaultChain))
 	targets := make([]dexscreenerTarget, 0, len(symbols))
+	seen := make(map[string]struct{}, len(symbols))
 	for _, raw := range symbols {
 		raw = strings.TrimSpace(raw)
 		if raw == "" {
@@ -281,8 +426,12 @@ func parseDexScreenerSymbols(symbols []string, defaultChain string) ([]dexscreen
 		if chain == "" || address == "" {
 			return nil, fmt.Errorf("dexscreener symbol %q missing chain or address", raw)
 		}
-		alias := composeDexAlias(aliasPart, address)
-		targets = append(targets, dexscreenerTarget{Alias: alias, Chain: chain, Address: address})
+		inst := reg.RegisterPair(instrument.Instrument{Venue: ProviderDexScreener, Chain: chain, PairAddress: address}, aliasPart)
+		if _, dup := seen[inst.Key()]; dup {
+			continue
+		}
+		seen[inst.Key()] = struct{}{}
+		targets = append(targets, dexscreenerTarget{Alias: inst.Symbol, Chain: chain, Address: address})
 	}
 	return targets, nil
 }
@@ -0,0 +1,162 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"memebot-go/internal/signal"
+)
+
+func TestBatchDexScreenerTargets(t *testing.T) {
+	var targets []dexscreenerTarget
+	for i := 0; i < 65; i++ {
+		targets = append(targets, dexscreenerTarget{Chain: "solana", Address: fmt.Sprintf("SOL%d", i)})
+	}
+	targets = append(targets, dexscreenerTarget{Chain: "base", Address: "BASE0"})
+
+	batches := batchDexScreenerTargets(targets, 30)
+	sizes := make([]string, len(batches))
+	for i, batch := range batches {
+		sizes[i] = fmt.Sprintf("%s:%d", batch.chain, len(batch.targets))
+	}
+	if got := strings.Join(sizes, ","); got != "solana:30,solana:30,solana:5,base:1" {
+		t.Fatalf("unexpected batches %s", got)
+	}
+}
+
+func TestDispatchDexScreenerBatchesConcurrently(t *testing.T) {
+	var (
+		inFlight, maxInFlight int32
+		mu                    sync.Mutex
+		requests              []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cur := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			prev := atomic.LoadInt32(&maxInFlight)
+			if cur <= prev || atomic.CompareAndSwapInt32(&maxInFlight, prev, cur) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		path := strings.TrimPrefix(r.URL.Path, "/latest/dex/pairs/solana/")
+		mu.Lock()
+		requests = append(requests, path)
+		mu.Unlock()
+		var pairs []string
+		for _, addr := range strings.Split(path, ",") {
+			if addr == "P3" {
+				continue // simulate a pair Dexscreener no longer returns
+			}
+			pairs = append(pairs, fmt.Sprintf(`{"pairAddress":%q,"priceUsd":"1.5","liquidity":{"usd":1000}}`, strings.ToLower(addr)))
+		}
+		fmt.Fprintf(w, `{"pairs":[%s]}`, strings.Join(pairs, ","))
+	}))
+	defer server.Close()
+
+	var symbols []string
+	for i := 0; i < 7; i++ {
+		symbols = append(symbols, fmt.Sprintf("T%d@solana/P%d", i, i))
+	}
+	feed := NewFeed(ProviderDexScreener, symbols, zerolog.Nop(),
+		WithDexScreenerConfig(server.URL, "solana"),
+		WithDexScreenerBatching(2, 2),
+	)
+	out := make(chan signal.Tick, 16)
+	if err := feed.pollDexScreener(context.Background(), server.Client(), out); err != nil {
+		t.Fatalf("poll returned error: %v", err)
+	}
+	close(out)
+
+	got := map[string]bool{}
+	for tk := range out {
+		got[tk.Symbol] = true
+		if tk.Price != 1.5 || tk.Provider != ProviderDexScreener {
+			t.Fatalf("unexpected tick %+v", tk)
+		}
+	}
+	if len(got) != 6 || got["T3_P3"] {
+		t.Fatalf("expected ticks for every returned pair except P3, got %+v", got)
+	}
+	if len(requests) != 4 {
+		t.Fatalf("expected 4 batched requests, got %d: %v", len(requests), requests)
+	}
+	if max := atomic.LoadInt32(&maxInFlight); max > 2 {
+		t.Fatalf("expected at most 2 concurrent requests, saw %d", max)
+	}
+}
+
+func TestDispatchDexScreenerPrunesDroppedPairs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var pairs []string
+		for _, addr := range strings.Split(strings.TrimPrefix(r.URL.Path, "/latest/dex/pairs/solana/"), ",") {
+			pairs = append(pairs, fmt.Sprintf(`{"pairAddress":%q,"priceUsd":"1.5"}`, addr))
+		}
+		fmt.Fprintf(w, `{"pairs":[%s]}`, strings.Join(pairs, ","))
+	}))
+	defer server.Close()
+
+	feed := NewFeed(ProviderDexScreener, []string{"A@solana/PA", "B@solana/PB"}, zerolog.Nop(),
+		WithDexScreenerConfig(server.URL, "solana"))
+	out := make(chan signal.Tick, 4)
+	if err := feed.pollDexScreener(context.Background(), server.Client(), out); err != nil {
+		t.Fatalf("poll returned error: %v", err)
+	}
+	feed.SetSymbols([]string{"A@solana/PA"})
+	if err := feed.pollDexScreener(context.Background(), server.Client(), out); err != nil {
+		t.Fatalf("poll returned error: %v", err)
+	}
+	if _, ok := feed.dexscreenerSeen["B_PB"]; ok || len(feed.dexscreenerSeen) != 1 {
+		t.Fatalf("expected only the polled pair to keep state, got %+v", feed.dexscreenerSeen)
+	}
+}
+
+func TestDexScreenerTicksOnlyOnChange(t *testing.T) {
+	feed := NewFeed(ProviderDexScreener, nil, zerolog.Nop())
+	target := dexscreenerTarget{Alias: "WIF_PAIR", Chain: "solana", Address: "PAIR"}
+	pair := func(price string, buys, sells int, volume float64) *dexscreenerPair {
+		return &dexscreenerPair{
+			PriceUsd: price,
+			Txns:     dexscreenerTxns{M5: dexscreenerTxn{Buys: buys, Sells: sells}, H24: dexscreenerTxn{Buys: 100 + buys, Sells: 100 + sells}},
+			Volume:   dexscreenerVolumes{M5: volume, H24: 10000 + volume},
+		}
+	}
+
+	steps := []struct {
+		name  string
+		pair  *dexscreenerPair
+		sides []int
+	}{
+		{"baseline", pair("2", 5, 5, 100), []int{0}},
+		{"unchanged", pair("2", 5, 5, 100), nil},
+		{"price only", pair("2.1", 5, 5, 100), []int{0}},
+		{"volume before counters, price down", pair("2", 5, 5, 120), []int{-1}},
+		// m5 rolls two buys off while h24 shows one new buy: the h24 delta wins.
+		{"rolling window", pair("2", 3, 5, 140), []int{1}},
+	}
+	for _, step := range steps {
+		ticks, err := feed.dexScreenerTicks(target, step.pair)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", step.name, err)
+		}
+		if len(ticks) != len(step.sides) {
+			t.Fatalf("%s: expected %d ticks, got %+v", step.name, len(step.sides), ticks)
+		}
+		for i, tk := range ticks {
+			if tk.Side != step.sides[i] || (tk.Side == 0) != (tk.Size == 0) {
+				t.Fatalf("%s: unexpected tick %+v", step.name, tk)
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"memebot-go/internal/signal"
+)
+
+func (f *Feed) runReplay(ctx context.Context, out chan<- signal.Tick) error {
+	if f.replayPath == "" {
+		return fmt.Errorf("replay feed requires a tick file path")
+	}
+	reader, err := OpenTickFile(f.replayPath)
+	if err != nil {
+		return fmt.Errorf("open replay file: %w", err)
+	}
+	defer reader.Close()
+
+	f.log.Info().Str("provider", ProviderReplay).Str("path", f.replayPath).Float64("speed", f.replaySpeed).Msg("replaying recorded ticks")
+
+	var prev time.Time
+	emitted := 0
+	for {
+		tick, err := reader.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				f.log.Info().Int("ticks", emitted).Msg("replay finished")
+				return nil
+			}
+			return fmt.Errorf("decode replay tick: %w", err)
+		}
+		if wait := replayDelay(prev, tick.Ts, f.replaySpeed); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		}
+		if !tick.Ts.IsZero() {
+			prev = tick.Ts
+		}
+		if err := f.emit(ctx, out, tick); err != nil {
+			return err
+		}
+		emitted++
+	}
+}
+
+// replayDelay converts the recorded gap between ticks into wall-clock time; speed <= 0 disables pacing.
+func replayDelay(prev, next time.Time, speed float64) time.Duration {
+	if speed <= 0 || prev.IsZero() || next.IsZero() || !next.After(prev) {
+		return 0
+	}
+	return time.Duration(float64(next.Sub(prev)) / speed)
+}
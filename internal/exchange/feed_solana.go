@@ -0,0 +1,584 @@
+package exchange
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+
+	dex "memebot-go/internal/dex/solana"
+	"memebot-go/internal/instrument"
+	"memebot-go/internal/signal"
+)
+
+const (
+	// SolanaDexRaydium marks a Raydium AMM v4 pool, decoded from ray_log swap logs.
+	SolanaDexRaydium = "raydium"
+	// SolanaDexOrca marks an Orca Whirlpool, decoded from Traded events plus sqrt_price account updates.
+	SolanaDexOrca = "orca"
+
+	defaultSolanaWSURL = "wss://api.mainnet-beta.solana.com"
+)
+
+// SolanaPool describes an on-chain pool streamed by the solana provider under Symbol.
+type SolanaPool struct {
+	Symbol        string
+	Address       string
+	Dex           string
+	BaseDecimals  int
+	QuoteDecimals int
+	Invert        bool // the pool's second token (Raydium pc, Whirlpool token B) is the symbol's base asset
+}
+
+// solanaSubscription ties a websocket subscription ID back to the pool and notification kind it serves.
+type solanaSubscription struct {
+	pool        SolanaPool
+	account     bool
+	unsubscribe bool // set on the pending entry of an unsubscribe request
+}
+
+type solanaRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int64  `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+// solanaMessage covers both subscription replies (ID/Result/Error) and notifications (Method/Params).
+type solanaMessage struct {
+	ID     *int64          `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+	Method string `json:"method"`
+	Params struct {
+		Subscription int64           `json:"subscription"`
+		Result       json.RawMessage `json:"result"`
+	} `json:"params"`
+}
+
+type solanaLogsResult struct {
+	Context struct {
+		Slot uint64 `json:"slot"`
+	} `json:"context"`
+	Value struct {
+		Signature string          `json:"signature"`
+		Err       json.RawMessage `json:"err"`
+		Logs      []string        `json:"logs"`
+	} `json:"value"`
+}
+
+type solanaAccountResult struct {
+	Context struct {
+		Slot uint64 `json:"slot"`
+	} `json:"context"`
+	Value struct {
+		Data []string `json:"data"` // [payload, encoding]
+	} `json:"value"`
+}
+
+// WithSolanaConfig points the solana provider at an RPC websocket endpoint and registers the pools
+// it may stream; the feed's symbols select which registered pools are subscribed.
+func WithSolanaConfig(wsURL, commitment string, pools []SolanaPool) Option {
+	return func(f *Feed) {
+		if wsURL != "" {
+			f.solanaWSURL = wsURL
+		}
+		if commitment != "" {
+			f.solanaCommitment = commitment
+		}
+		for _, pool := range pools {
+			f.solanaPools[pool.Symbol] = pool
+		}
+	}
+}
+
+// WithSolanaRPCURL sets the HTTP RPC endpoint the solana provider reads block times from.
+func WithSolanaRPCURL(rpcURL string) Option {
+	return func(f *Feed) {
+		if rpcURL != "" {
+			f.solanaRPCURL = rpcURL
+		}
+	}
+}
+
+// solanaTargets resolves the feed symbols against the registered pools.
+func (f *Feed) solanaTargets() ([]SolanaPool, error) {
+	var pools []SolanaPool
+	for _, sym := range f.snapshotSymbols() {
+		pool, ok := f.solanaPools[sym]
+		if !ok {
+			f.log.Warn().Str("symbol", sym).Msg("no solana pool configured for symbol, skipping")
+			continue
+		}
+		switch pool.Dex {
+		case SolanaDexRaydium, SolanaDexOrca:
+		default:
+			return nil, fmt.Errorf("solana pool %s: unsupported dex %q", pool.Symbol, pool.Dex)
+		}
+		if _, err := f.instruments.Register(instrument.Instrument{
+			Symbol:        pool.Symbol,
+			Venue:         ProviderSolana,
+			Chain:         "solana",
+			PairAddress:   pool.Address,
+			BaseDecimals:  pool.BaseDecimals,
+			QuoteDecimals: pool.QuoteDecimals,
+		}); err != nil {
+			return nil, fmt.Errorf("solana pool %s: %w", pool.Symbol, err)
+		}
+		pools = append(pools, pool)
+	}
+	return pools, nil
+}
+
+func (f *Feed) runSolana(ctx context.Context, out chan<- signal.Tick) error {
+	pools, err := f.solanaTargets()
+	if err != nil {
+		return err
+	}
+	if len(pools) == 0 {
+		return fmt.Errorf("solana feed requires at least one configured pool")
+	}
+	blockTimes := f.solanaBlockTimes()
+
+	backoff := time.Second
+	const maxBackoff = 30 * time.Second
+
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// The symbol list may have been emptied by SetSymbols; wait for something to stream.
+		if len(f.snapshotSymbols()) == 0 {
+			select {
+			case <-f.symbolsChanged:
+				continue
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		delivered, err := f.consumeSolanaStream(ctx, blockTimes, out)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if delivered {
+			// The connection was healthy for a while; start the next retry cycle from scratch.
+			backoff = time.Second
+		}
+		f.log.Warn().Err(err).Dur("backoff", backoff).Msg("solana feed disconnected, retrying")
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff = time.Duration(math.Min(float64(maxBackoff), float64(backoff)*1.8))
+	}
+}
+
+// solanaSession tracks the subscriptions of one connection so SetSymbols can add and drop pools
+// without reconnecting. Only the goroutine reading the connection's messages uses it.
+type solanaSession struct {
+	conn       *websocket.Conn
+	commitment string
+	requestID  int64
+	pending    map[int64]solanaSubscription // request id -> subscription being created or dropped
+	subs       map[int64]solanaSubscription // live subscription id -> pool
+	active     map[string]SolanaPool        // pools subscribed or being subscribed, by symbol
+}
+
+func (s *solanaSession) send(method string, params []any, sub solanaSubscription) error {
+	s.requestID++
+	s.pending[s.requestID] = sub
+	s.conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+	return s.conn.WriteJSON(solanaRequest{JSONRPC: "2.0", ID: s.requestID, Method: method, Params: params})
+}
+
+func (s *solanaSession) subscribe(pool SolanaPool) error {
+	commitment := map[string]string{"commitment": s.commitment}
+	if err := s.send("logsSubscribe", []any{map[string][]string{"mentions": {pool.Address}}, commitment}, solanaSubscription{pool: pool}); err != nil {
+		return fmt.Errorf("logsSubscribe %s: %w", pool.Symbol, err)
+	}
+	if pool.Dex == SolanaDexOrca {
+		params := []any{pool.Address, map[string]string{"encoding": "base64", "commitment": s.commitment}}
+		if err := s.send("accountSubscribe", params, solanaSubscription{pool: pool, account: true}); err != nil {
+			return fmt.Errorf("accountSubscribe %s: %w", pool.Symbol, err)
+		}
+	}
+	return nil
+}
+
+// unsubscribe stops notifications for a live subscription; they are ignored from here on.
+func (s *solanaSession) unsubscribe(subID int64, sub solanaSubscription) error {
+	delete(s.subs, subID)
+	method := "logsUnsubscribe"
+	if sub.account {
+		method = "accountUnsubscribe"
+	}
+	sub.unsubscribe = true
+	if err := s.send(method, []any{subID}, sub); err != nil {
+		return fmt.Errorf("%s %s: %w", method, sub.pool.Symbol, err)
+	}
+	return nil
+}
+
+// sync subscribes pools that are wanted but not active and unsubscribes active pools that are no longer wanted.
+func (s *solanaSession) sync(pools []SolanaPool) error {
+	wanted := make(map[string]SolanaPool, len(pools))
+	for _, pool := range pools {
+		wanted[pool.Symbol] = pool
+		if current, ok := s.active[pool.Symbol]; ok && current == pool {
+			continue
+		}
+		if err := s.drop(pool.Symbol); err != nil {
+			return err
+		}
+		if err := s.subscribe(pool); err != nil {
+			return err
+		}
+		s.active[pool.Symbol] = pool
+	}
+	for symbol := range s.active {
+		if _, ok := wanted[symbol]; !ok {
+			if err := s.drop(symbol); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// drop unsubscribes every live subscription of symbol; subscriptions still being acknowledged are
+// dropped when their reply arrives.
+func (s *solanaSession) drop(symbol string) error {
+	delete(s.active, symbol)
+	for subID, sub := range s.subs {
+		if sub.pool.Symbol != symbol {
+			continue
+		}
+		if err := s.unsubscribe(subID, sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reply handles the response to a subscribe or unsubscribe request.
+func (s *solanaSession) reply(log zerolog.Logger, msg solanaMessage) error {
+	sub, ok := s.pending[*msg.ID]
+	if !ok {
+		return nil
+	}
+	delete(s.pending, *msg.ID)
+	if sub.unsubscribe {
+		if msg.Error != nil {
+			log.Warn().Str("symbol", sub.pool.Symbol).Str("error", msg.Error.Message).Msg("solana unsubscribe rejected")
+		}
+		return nil
+	}
+	if msg.Error != nil {
+		return fmt.Errorf("subscribe %s: %s (code %d)", sub.pool.Symbol, msg.Error.Message, msg.Error.Code)
+	}
+	var subID int64
+	if err := json.Unmarshal(msg.Result, &subID); err != nil {
+		return fmt.Errorf("subscribe %s: decode subscription id: %w", sub.pool.Symbol, err)
+	}
+	if current, ok := s.active[sub.pool.Symbol]; !ok || current != sub.pool {
+		// The pool was dropped while the request was in flight.
+		return s.unsubscribe(subID, sub)
+	}
+	s.subs[subID] = sub
+	return nil
+}
+
+// consumeSolanaStream subscribes every tracked pool on a fresh connection, follows SetSymbols changes
+// with live subscribe and unsubscribe requests, and emits ticks until it fails, reporting whether any
+// notification was delivered.
+func (f *Feed) consumeSolanaStream(ctx context.Context, blockTimes *solanaBlockTimes, out chan<- signal.Tick) (bool, error) {
+	pools, err := f.solanaTargets()
+	if err != nil {
+		return false, err
+	}
+	dialer := websocket.Dialer{HandshakeTimeout: 10 * time.Second}
+	conn, _, err := dialer.DialContext(ctx, f.solanaWSURL, nil)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	conn.SetReadLimit(4 << 20)
+	resetDeadline := func() { conn.SetReadDeadline(time.Now().Add(60 * time.Second)) }
+	resetDeadline()
+	conn.SetPongHandler(func(string) error {
+		resetDeadline()
+		return nil
+	})
+
+	session := &solanaSession{
+		conn:       conn,
+		commitment: f.solanaCommitment,
+		pending:    make(map[int64]solanaSubscription, 2*len(pools)),
+		subs:       make(map[int64]solanaSubscription, 2*len(pools)),
+		active:     make(map[string]SolanaPool, len(pools)),
+	}
+	if err := session.sync(pools); err != nil {
+		return false, err
+	}
+
+	sessionCtx, sessionCancel := context.WithCancel(ctx)
+	defer sessionCancel()
+	go func() {
+		ticker := time.NewTicker(20 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				// WriteControl may run alongside the session's subscription writes.
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(5*time.Second)); err != nil {
+					f.log.Warn().Err(err).Msg("solana ping failed")
+					return
+				}
+			case <-sessionCtx.Done():
+				return
+			}
+		}
+	}()
+	// Reading moves to its own goroutine so this one can also react to symbol changes; closing the
+	// connection on return unblocks it.
+	messages := make(chan []byte)
+	readErr := make(chan error, 1)
+	go func() {
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				readErr <- err
+				return
+			}
+			resetDeadline()
+			select {
+			case messages <- message:
+			case <-sessionCtx.Done():
+				return
+			}
+		}
+	}()
+
+	symbols := make([]string, 0, len(pools))
+	for _, pool := range pools {
+		symbols = append(symbols, pool.Symbol)
+	}
+	sort.Strings(symbols)
+	f.log.Info().Str("provider", ProviderSolana).Strs("symbols", symbols).Msg("connected market data feed")
+
+	delivered := false
+	for {
+		var message []byte
+		select {
+		case <-ctx.Done():
+			return delivered, ctx.Err()
+		case err := <-readErr:
+			return delivered, err
+		case <-f.symbolsChanged:
+			pools, err := f.solanaTargets()
+			if err != nil {
+				f.log.Warn().Err(err).Msg("solana symbol update rejected; keeping current subscriptions")
+				continue
+			}
+			if err := session.sync(pools); err != nil {
+				return delivered, err
+			}
+			continue
+		case message = <-messages:
+		}
+
+		var msg solanaMessage
+		if err := json.Unmarshal(message, &msg); err != nil {
+			f.log.Warn().Err(err).Msg("failed to decode solana message")
+			continue
+		}
+		if msg.ID != nil {
+			if err := session.reply(f.log, msg); err != nil {
+				return delivered, err
+			}
+			continue
+		}
+
+		sub, ok := session.subs[msg.Params.Subscription]
+		if !ok {
+			continue
+		}
+		var ticks []signal.Tick
+		switch msg.Method {
+		case "logsNotification":
+			ticks = f.decodeSolanaLogs(sub.pool, msg.Params.Result)
+		case "accountNotification":
+			if tick, ok := f.decodeSolanaAccount(sub.pool, msg.Params.Result); ok {
+				ticks = append(ticks, tick)
+			}
+		}
+		for _, tick := range ticks {
+			tick.Ts = blockTimes.stamp(ctx, tick.Slot, tick.Ts)
+			if err := f.emit(ctx, out, tick); err != nil {
+				return delivered, err
+			}
+			delivered = true
+		}
+	}
+}
+
+// decodeSolanaLogs turns a successful transaction's swap events on pool into trade ticks.
+func (f *Feed) decodeSolanaLogs(pool SolanaPool, raw json.RawMessage) []signal.Tick {
+	var result solanaLogsResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		f.log.Warn().Err(err).Msg("failed to decode solana logs notification")
+		return nil
+	}
+	if errField := strings.TrimSpace(string(result.Value.Err)); errField != "" && errField != "null" {
+		return nil
+	}
+
+	var swaps []dex.PoolSwap
+	switch pool.Dex {
+	case SolanaDexRaydium:
+		swaps = dex.DecodeRaydiumSwaps(result.Value.Logs)
+		// ray_log does not name its pool, so a multi-hop through several Raydium pools is ambiguous.
+		if len(swaps) > 1 {
+			f.log.Debug().Str("symbol", pool.Symbol).Str("signature", result.Value.Signature).Int("swaps", len(swaps)).Msg("skipping ambiguous raydium transaction")
+			return nil
+		}
+	case SolanaDexOrca:
+		for _, swap := range dex.DecodeWhirlpoolTrades(result.Value.Logs) {
+			if swap.Pool.String() == pool.Address {
+				swaps = append(swaps, swap)
+			}
+		}
+	}
+
+	// Ticks are stamped on receipt here; the stream swaps in the block time when it is available.
+	now := time.Now()
+	ticks := make([]signal.Tick, 0, len(swaps))
+	for _, swap := range swaps {
+		price, size, side := pool.trade(swap)
+		if price <= 0 || size <= 0 {
+			continue
+		}
+		ticks = append(ticks, signal.Tick{Symbol: pool.Symbol, Price: price, Size: size, Side: side, Ts: now, Slot: result.Context.Slot})
+	}
+	return ticks
+}
+
+// decodeSolanaAccount reads the Whirlpool sqrt_price into a price-only tick (zero size and side).
+func (f *Feed) decodeSolanaAccount(pool SolanaPool, raw json.RawMessage) (signal.Tick, bool) {
+	var result solanaAccountResult
+	if err := json.Unmarshal(raw, &result); err != nil || len(result.Value.Data) == 0 {
+		f.log.Warn().Err(err).Msg("failed to decode solana account notification")
+		return signal.Tick{}, false
+	}
+	data, err := base64.StdEncoding.DecodeString(result.Value.Data[0])
+	if err != nil {
+		f.log.Warn().Err(err).Msg("invalid solana account data")
+		return signal.Tick{}, false
+	}
+	rawPrice, ok := dex.WhirlpoolPrice(data)
+	if !ok {
+		return signal.Tick{}, false
+	}
+	if pool.Invert {
+		rawPrice = 1 / rawPrice
+	}
+	price := rawPrice * math.Pow10(pool.BaseDecimals-pool.QuoteDecimals)
+	return signal.Tick{Symbol: pool.Symbol, Price: price, Ts: time.Now(), Slot: result.Context.Slot}, true
+}
+
+// trade converts a pool-ordered swap into symbol price, base size, and aggressor side (+1 bought base).
+func (pool SolanaPool) trade(swap dex.PoolSwap) (float64, float64, int) {
+	baseRaw, quoteRaw, baseIn := swap.AmountA, swap.AmountB, swap.AToB
+	if pool.Invert {
+		baseRaw, quoteRaw, baseIn = swap.AmountB, swap.AmountA, !swap.AToB
+	}
+	size := float64(baseRaw) / math.Pow10(pool.BaseDecimals)
+	quote := float64(quoteRaw) / math.Pow10(pool.QuoteDecimals)
+	if size <= 0 {
+		return 0, 0, 0
+	}
+	side := 1
+	if baseIn {
+		side = -1
+	}
+	return quote / size, size, side
+}
+
+const (
+	// solanaBlockTimeLookup bounds one getBlockTime call; a slow RPC node falls back to receipt time.
+	solanaBlockTimeLookup = 2 * time.Second
+	// solanaBlockTimeSlots is how many recent slots keep their looked-up block time.
+	solanaBlockTimeSlots = 512
+)
+
+// solanaBlockTimes stamps on-chain ticks with their block time, looked up once per slot over HTTP RPC.
+// A slot whose block time is unavailable keeps the receipt time of its ticks.
+type solanaBlockTimes struct {
+	rpc   *rpc.Client
+	times map[uint64]time.Time // zero when the lookup failed
+}
+
+// solanaBlockTimes returns the block time source for this feed, or nil at processed commitment, where
+// blocks are not yet available to getBlockTime.
+func (f *Feed) solanaBlockTimes() *solanaBlockTimes {
+	if f.solanaCommitment == string(rpc.CommitmentProcessed) {
+		return nil
+	}
+	endpoint := f.solanaRPCURL
+	if endpoint == "" {
+		endpoint = solanaHTTPURL(f.solanaWSURL)
+	}
+	return &solanaBlockTimes{rpc: rpc.New(endpoint), times: make(map[uint64]time.Time)}
+}
+
+// solanaHTTPURL derives the HTTP RPC endpoint served next to a websocket endpoint.
+func solanaHTTPURL(wsURL string) string {
+	switch {
+	case strings.HasPrefix(wsURL, "wss://"):
+		return "https://" + strings.TrimPrefix(wsURL, "wss://")
+	case strings.HasPrefix(wsURL, "ws://"):
+		return "http://" + strings.TrimPrefix(wsURL, "ws://")
+	}
+	return wsURL
+}
+
+// stamp returns the block time of slot, or received when it is unknown.
+func (b *solanaBlockTimes) stamp(ctx context.Context, slot uint64, received time.Time) time.Time {
+	if b == nil || slot == 0 {
+		return received
+	}
+	at, ok := b.times[slot]
+	if !ok {
+		lookupCtx, cancel := context.WithTimeout(ctx, solanaBlockTimeLookup)
+		unix, err := b.rpc.GetBlockTime(lookupCtx, slot)
+		cancel()
+		if err == nil && unix != nil {
+			at = unix.Time()
+		}
+		if len(b.times) >= solanaBlockTimeSlots {
+			for cached := range b.times {
+				if cached+solanaBlockTimeSlots/2 < slot || cached > slot+solanaBlockTimeSlots {
+					delete(b.times, cached)
+				}
+			}
+		}
+		b.times[slot] = at
+	}
+	if at.IsZero() {
+		return received
+	}
+	return at
+}
@@ -0,0 +1,309 @@
+package exchange
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+
+	dex "memebot-go/internal/dex/solana"
+	"memebot-go/internal/signal"
+)
+
+const (
+	testRaydiumPool = "EP2ib6dYdEeqD8MfE2ezHCxX3kP3K2eLKkirfPm5eyMx"
+	testOrcaPool    = "Czfq3xZZDmsdGdUyrNLtRhGc47cXcZtLG4crryfu44zE"
+)
+
+// testSolanaPools matches the captured notifications in testdata/solana_notifications.jsonl.
+var testSolanaPools = []SolanaPool{
+	{Symbol: "WIFSOL", Address: testRaydiumPool, Dex: SolanaDexRaydium, BaseDecimals: 6, QuoteDecimals: 9},
+	{Symbol: "SOLUSDC", Address: testOrcaPool, Dex: SolanaDexOrca, BaseDecimals: 9, QuoteDecimals: 6},
+}
+
+// testSolanaSubscriptions assigns the subscription IDs referenced by the captured notifications.
+var testSolanaSubscriptions = map[string]int64{
+	"logsSubscribe:" + testRaydiumPool: 11,
+	"logsSubscribe:" + testOrcaPool:    21,
+	"accountSubscribe:" + testOrcaPool: 22,
+}
+
+// newSolanaStandIn acknowledges subscriptions with fixed IDs, then replays notifications; the first
+// connection is dropped after dropAfter notifications (0 keeps it open) to exercise reconnects.
+func newSolanaStandIn(t *testing.T, notifications []string, dropAfter int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var connections atomic.Int32
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		attempt := connections.Add(1)
+		for range testSolanaSubscriptions {
+			var req struct {
+				ID     int64             `json:"id"`
+				Method string            `json:"method"`
+				Params []json.RawMessage `json:"params"`
+			}
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+			var address string
+			if req.Method == "logsSubscribe" {
+				var filter struct {
+					Mentions []string `json:"mentions"`
+				}
+				_ = json.Unmarshal(req.Params[0], &filter)
+				address = filter.Mentions[0]
+			} else {
+				_ = json.Unmarshal(req.Params[0], &address)
+			}
+			sub, ok := testSolanaSubscriptions[req.Method+":"+address]
+			if !ok {
+				_ = conn.WriteJSON(map[string]any{"jsonrpc": "2.0", "id": req.ID, "error": map[string]any{"code": -32602, "message": "unknown"}})
+				return
+			}
+			_ = conn.WriteJSON(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": sub})
+		}
+		replay := notifications
+		if attempt == 1 && dropAfter > 0 {
+			replay = notifications[:dropAfter]
+		} else if attempt > 1 {
+			replay = notifications[dropAfter:]
+		}
+		for _, note := range replay {
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(note)); err != nil {
+				return
+			}
+		}
+		if attempt == 1 && dropAfter > 0 {
+			return
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &connections
+}
+
+func loadSolanaNotifications(t *testing.T) []string {
+	t.Helper()
+	file, err := os.Open("testdata/solana_notifications.jsonl")
+	if err != nil {
+		t.Fatalf("open fixtures: %v", err)
+	}
+	defer file.Close()
+	var out []string
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	for scanner.Scan() {
+		out = append(out, scanner.Text())
+	}
+	return out
+}
+
+func TestRunSolanaDecodesSwapsAndReconnects(t *testing.T) {
+	server, connections := newSolanaStandIn(t, loadSolanaNotifications(t), 2)
+	feed := NewFeed(ProviderSolana, []string{"WIFSOL", "SOLUSDC"}, zerolog.Nop(),
+		WithSolanaConfig("ws"+strings.TrimPrefix(server.URL, "http"), "processed", testSolanaPools))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	out := make(chan signal.Tick, 8)
+	go func() { _ = feed.Run(ctx, out) }()
+
+	// The failed transaction in the second notification is skipped.
+	buy := receiveTick(t, ctx, out)
+	if buy.Symbol != "WIFSOL" || buy.Provider != ProviderSolana || buy.Side != 1 || buy.Size != 1000 || math.Abs(buy.Price-0.002) > 1e-12 || buy.Slot != 250000001 {
+		t.Fatalf("unexpected raydium buy %+v", buy)
+	}
+	sell := receiveTick(t, ctx, out)
+	if sell.Side != -1 || sell.Size != 250 || math.Abs(sell.Price-0.002) > 1e-12 {
+		t.Fatalf("unexpected raydium sell after reconnect %+v", sell)
+	}
+	if got := connections.Load(); got != 2 {
+		t.Fatalf("expected one reconnect, saw %d connections", got)
+	}
+	trade := receiveTick(t, ctx, out)
+	if trade.Symbol != "SOLUSDC" || trade.Side != -1 || trade.Size != 1 || math.Abs(trade.Price-150) > 1e-9 {
+		t.Fatalf("unexpected whirlpool trade %+v", trade)
+	}
+	mark := receiveTick(t, ctx, out)
+	if mark.Symbol != "SOLUSDC" || mark.Size != 0 || math.Abs(mark.Price-150) > 1e-6 || mark.Slot != 250000005 {
+		t.Fatalf("unexpected whirlpool price tick %+v", mark)
+	}
+}
+
+func TestSolanaPoolTradeInvert(t *testing.T) {
+	pool := SolanaPool{BaseDecimals: 9, QuoteDecimals: 6, Invert: true}
+	// Token A is USDC (quote), token B is SOL (base); paying USDC for SOL is a buy.
+	price, size, side := pool.trade(dex.PoolSwap{AmountA: 150_000_000, AmountB: 1_000_000_000, AToB: true})
+	if price != 150 || size != 1 || side != 1 {
+		t.Fatalf("unexpected inverted trade price=%.4f size=%.4f side=%d", price, size, side)
+	}
+}
+
+func TestRunSolanaRequiresConfiguredPool(t *testing.T) {
+	feed := NewFeed(ProviderSolana, []string{"UNKNOWN"}, zerolog.Nop())
+	if err := feed.Run(context.Background(), make(chan signal.Tick)); err == nil {
+		t.Fatalf("expected an error without configured pools")
+	}
+}
+
+func TestRunSolanaFollowsSymbolChanges(t *testing.T) {
+	notifications := loadSolanaNotifications(t)
+	requests := make(chan string, 8)
+	push := make(chan string)
+	var connections atomic.Int32
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		connections.Add(1)
+		var mu sync.Mutex
+		go func() {
+			for note := range push {
+				mu.Lock()
+				err := conn.WriteMessage(websocket.TextMessage, []byte(note))
+				mu.Unlock()
+				if err != nil {
+					return
+				}
+			}
+		}()
+		for {
+			var req struct {
+				ID     int64             `json:"id"`
+				Method string            `json:"method"`
+				Params []json.RawMessage `json:"params"`
+			}
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+			var target string
+			var result any = true
+			switch req.Method {
+			case "logsSubscribe":
+				var filter struct {
+					Mentions []string `json:"mentions"`
+				}
+				_ = json.Unmarshal(req.Params[0], &filter)
+				target = filter.Mentions[0]
+				result = testSolanaSubscriptions[req.Method+":"+target]
+			case "accountSubscribe":
+				_ = json.Unmarshal(req.Params[0], &target)
+				result = testSolanaSubscriptions[req.Method+":"+target]
+			default:
+				target = string(req.Params[0])
+			}
+			mu.Lock()
+			_ = conn.WriteJSON(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result})
+			mu.Unlock()
+			requests <- req.Method + ":" + target
+		}
+	}))
+	defer server.Close()
+	defer close(push)
+
+	feed := NewFeed(ProviderSolana, []string{"WIFSOL"}, zerolog.Nop(),
+		WithSolanaConfig("ws"+strings.TrimPrefix(server.URL, "http"), "processed", testSolanaPools))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	out := make(chan signal.Tick, 8)
+	go func() { _ = feed.Run(ctx, out) }()
+
+	expect := func(want ...string) {
+		t.Helper()
+		got := make(map[string]bool)
+		for range want {
+			select {
+			case req := <-requests:
+				got[req] = true
+			case <-ctx.Done():
+				t.Fatalf("timed out waiting for %v, saw %v", want, got)
+			}
+		}
+		for _, req := range want {
+			if !got[req] {
+				t.Fatalf("expected requests %v, saw %v", want, got)
+			}
+		}
+	}
+	expect("logsSubscribe:" + testRaydiumPool)
+	push <- notifications[0]
+	if tick := receiveTick(t, ctx, out); tick.Symbol != "WIFSOL" {
+		t.Fatalf("unexpected tick %+v", tick)
+	}
+
+	feed.SetSymbols([]string{"SOLUSDC"})
+	expect("logsSubscribe:"+testOrcaPool, "accountSubscribe:"+testOrcaPool, "logsUnsubscribe:11")
+	// The dropped pool's notifications are ignored; the added pool streams on the same connection.
+	push <- notifications[0]
+	push <- notifications[3]
+	if tick := receiveTick(t, ctx, out); tick.Symbol != "SOLUSDC" {
+		t.Fatalf("expected the added pool's trade, got %+v", tick)
+	}
+	if got := connections.Load(); got != 1 {
+		t.Fatalf("expected symbol changes without reconnecting, saw %d connections", got)
+	}
+}
+
+func TestSolanaBlockTimesStampSlots(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Params []uint64        `json:"params"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		calls.Add(1)
+		if req.Params[0] == 7 {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(req.ID) + `,"error":{"code":-32004,"message":"Block not available for slot 7"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(req.ID) + `,"result":1700000000}`))
+	}))
+	defer server.Close()
+
+	feed := NewFeed(ProviderSolana, nil, zerolog.Nop(), WithSolanaConfig("", "confirmed", nil), WithSolanaRPCURL(server.URL))
+	times := feed.solanaBlockTimes()
+	received := time.Now()
+	for i := 0; i < 2; i++ {
+		if at := times.stamp(context.Background(), 5, received); !at.Equal(time.Unix(1700000000, 0)) {
+			t.Fatalf("expected the block time, got %v", at)
+		}
+	}
+	if at := times.stamp(context.Background(), 7, received); !at.Equal(received) {
+		t.Fatalf("expected the receipt time for a slot without block time, got %v", at)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected one lookup per slot, saw %d", got)
+	}
+
+	processed := NewFeed(ProviderSolana, nil, zerolog.Nop(), WithSolanaConfig("", "processed", nil))
+	if processed.solanaBlockTimes() != nil {
+		t.Fatalf("expected no block time lookups at processed commitment")
+	}
+	if got := solanaHTTPURL("wss://rpc.example/ws"); got != "https://rpc.example/ws" {
+		t.Fatalf("unexpected derived rpc url %s", got)
+	}
+}
@@ -3,13 +3,21 @@ package exchange
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/rs/zerolog"
 
+	"memebot-go/internal/instrument"
+	"memebot-go/internal/metrics"
 	"memebot-go/internal/signal"
 )
 
@@ -30,11 +38,60 @@ func TestFeedRunEmitsTicks(t *testing.T) {
 			t.Fatalf("unexpected symbol %s", tk.Symbol)
 		}
 		cancel()
+		// The feed stamps the tick right after the send completes, so give it a moment.
+		deadline := time.Now().Add(time.Second)
+		for {
+			if _, ok := feed.LastTick("BTCUSDT"); ok {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected last tick to be stamped on emit")
+			}
+			time.Sleep(time.Millisecond)
+		}
 	case <-time.After(2 * time.Second):
 		t.Fatal("timed out waiting for tick")
 	}
 }
 
+func TestSetSymbolsPrunesTickAges(t *testing.T) {
+	feed := NewFeed(ProviderDexScreener, []string{"WIFSOL@solana/PAIRWIF", "BONKSOL@solana/PAIRBONK"}, zerolog.Nop())
+	wif := feed.Instruments().RegisterPair(instrument.Instrument{Chain: "solana", PairAddress: "PAIRWIF"}, "WIFSOL")
+	bonk := feed.Instruments().RegisterPair(instrument.Instrument{Chain: "solana", PairAddress: "PAIRBONK"}, "BONKSOL")
+	now := time.Now()
+	feed.touch(ProviderDexScreener, wif.Symbol, now)
+	feed.touch(ProviderDexScreener, bonk.Symbol, now)
+	before := testutil.CollectAndCount(metrics.FeedLastTickTimestamp)
+
+	feed.SetSymbols([]string{wif.FeedSymbol()})
+	if _, ok := feed.LastTick(wif.Symbol); !ok {
+		t.Fatalf("expected tracked %s to keep its tick age", wif.Symbol)
+	}
+	if _, ok := feed.LastTick(bonk.Symbol); ok {
+		t.Fatalf("expected dropped %s to be pruned", bonk.Symbol)
+	}
+	if after := testutil.CollectAndCount(metrics.FeedLastTickTimestamp); after != before-1 {
+		t.Fatalf("expected the dropped symbol's gauge deleted, series %d -> %d", before, after)
+	}
+}
+
+func TestSetSymbolsKeepsTickAgesOfAliaslessSymbols(t *testing.T) {
+	feed := NewFeed(ProviderDexScreener, nil, zerolog.Nop(), WithDexScreenerConfig("", "solana"))
+	wif := feed.Instruments().RegisterPair(instrument.Instrument{Chain: "solana", PairAddress: "PAIRWIF"}, "WIFSOL")
+	bonk := feed.Instruments().RegisterPair(instrument.Instrument{Chain: "solana", PairAddress: "PAIRBONK"}, "BONKSOL")
+	now := time.Now()
+	feed.touch(ProviderDexScreener, wif.Symbol, now)
+	feed.touch(ProviderDexScreener, bonk.Symbol, now)
+
+	// Both forms leave out the alias; the bare address falls back to the default chain.
+	feed.SetSymbols([]string{"solana/PAIRWIF", "PAIRBONK"})
+	for _, inst := range []instrument.Instrument{wif, bonk} {
+		if _, ok := feed.LastTick(inst.Symbol); !ok {
+			t.Fatalf("expected tracked %s to keep its tick age", inst.Symbol)
+		}
+	}
+}
+
 func TestParseBinanceSymbol(t *testing.T) {
 	cases := map[string]string{
 		"btcusdt@trade":    "BTCUSDT",
@@ -50,12 +107,12 @@ func TestParseBinanceSymbol(t *testing.T) {
 }
 
 func TestParseDexScreenerSymbols(t *testing.T) {
-	targets, err := parseDexScreenerSymbols([]string{"WIFSOL@solana/PAIR", "BODEN@/another"}, "solana")
+	targets, err := parseDexScreenerSymbols(instrument.NewRegistry(zerolog.Nop()), []string{"WIFSOL@solana/PAIR", "BODEN@/another", "WIF@solana/PAIR"}, "solana")
 	if err != nil {
 		t.Fatalf("parseDexScreenerSymbols returned error: %v", err)
 	}
 	if len(targets) != 2 {
-		t.Fatalf("expected 2 targets, got %d", len(targets))
+		t.Fatalf("expected 2 targets with the repeated pair polled once, got %d", len(targets))
 	}
 	if targets[0].Alias != "WIFSOL_PAIR" || targets[0].Chain != "solana" || targets[0].Address != "PAIR" {
 		t.Fatalf("unexpected first target: %+v", targets[0])
@@ -66,9 +123,12 @@ func TestParseDexScreenerSymbols(t *testing.T) {
 }
 
 func TestRunDexScreenerEmitsTick(t *testing.T) {
-	const body = `{"pairs":[{"priceUsd":"0.01","priceNative":"0.0001","txns":{"m5":{"buys":3,"sells":1},"h1":{"buys":5,"sells":4},"h6":{"buys":10,"sells":8},"h24":{"buys":20,"sells":20}},"volume":{"m5":120,"h1":500,"h6":1000,"h24":5000},"liquidity":{"usd":20000,"base":1000000,"quote":5000}}]}`
+	// Each poll reports two more m5 buys, one more sell, and $30 more volume than the last.
+	var polls atomic.Int32
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		_, _ = w.Write([]byte(body))
+		n := int(polls.Add(1)) - 1
+		fmt.Fprintf(w, `{"pairs":[{"priceUsd":"0.01","priceNative":"0.0001","txns":{"m5":{"buys":%d,"sells":%d},"h24":{"buys":20,"sells":20}},"volume":{"m5":%d,"h24":5000},"liquidity":{"usd":20000}}]}`,
+			3+2*n, 1+n, 120+30*n)
 	}))
 	defer server.Close()
 
@@ -83,7 +143,7 @@ func TestRunDexScreenerEmitsTick(t *testing.T) {
 		WithPollInterval(50*time.Millisecond),
 	)
 
-	ticks := make(chan signal.Tick, 1)
+	ticks := make(chan signal.Tick, 4)
 	errCh := make(chan error, 1)
 	go func() {
 		if err := feed.Run(ctx, ticks); err != nil && !errors.Is(err, context.Canceled) {
@@ -92,23 +152,35 @@ func TestRunDexScreenerEmitsTick(t *testing.T) {
 		close(errCh)
 	}()
 
-	select {
-	case tk := <-ticks:
-		if tk.Symbol != "WIFSOL_PAIR" {
-			t.Fatalf("unexpected symbol %s", tk.Symbol)
+	next := func() signal.Tick {
+		t.Helper()
+		select {
+		case tk := <-ticks:
+			return tk
+		case <-time.After(2 * time.Second):
+			cancel()
+			t.Fatalf("timed out waiting for tick")
 		}
-		if tk.Price <= 0 {
-			t.Fatalf("expected positive price")
-		}
-		if tk.Size <= 0 {
-			t.Fatalf("expected positive size")
-		}
-		cancel()
-	case <-time.After(2 * time.Second):
-		cancel()
-		t.Fatalf("timed out waiting for tick")
+		return signal.Tick{}
 	}
 
+	baseline := next()
+	if baseline.Symbol != "WIFSOL_PAIR" || baseline.Price <= 0 {
+		t.Fatalf("unexpected baseline tick %+v", baseline)
+	}
+	if baseline.Size != 0 || baseline.Side != 0 {
+		t.Fatalf("expected a price-only baseline on first poll, got %+v", baseline)
+	}
+	// $30 over three new trades at $0.01: two buys worth 2000 units and one sell worth 1000.
+	sell, buy := next(), next()
+	if sell.Side != -1 || sell.Trades != 1 || math.Abs(sell.Size-1000) > 1e-6 {
+		t.Fatalf("unexpected sell flow %+v", sell)
+	}
+	if buy.Side != 1 || buy.Trades != 2 || math.Abs(buy.Size-2000) > 1e-6 {
+		t.Fatalf("unexpected buy flow %+v", buy)
+	}
+	cancel()
+
 	select {
 	case err := <-errCh:
 		if err != nil {
@@ -118,3 +190,82 @@ func TestRunDexScreenerEmitsTick(t *testing.T) {
 		t.Fatalf("feed did not stop after cancel")
 	}
 }
+
+func TestRunReplayEmitsRecordedTicks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ticks.jsonl")
+	recorder, err := NewJSONLTickRecorder(zerolog.Nop(), path)
+	if err != nil {
+		t.Fatalf("NewJSONLTickRecorder error: %v", err)
+	}
+	start := time.Now().Add(-time.Hour)
+	for i := 0; i < 3; i++ {
+		recorder.Record(signal.Tick{Symbol: "BTCUSDT", Price: 100 + float64(i), Size: 1, Side: 1, Ts: start.Add(time.Duration(i) * time.Minute)})
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close error: %v", err)
+	}
+
+	feed := NewFeed(ProviderReplay, nil, zerolog.Nop(), WithReplayConfig(path, 0))
+	ticks := make(chan signal.Tick, 3)
+	if err := feed.Run(context.Background(), ticks); err != nil {
+		t.Fatalf("replay returned error: %v", err)
+	}
+	close(ticks)
+	var prices []float64
+	for tk := range ticks {
+		prices = append(prices, tk.Price)
+	}
+	if len(prices) != 3 || prices[0] != 100 || prices[2] != 102 {
+		t.Fatalf("unexpected replayed prices %+v", prices)
+	}
+}
+
+func TestFeedRecordsEmittedTicks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ticks.jsonl")
+	recorder, err := NewJSONLTickRecorder(zerolog.Nop(), path)
+	if err != nil {
+		t.Fatalf("NewJSONLTickRecorder error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	feed := NewFeed(ProviderStub, []string{"BTCUSDT"}, zerolog.Nop(), WithTickRecorder(recorder))
+	ticks := make(chan signal.Tick, 1)
+	done := make(chan struct{})
+	go func() {
+		_ = feed.Run(ctx, ticks)
+		close(done)
+	}()
+	select {
+	case <-ticks:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for tick")
+	}
+	cancel()
+	<-done
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close error: %v", err)
+	}
+
+	reader, err := OpenTickFile(path)
+	if err != nil {
+		t.Fatalf("OpenTickFile error: %v", err)
+	}
+	defer reader.Close()
+	tk, err := reader.Next()
+	if err != nil {
+		t.Fatalf("expected recorded tick: %v", err)
+	}
+	if tk.Symbol != "BTCUSDT" {
+		t.Fatalf("unexpected recorded symbol %s", tk.Symbol)
+	}
+}
+
+func TestFeedSymbolFilter(t *testing.T) {
+	feed := NewFeed(ProviderDexScreener, []string{"A@solana/1", "B@solana/2"}, zerolog.Nop())
+	feed.SetSymbolFilter(func(sym string) bool { return !strings.HasSuffix(sym, "/2") })
+	feed.SetSymbols([]string{"A@solana/1", "B@solana/2", "C@solana/3"})
+	if got := strings.Join(feed.snapshotSymbols(), ","); got != "A@solana/1,C@solana/3" {
+		t.Fatalf("unexpected filtered symbols %s", got)
+	}
+}
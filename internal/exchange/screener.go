@@ -0,0 +1,80 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Names of the safety checks reported in ScreenFinding.Check and listed in screening.reject.
+const (
+	CheckMintAuthority   = "mint_authority"
+	CheckFreezeAuthority = "freeze_authority"
+	CheckTopHolders      = "top_holders"
+	CheckLPBurn          = "lp_burn"
+	CheckPairAge         = "pair_age"
+	CheckUnverified      = "unverified"
+)
+
+// ScreenCandidate describes a discovered pair for token safety screening.
+type ScreenCandidate struct {
+	Symbol      string
+	Chain       string
+	DexID       string
+	PairAddress string
+	BaseMint    string
+	CreatedAt   time.Time // zero when Dexscreener omits pairCreatedAt
+}
+
+// ScreenFinding is one failed check; Reject drops the candidate, otherwise it is down-scored.
+type ScreenFinding struct {
+	Check  string
+	Reason string
+	Reject bool
+}
+
+func (f ScreenFinding) String() string {
+	return f.Check + ": " + f.Reason
+}
+
+// Screener inspects discovery candidates before they join the universe.
+type Screener interface {
+	Screen(ctx context.Context, cand ScreenCandidate) ([]ScreenFinding, error)
+}
+
+// PairAgeScreener flags pairs younger than a minimum age.
+type PairAgeScreener struct {
+	minAge time.Duration
+	reject bool
+	now    func() time.Time
+}
+
+// NewPairAgeScreener flags pairs created less than minAge ago, rejecting them outright when reject is set.
+func NewPairAgeScreener(minAge time.Duration, reject bool) *PairAgeScreener {
+	return &PairAgeScreener{minAge: minAge, reject: reject, now: time.Now}
+}
+
+// Screen implements Screener; pairs without a creation time pass.
+func (s *PairAgeScreener) Screen(_ context.Context, cand ScreenCandidate) ([]ScreenFinding, error) {
+	if s.minAge <= 0 || cand.CreatedAt.IsZero() {
+		return nil, nil
+	}
+	age := s.now().Sub(cand.CreatedAt)
+	if age >= s.minAge {
+		return nil, nil
+	}
+	return []ScreenFinding{{
+		Check:  CheckPairAge,
+		Reason: fmt.Sprintf("pair is %s old, minimum %s", age.Round(time.Second), s.minAge),
+		Reject: s.reject,
+	}}, nil
+}
+
+func rejectSet(checks []string) map[string]bool {
+	set := make(map[string]bool, len(checks))
+	for _, check := range checks {
+		set[strings.ToLower(strings.TrimSpace(check))] = true
+	}
+	return set
+}
@@ -0,0 +1,229 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	solana "github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/rs/zerolog"
+
+	"memebot-go/internal/config"
+	dex "memebot-go/internal/dex/solana"
+)
+
+const (
+	defaultScreenCacheTTL = 10 * time.Minute
+	// screenRetryAfter is how long a failed inspection is cached before the RPC is tried again.
+	screenRetryAfter = time.Minute
+)
+
+// rpcChecks are the checks SolanaScreener evaluates over RPC; an RPC failure leaves all of them unknown.
+var rpcChecks = []string{CheckMintAuthority, CheckFreezeAuthority, CheckTopHolders, CheckLPBurn}
+
+// SolanaScreener checks a Solana pair's base mint and pool over RPC: mint and freeze authorities,
+// top-holder concentration, and (for Raydium AMM v4 pools) how much of the LP supply was burned.
+// LP tokens held by lockers rather than burned count as unburned.
+type SolanaScreener struct {
+	log        zerolog.Logger
+	rpc        *rpc.Client
+	commitment rpc.CommitmentType
+	cfg        config.Screening
+	reject     map[string]bool
+	ttl        time.Duration
+	now        func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedScreen
+}
+
+type cachedScreen struct {
+	findings []ScreenFinding
+	at       time.Time
+	ttl      time.Duration
+}
+
+// NewSolanaScreener builds a screener against cfg.RPCURL (mainnet by default).
+func NewSolanaScreener(log zerolog.Logger, cfg config.Screening) *SolanaScreener {
+	endpoint := cfg.RPCURL
+	if endpoint == "" {
+		endpoint = rpc.MainNetBeta_RPC
+	}
+	commitment := rpc.CommitmentType(cfg.Commitment)
+	if commitment == "" {
+		commitment = rpc.CommitmentConfirmed
+	}
+	ttl := time.Duration(cfg.CacheTTL) * time.Millisecond
+	if ttl <= 0 {
+		ttl = defaultScreenCacheTTL
+	}
+	if cfg.TopHolders <= 0 {
+		cfg.TopHolders = 10
+	}
+	return &SolanaScreener{
+		log:        log,
+		rpc:        rpc.New(endpoint),
+		commitment: commitment,
+		cfg:        cfg,
+		reject:     rejectSet(cfg.Reject),
+		ttl:        ttl,
+		now:        time.Now,
+		cache:      make(map[string]cachedScreen),
+	}
+}
+
+// Screen implements Screener for Solana pairs; other chains pass untouched. Results are cached per pair.
+// When the RPC fails the pair is flagged unverified, and that finding is cached for screenRetryAfter so
+// every refresh does not hit the RPC again.
+func (s *SolanaScreener) Screen(ctx context.Context, cand ScreenCandidate) ([]ScreenFinding, error) {
+	if cand.Chain != "solana" || cand.BaseMint == "" {
+		return nil, nil
+	}
+	now := s.now()
+	s.mu.Lock()
+	cached, ok := s.cache[cand.PairAddress]
+	s.mu.Unlock()
+	if ok && now.Sub(cached.at) < cached.ttl {
+		return cached.findings, nil
+	}
+	ttl := s.ttl
+	findings, err := s.inspect(ctx, cand)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		s.log.Warn().Err(err).Str("symbol", cand.Symbol).Msg("solana screening failed")
+		findings = []ScreenFinding{s.unverified(err)}
+		ttl = min(ttl, screenRetryAfter)
+	}
+	s.mu.Lock()
+	s.cache[cand.PairAddress] = cachedScreen{findings: findings, at: now, ttl: ttl}
+	s.mu.Unlock()
+	return findings, nil
+}
+
+// unverified flags a pair whose checks could not be evaluated. It rejects when unverified is listed in
+// reject, or when any listed check is one the failed RPC would have answered: a check that must hold
+// cannot pass by default.
+func (s *SolanaScreener) unverified(err error) ScreenFinding {
+	finding := s.finding(CheckUnverified, err.Error())
+	for _, check := range rpcChecks {
+		finding.Reject = finding.Reject || s.reject[check]
+	}
+	return finding
+}
+
+func (s *SolanaScreener) inspect(ctx context.Context, cand ScreenCandidate) ([]ScreenFinding, error) {
+	mintKey, err := solana.PublicKeyFromBase58(cand.BaseMint)
+	if err != nil {
+		return nil, fmt.Errorf("base mint %q: %w", cand.BaseMint, err)
+	}
+	mintData, _, err := s.account(ctx, mintKey)
+	if err != nil {
+		return nil, fmt.Errorf("load mint: %w", err)
+	}
+	mint, err := dex.DecodeMint(mintData)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []ScreenFinding
+	if mint.MintAuthority != nil {
+		findings = append(findings, s.finding(CheckMintAuthority, fmt.Sprintf("mint authority %s can inflate supply", mint.MintAuthority)))
+	}
+	if mint.FreezeAuthority != nil {
+		findings = append(findings, s.finding(CheckFreezeAuthority, fmt.Sprintf("freeze authority %s can freeze holders", mint.FreezeAuthority)))
+	}
+
+	// The pool's own vaults are usually the largest holders; knowing them keeps concentration honest.
+	vaults := make(map[solana.PublicKey]bool)
+	var raydium *dex.RaydiumPool
+	if pairKey, err := solana.PublicKeyFromBase58(cand.PairAddress); err == nil {
+		poolData, owner, err := s.account(ctx, pairKey)
+		if err != nil {
+			return nil, fmt.Errorf("load pool: %w", err)
+		}
+		switch owner.String() {
+		case dex.RaydiumAMMProgramID:
+			pool, err := dex.DecodeRaydiumPool(poolData)
+			if err != nil {
+				return nil, err
+			}
+			raydium = &pool
+			vaults[pool.CoinVault], vaults[pool.PCVault] = true, true
+		case dex.WhirlpoolProgramID:
+			if vaultA, vaultB, err := dex.WhirlpoolVaults(poolData); err == nil {
+				vaults[vaultA], vaults[vaultB] = true, true
+			}
+		}
+	}
+
+	if s.cfg.MaxTopHolderPct > 0 && mint.Supply > 0 {
+		share, err := s.topHolderShare(ctx, mintKey, mint.Supply, vaults)
+		if err != nil {
+			return nil, fmt.Errorf("largest accounts: %w", err)
+		}
+		if share > s.cfg.MaxTopHolderPct {
+			findings = append(findings, s.finding(CheckTopHolders, fmt.Sprintf("top %d holders own %.1f%% of supply (max %.1f%%)", s.cfg.TopHolders, share, s.cfg.MaxTopHolderPct)))
+		}
+	}
+
+	if s.cfg.MinLPBurnPct > 0 && raydium != nil && raydium.LPReserve > 0 {
+		lpData, _, err := s.account(ctx, raydium.LPMint)
+		if err != nil {
+			return nil, fmt.Errorf("load lp mint: %w", err)
+		}
+		lpMint, err := dex.DecodeMint(lpData)
+		if err != nil {
+			return nil, err
+		}
+		burned := 0.0
+		if lpMint.Supply < raydium.LPReserve {
+			burned = 100 * (1 - float64(lpMint.Supply)/float64(raydium.LPReserve))
+		}
+		if burned < s.cfg.MinLPBurnPct {
+			findings = append(findings, s.finding(CheckLPBurn, fmt.Sprintf("%.1f%% of LP burned (min %.1f%%)", burned, s.cfg.MinLPBurnPct)))
+		}
+	}
+	return findings, nil
+}
+
+func (s *SolanaScreener) account(ctx context.Context, key solana.PublicKey) ([]byte, solana.PublicKey, error) {
+	out, err := s.rpc.GetAccountInfoWithOpts(ctx, key, &rpc.GetAccountInfoOpts{Encoding: solana.EncodingBase64, Commitment: s.commitment})
+	if err != nil {
+		return nil, solana.PublicKey{}, fmt.Errorf("%s: %w", key, err)
+	}
+	return out.Value.Data.GetBinary(), out.Value.Owner, nil
+}
+
+// topHolderShare returns the percentage of supply held by the largest non-vault token accounts.
+func (s *SolanaScreener) topHolderShare(ctx context.Context, mint solana.PublicKey, supply uint64, vaults map[solana.PublicKey]bool) (float64, error) {
+	out, err := s.rpc.GetTokenLargestAccounts(ctx, mint, s.commitment)
+	if err != nil {
+		return 0, err
+	}
+	var held float64
+	counted := 0
+	for _, holder := range out.Value {
+		if counted >= s.cfg.TopHolders {
+			break
+		}
+		if holder == nil || vaults[holder.Address] {
+			continue
+		}
+		amount, err := strconv.ParseUint(holder.Amount, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("holder %s amount %q: %w", holder.Address, holder.Amount, err)
+		}
+		held += float64(amount)
+		counted++
+	}
+	return 100 * held / float64(supply), nil
+}
+
+func (s *SolanaScreener) finding(check, reason string) ScreenFinding {
+	return ScreenFinding{Check: check, Reason: reason, Reject: s.reject[check]}
+}
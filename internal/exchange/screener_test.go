@@ -0,0 +1,199 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	solana "github.com/gagliardetto/solana-go"
+	"github.com/rs/zerolog"
+
+	"memebot-go/internal/config"
+	dex "memebot-go/internal/dex/solana"
+	"memebot-go/internal/dex/solana/solanatest"
+)
+
+// screenedToken registers a Raydium pool and its base mint on the fake RPC.
+type screenedToken struct {
+	name      string
+	pool      solana.PublicKey
+	mint      solana.PublicKey
+	liquidity float64
+	createdAt time.Time
+}
+
+func addRaydiumToken(t *testing.T, rpc *solanatest.Server, name string, mintAuthority, freezeAuthority *solana.PublicKey, lpSupply, lpReserve uint64, liquidity float64) screenedToken {
+	t.Helper()
+	tok := screenedToken{
+		name:      name,
+		pool:      solana.NewWallet().PublicKey(),
+		mint:      solana.NewWallet().PublicKey(),
+		liquidity: liquidity,
+		createdAt: time.Now().Add(-24 * time.Hour),
+	}
+	coinVault, pcVault, lpMint := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	raydium := solana.MustPublicKeyFromBase58(dex.RaydiumAMMProgramID)
+	rpc.SetAccount(tok.pool, raydium, solanatest.RaydiumPoolData(coinVault, pcVault, tok.mint, solana.SolMint, lpMint, lpReserve))
+	rpc.SetAccount(tok.mint, solana.TokenProgramID, solanatest.MintData(mintAuthority, freezeAuthority, 1_000_000, 6))
+	rpc.SetAccount(lpMint, solana.TokenProgramID, solanatest.MintData(nil, nil, lpSupply, 6))
+	// The pool vault is the largest holder and must not count toward concentration.
+	rpc.SetLargestAccounts(tok.mint,
+		solanatest.Holder{Address: coinVault, Amount: 800_000},
+		solanatest.Holder{Address: solana.NewWallet().PublicKey(), Amount: 50_000},
+		solanatest.Holder{Address: solana.NewWallet().PublicKey(), Amount: 40_000},
+	)
+	return tok
+}
+
+func screeningSearchServer(t *testing.T, tokens []screenedToken) *httptest.Server {
+	t.Helper()
+	pairs := make([]map[string]any, len(tokens))
+	for i, tok := range tokens {
+		pairs[i] = map[string]any{
+			"chainId":       "solana",
+			"dexId":         "raydium",
+			"pairAddress":   tok.pool.String(),
+			"baseToken":     map[string]any{"address": tok.mint.String(), "symbol": tok.name},
+			"quoteToken":    map[string]any{"address": solana.SolMint.String(), "symbol": "SOL"},
+			"priceUsd":      "0.01",
+			"volume":        map[string]any{"h24": 10000},
+			"liquidity":     map[string]any{"usd": tok.liquidity},
+			"pairCreatedAt": tok.createdAt.UnixMilli(),
+		}
+	}
+	body, err := json.Marshal(map[string]any{"pairs": pairs})
+	if err != nil {
+		t.Fatalf("marshal pairs: %v", err)
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(body)
+	}))
+}
+
+func TestDiscoveryScreensTokensOverRPC(t *testing.T) {
+	rpc := solanatest.NewServer()
+	defer rpc.Close()
+	authority := solana.NewWallet().PublicKey()
+
+	safe := addRaydiumToken(t, rpc, "SAFE", nil, nil, 0, 1000, 20000)
+	frozen := addRaydiumToken(t, rpc, "FROZEN", nil, &authority, 0, 1000, 30000)
+	// Mintable with half the LP still outstanding: kept, but down-scored twice despite deeper liquidity.
+	risky := addRaydiumToken(t, rpc, "RISKY", &authority, nil, 500, 1000, 25000)
+	young := addRaydiumToken(t, rpc, "YOUNG", nil, nil, 0, 1000, 20000)
+	young.createdAt = time.Now().Add(-time.Minute)
+	unknown := screenedToken{name: "GHOST", pool: solana.NewWallet().PublicKey(), mint: solana.NewWallet().PublicKey(), liquidity: 10000, createdAt: time.Now().Add(-time.Hour)}
+
+	server := screeningSearchServer(t, []screenedToken{safe, frozen, risky, young, unknown})
+	defer server.Close()
+
+	discCfg := config.Discovery{
+		Enabled:         true,
+		Keywords:        []string{"meme"},
+		Chains:          []string{"solana"},
+		MaxPairs:        10,
+		MinLiquidityUSD: 1000,
+		Screening: config.Screening{
+			Enabled:         true,
+			RPCURL:          rpc.RPCURL(),
+			TopHolders:      5,
+			MaxTopHolderPct: 50,
+			MinLPBurnPct:    90,
+			MinPairAgeMs:    int((10 * time.Minute).Milliseconds()),
+			Reject:          []string{CheckFreezeAuthority, CheckPairAge},
+			ScorePenalty:    0.5,
+		},
+	}
+	feed := NewFeed(ProviderDexScreener, nil, zerolog.Nop())
+	disc := NewDexScreenerDiscovery(zerolog.Nop(), feed, nil, config.DexScreener{BaseURL: server.URL}, discCfg)
+	disc.client = server.Client()
+
+	candidates, err := disc.discover(context.Background())
+	if err != nil {
+		t.Fatalf("discover returned error: %v", err)
+	}
+	var got []string
+	flags := make(map[string][]string)
+	for _, cand := range candidates {
+		for _, tok := range []screenedToken{safe, frozen, risky, young, unknown} {
+			if strings.HasSuffix(cand.symbol, tok.pool.String()) {
+				got = append(got, tok.name)
+				flags[tok.name] = cand.flags
+			}
+		}
+	}
+	// GHOST's mint cannot be read, so the rejecting freeze_authority check cannot pass.
+	if strings.Join(got, ",") != "SAFE,RISKY" {
+		t.Fatalf("unexpected screened universe %v (flags %v)", got, flags)
+	}
+	if len(flags["SAFE"]) != 0 {
+		t.Fatalf("safe token should pass cleanly, got %v", flags["SAFE"])
+	}
+	if len(flags["RISKY"]) != 2 || !strings.HasPrefix(flags["RISKY"][0], CheckMintAuthority) || !strings.HasPrefix(flags["RISKY"][1], CheckLPBurn) {
+		t.Fatalf("unexpected risky findings %v", flags["RISKY"])
+	}
+}
+
+func TestSolanaScreenerCachesUnverifiedPairs(t *testing.T) {
+	rpc := solanatest.NewServer()
+	defer rpc.Close()
+	// The pool exists but its base mint does not, until the RPC catches up.
+	tok := addRaydiumToken(t, rpc, "GHOST", nil, nil, 0, 1000, 10000)
+	mint := solana.NewWallet().PublicKey()
+	cand := ScreenCandidate{Symbol: "GHOST", Chain: "solana", PairAddress: tok.pool.String(), BaseMint: mint.String()}
+
+	penalize := NewSolanaScreener(zerolog.Nop(), config.Screening{RPCURL: rpc.RPCURL(), Reject: []string{CheckPairAge}})
+	if findings, err := penalize.Screen(context.Background(), cand); err != nil || len(findings) != 1 || findings[0].Check != CheckUnverified || findings[0].Reject {
+		t.Fatalf("expected a soft unverified finding without rejecting RPC checks, got %+v (%v)", findings, err)
+	}
+
+	screener := NewSolanaScreener(zerolog.Nop(), config.Screening{RPCURL: rpc.RPCURL(), Reject: []string{CheckMintAuthority}})
+	findings, err := screener.Screen(context.Background(), cand)
+	if err != nil || len(findings) != 1 || findings[0].Check != CheckUnverified || !findings[0].Reject {
+		t.Fatalf("expected an unverifiable rejecting check to reject, got %+v (%v)", findings, err)
+	}
+
+	// The failure is cached, so the RPC is not asked again until the retry interval passes.
+	rpc.SetAccount(mint, solana.TokenProgramID, solanatest.MintData(nil, nil, 1_000_000, 6))
+	if findings, _ := screener.Screen(context.Background(), cand); len(findings) != 1 || findings[0].Check != CheckUnverified {
+		t.Fatalf("expected the cached failure, got %+v", findings)
+	}
+	screener.now = func() time.Time { return time.Now().Add(2 * screenRetryAfter) }
+	if findings, _ := screener.Screen(context.Background(), cand); len(findings) != 0 {
+		t.Fatalf("expected the retried screen to pass, got %+v", findings)
+	}
+}
+
+func TestSolanaScreenerFlagsConcentratedHolders(t *testing.T) {
+	rpc := solanatest.NewServer()
+	defer rpc.Close()
+	tok := addRaydiumToken(t, rpc, "WHALE", nil, nil, 0, 1000, 10000)
+	rpc.SetLargestAccounts(tok.mint, solanatest.Holder{Address: solana.NewWallet().PublicKey(), Amount: 700_000})
+
+	screener := NewSolanaScreener(zerolog.Nop(), config.Screening{RPCURL: rpc.RPCURL(), MaxTopHolderPct: 50, Reject: []string{CheckTopHolders}})
+	cand := ScreenCandidate{Symbol: "WHALE", Chain: "solana", PairAddress: tok.pool.String(), BaseMint: tok.mint.String()}
+	findings, err := screener.Screen(context.Background(), cand)
+	if err != nil {
+		t.Fatalf("Screen returned error: %v", err)
+	}
+	if len(findings) != 1 || findings[0].Check != CheckTopHolders || !findings[0].Reject {
+		t.Fatalf("unexpected findings %+v", findings)
+	}
+
+	// Cached results survive the holder spreading out until the TTL lapses.
+	rpc.SetLargestAccounts(tok.mint, solanatest.Holder{Address: solana.NewWallet().PublicKey(), Amount: 10_000})
+	if findings, _ := screener.Screen(context.Background(), cand); len(findings) != 1 {
+		t.Fatalf("expected cached findings, got %+v", findings)
+	}
+	screener.now = func() time.Time { return time.Now().Add(time.Hour) }
+	if findings, _ := screener.Screen(context.Background(), cand); len(findings) != 0 {
+		t.Fatalf("expected refreshed findings to pass, got %+v", findings)
+	}
+
+	if findings, err := screener.Screen(context.Background(), ScreenCandidate{Chain: "ethereum", BaseMint: "0xabc"}); err != nil || findings != nil {
+		t.Fatalf("expected non-solana pairs to pass, got %+v (%v)", findings, err)
+	}
+}
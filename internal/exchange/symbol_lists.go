@@ -0,0 +1,291 @@
+package exchange
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"memebot-go/internal/instrument"
+)
+
+// Names of the two symbol lists.
+const (
+	ListAllow = "allow"
+	ListDeny  = "deny"
+)
+
+// ListEntry is one allow or deny entry keyed by pair address or token mint.
+type ListEntry struct {
+	Key     string    `json:"key"`
+	Chain   string    `json:"chain,omitempty"` // allow entries only; defaults to the discovery chain
+	Note    string    `json:"note,omitempty"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+// SymbolLists is a persistent allow/deny list consulted by discovery and feed symbol updates.
+// Allowed keys are force-included in the universe; denied keys are dropped once no position holds them.
+type SymbolLists struct {
+	path     string
+	mu       sync.RWMutex
+	allow    map[string]ListEntry
+	deny     map[string]ListEntry
+	onChange []func()
+}
+
+type symbolListsFile struct {
+	Allow []ListEntry `json:"allow"`
+	Deny  []ListEntry `json:"deny"`
+}
+
+// LoadSymbolLists reads lists from path (a missing file starts empty); an empty path keeps them in memory only.
+func LoadSymbolLists(path string) (*SymbolLists, error) {
+	l := &SymbolLists{path: path, allow: make(map[string]ListEntry), deny: make(map[string]ListEntry)}
+	if path == "" {
+		return l, nil
+	}
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read symbol lists: %w", err)
+	}
+	var file symbolListsFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("decode symbol lists: %w", err)
+	}
+	for _, entry := range file.Allow {
+		l.allow[entry.Key] = entry
+	}
+	for _, entry := range file.Deny {
+		l.deny[entry.Key] = entry
+	}
+	return l, nil
+}
+
+// OnChange registers a callback run after every successful Add or Remove.
+func (l *SymbolLists) OnChange(fn func()) {
+	l.mu.Lock()
+	l.onChange = append(l.onChange, fn)
+	l.mu.Unlock()
+}
+
+// Add inserts or replaces entry on list and persists; a key lives on at most one list.
+func (l *SymbolLists) Add(list string, entry ListEntry) (ListEntry, error) {
+	entry.Key = strings.TrimSpace(entry.Key)
+	entry.Chain = strings.ToLower(strings.TrimSpace(entry.Chain))
+	if entry.Key == "" {
+		return ListEntry{}, errors.New("list entry needs a key")
+	}
+	if entry.AddedAt.IsZero() {
+		entry.AddedAt = time.Now().UTC()
+	}
+	l.mu.Lock()
+	target, other, err := l.lists(list)
+	if err != nil {
+		l.mu.Unlock()
+		return ListEntry{}, err
+	}
+	prevTarget, hadTarget := target[entry.Key]
+	prevOther, hadOther := other[entry.Key]
+	target[entry.Key] = entry
+	delete(other, entry.Key)
+	err = l.saveLocked()
+	if err != nil {
+		// Keep memory in line with the file the caller was told was not updated.
+		delete(target, entry.Key)
+		if hadTarget {
+			target[entry.Key] = prevTarget
+		}
+		if hadOther {
+			other[entry.Key] = prevOther
+		}
+	}
+	callbacks := append([]func(){}, l.onChange...)
+	l.mu.Unlock()
+	if err != nil {
+		return ListEntry{}, err
+	}
+	for _, fn := range callbacks {
+		fn()
+	}
+	return entry, nil
+}
+
+// Remove deletes key from list and persists, reporting whether it was removed. A failed save leaves the
+// entry in place.
+func (l *SymbolLists) Remove(list, key string) (bool, error) {
+	l.mu.Lock()
+	target, _, err := l.lists(list)
+	if err != nil {
+		l.mu.Unlock()
+		return false, err
+	}
+	prev, ok := target[key]
+	if !ok {
+		l.mu.Unlock()
+		return false, nil
+	}
+	delete(target, key)
+	err = l.saveLocked()
+	if err != nil {
+		target[key] = prev
+	}
+	callbacks := append([]func(){}, l.onChange...)
+	l.mu.Unlock()
+	if err != nil {
+		return false, err
+	}
+	for _, fn := range callbacks {
+		fn()
+	}
+	return true, nil
+}
+
+// Entries returns list sorted by key.
+func (l *SymbolLists) Entries(list string) []ListEntry {
+	if l == nil {
+		return nil
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	target, _, err := l.lists(list)
+	if err != nil {
+		return nil
+	}
+	return sortedEntries(target)
+}
+
+// Denied reports whether any of keys (pair addresses or mints) is on the deny list.
+func (l *SymbolLists) Denied(keys ...string) bool {
+	if l == nil {
+		return false
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for _, key := range keys {
+		if _, ok := l.deny[key]; ok && key != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// DeniesSymbol reports whether a feed symbol is denied by its pair address or, when reg knows the pair,
+// by its base token mint.
+func (l *SymbolLists) DeniesSymbol(reg *instrument.Registry, symbol string) bool {
+	return l.Denied(symbolKeys(reg, symbol)...)
+}
+
+// DenyFilter returns a feed symbol filter that drops denied pairs unless holdings still hold them,
+// so open positions keep their marks.
+func DenyFilter(lists *SymbolLists, reg *instrument.Registry, holdings Holdings) func(symbol string) bool {
+	return func(symbol string) bool {
+		if !lists.DeniesSymbol(reg, symbol) {
+			return true
+		}
+		address := symbolAddress(symbol)
+		for _, held := range holdings.HeldSymbols() {
+			if held == symbol {
+				return true
+			}
+			if inst, ok := reg.Lookup(held); ok && inst.PairAddress == address {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// symbolAddress is the pair address of an ALIAS@chain/address feed symbol, or the symbol itself.
+func symbolAddress(symbol string) string {
+	if i := strings.LastIndex(symbol, "/"); i >= 0 {
+		return symbol[i+1:]
+	}
+	return symbol
+}
+
+// splitFeedSymbol returns the chain and pair address of an ALIAS@chain/address, chain/address, or bare
+// address symbol; chain is empty when the symbol does not name one.
+func splitFeedSymbol(symbol string) (chain, address string) {
+	target := symbol
+	if _, after, ok := strings.Cut(symbol, "@"); ok {
+		target = after
+	}
+	if chain, address, ok := strings.Cut(target, "/"); ok {
+		return strings.ToLower(strings.TrimSpace(chain)), strings.TrimSpace(address)
+	}
+	return "", strings.TrimSpace(target)
+}
+
+// symbolInstrument resolves a feed symbol, or an instrument symbol, to its registered instrument.
+func symbolInstrument(reg *instrument.Registry, symbol string) (instrument.Instrument, bool) {
+	if reg == nil {
+		return instrument.Instrument{}, false
+	}
+	if chain, address := splitFeedSymbol(symbol); chain != "" {
+		if inst, ok := reg.ByPair(chain, address); ok {
+			return inst, true
+		}
+	}
+	return reg.Lookup(symbol)
+}
+
+// symbolKeys lists the deny-list keys of a feed symbol: its pair address and, when known, its base mint.
+func symbolKeys(reg *instrument.Registry, symbol string) []string {
+	keys := []string{symbolAddress(symbol)}
+	if inst, ok := symbolInstrument(reg, symbol); ok && inst.BaseMint != "" {
+		keys = append(keys, inst.BaseMint)
+	}
+	return keys
+}
+
+func (l *SymbolLists) lists(list string) (target, other map[string]ListEntry, err error) {
+	switch list {
+	case ListAllow:
+		return l.allow, l.deny, nil
+	case ListDeny:
+		return l.deny, l.allow, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown symbol list %q", list)
+	}
+}
+
+func (l *SymbolLists) saveLocked() error {
+	if l.path == "" {
+		return nil
+	}
+	raw, err := json.MarshalIndent(symbolListsFile{Allow: sortedEntries(l.allow), Deny: sortedEntries(l.deny)}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode symbol lists: %w", err)
+	}
+	if dir := filepath.Dir(l.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create symbol lists dir: %w", err)
+		}
+	}
+	// Write then rename so a crash never leaves a truncated file behind.
+	tmp := l.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return fmt.Errorf("write symbol lists: %w", err)
+	}
+	if err := os.Rename(tmp, l.path); err != nil {
+		return fmt.Errorf("replace symbol lists: %w", err)
+	}
+	return nil
+}
+
+func sortedEntries(set map[string]ListEntry) []ListEntry {
+	out := make([]ListEntry, 0, len(set))
+	for _, entry := range set {
+		out = append(out, entry)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out
+}
@@ -0,0 +1,131 @@
+package exchange
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"memebot-go/internal/instrument"
+)
+
+func TestSymbolListsPersistAndMoveKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lists", "symbols.json")
+	lists, err := LoadSymbolLists(path)
+	if err != nil {
+		t.Fatalf("LoadSymbolLists returned error: %v", err)
+	}
+	changes := 0
+	lists.OnChange(func() { changes++ })
+
+	if _, err := lists.Add(ListDeny, ListEntry{Key: " MINT1 ", Note: "rug"}); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	if _, err := lists.Add(ListAllow, ListEntry{Key: "PAIR1", Chain: "Solana"}); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	if _, err := lists.Add("grey", ListEntry{Key: "X"}); err == nil {
+		t.Fatalf("expected unknown list to fail")
+	}
+	if !lists.Denied("other", "MINT1") || !lists.DeniesSymbol(nil, "BONK@solana/MINT1") || lists.DeniesSymbol(nil, "BONK@solana/PAIR1") {
+		t.Fatalf("unexpected deny checks")
+	}
+
+	// Allowing a denied key moves it across.
+	if _, err := lists.Add(ListAllow, ListEntry{Key: "MINT1"}); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	if lists.Denied("MINT1") {
+		t.Fatalf("key should have left the deny list")
+	}
+	if ok, err := lists.Remove(ListAllow, "PAIR1"); err != nil || !ok {
+		t.Fatalf("Remove = %v, %v", ok, err)
+	}
+	if ok, _ := lists.Remove(ListAllow, "PAIR1"); ok {
+		t.Fatalf("second Remove should report absence")
+	}
+	if changes != 4 {
+		t.Fatalf("expected 4 change callbacks, got %d", changes)
+	}
+
+	reloaded, err := LoadSymbolLists(path)
+	if err != nil {
+		t.Fatalf("reload returned error: %v", err)
+	}
+	allow := reloaded.Entries(ListAllow)
+	if len(allow) != 1 || allow[0].Key != "MINT1" || allow[0].AddedAt.IsZero() || len(reloaded.Entries(ListDeny)) != 0 {
+		t.Fatalf("unexpected reloaded lists %+v / %+v", allow, reloaded.Entries(ListDeny))
+	}
+}
+
+func TestDenyFilterKeepsHeldPairs(t *testing.T) {
+	lists, _ := LoadSymbolLists("")
+	if _, err := lists.Add(ListDeny, ListEntry{Key: "PAIR1"}); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	reg := instrument.NewRegistry(zerolog.Nop())
+	inst := reg.RegisterPair(instrument.Instrument{Chain: "solana", PairAddress: "PAIR1"}, "WIFSOL")
+	held := &staticHoldings{}
+	keep := DenyFilter(lists, reg, held)
+
+	if !keep("BONK@solana/PAIR2") || keep("WIFSOL@solana/PAIR1") {
+		t.Fatalf("expected only the denied pair to be filtered")
+	}
+	// Positions carry the instrument symbol, not the subscription symbol.
+	*held = []string{inst.Symbol}
+	if !keep("WIFSOL@solana/PAIR1") {
+		t.Fatalf("held pair should stay polled while denied")
+	}
+}
+
+func TestDenyFilterMatchesBaseMint(t *testing.T) {
+	lists, _ := LoadSymbolLists("")
+	if _, err := lists.Add(ListDeny, ListEntry{Key: "MINT1"}); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	reg := instrument.NewRegistry(zerolog.Nop())
+	reg.RegisterPair(instrument.Instrument{Chain: "solana", PairAddress: "PAIR1", BaseMint: "MINT1"}, "WIFSOL")
+	reg.RegisterPair(instrument.Instrument{Chain: "solana", PairAddress: "PAIR2", BaseMint: "MINT2"}, "BONKSOL")
+	keep := DenyFilter(lists, reg, &staticHoldings{})
+
+	if keep("WIFSOL@solana/PAIR1") || !keep("BONK@solana/PAIR2") {
+		t.Fatalf("expected the pair of the denied mint to be filtered")
+	}
+	if lists.DeniesSymbol(nil, "WIFSOL@solana/PAIR1") {
+		t.Fatalf("without a registry only the pair address can match")
+	}
+}
+
+func TestSymbolListsRestoreOnSaveFailure(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "symbols.json")
+	lists, err := LoadSymbolLists(path)
+	if err != nil {
+		t.Fatalf("LoadSymbolLists returned error: %v", err)
+	}
+	if _, err := lists.Add(ListDeny, ListEntry{Key: "MINT1", Note: "rug"}); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	// Swap the file for a directory so every later save fails.
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if err := os.Mkdir(path, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	if _, err := lists.Add(ListAllow, ListEntry{Key: "MINT1"}); err == nil {
+		t.Fatalf("expected Add to fail")
+	}
+	if _, err := lists.Add(ListDeny, ListEntry{Key: "MINT2"}); err == nil {
+		t.Fatalf("expected Add to fail")
+	}
+	if ok, err := lists.Remove(ListDeny, "MINT1"); err == nil || ok {
+		t.Fatalf("Remove = %v, %v; expected failure", ok, err)
+	}
+	deny := lists.Entries(ListDeny)
+	if len(deny) != 1 || deny[0].Key != "MINT1" || deny[0].Note != "rug" || len(lists.Entries(ListAllow)) != 0 {
+		t.Fatalf("failed writes changed the lists: %+v / %+v", deny, lists.Entries(ListAllow))
+	}
+}
@@ -0,0 +1,111 @@
+package exchange
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"memebot-go/internal/metrics"
+)
+
+// TickAge reports how long ago a symbol last delivered a tick.
+type TickAge struct {
+	Provider string
+	Symbol   string
+	LastTick time.Time
+	Age      time.Duration
+}
+
+// lastTick is the receipt time of a symbol's latest tick and the provider label its gauge was set under.
+type lastTick struct {
+	at       time.Time
+	provider string
+}
+
+// touch stamps the receipt time of a symbol's latest tick and mirrors it into the last-tick gauge.
+func (f *Feed) touch(provider, symbol string, at time.Time) {
+	f.mu.Lock()
+	f.lastTicks[symbol] = lastTick{at: at, provider: provider}
+	f.mu.Unlock()
+	metrics.FeedLastTickTimestamp.WithLabelValues(provider, symbol).Set(float64(at.UnixNano()) / 1e9)
+}
+
+// pruneLastTicksLocked forgets tick ages, and deletes their gauges, for symbols the feed no longer tracks.
+// Ticks carry instrument symbols, so tracked Dexscreener feed symbols are mapped through the registry first.
+func (f *Feed) pruneLastTicksLocked() {
+	if len(f.lastTicks) == 0 {
+		return
+	}
+	tracked := make(map[string]struct{}, 2*len(f.symbols))
+	for _, sym := range f.symbols {
+		tracked[strings.ToUpper(sym)] = struct{}{}
+		target := sym
+		if alias, after, hasAlias := strings.Cut(sym, "@"); hasAlias {
+			tracked[strings.ToUpper(alias)] = struct{}{}
+			target = after
+		}
+		chain, address, ok := strings.Cut(target, "/")
+		if !ok {
+			chain, address = f.dexscreenerDefaultChain, target
+		}
+		if inst, ok := f.instruments.ByPair(strings.ToLower(chain), address); ok {
+			tracked[strings.ToUpper(inst.Symbol)] = struct{}{}
+		}
+	}
+	for sym, last := range f.lastTicks {
+		if _, ok := tracked[strings.ToUpper(sym)]; ok {
+			continue
+		}
+		delete(f.lastTicks, sym)
+		metrics.FeedLastTickTimestamp.DeleteLabelValues(last.provider, sym)
+	}
+}
+
+// LastTick returns when the feed last emitted a tick for symbol (as emitted, e.g. a Dexscreener alias).
+func (f *Feed) LastTick(symbol string) (time.Time, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	last, ok := f.lastTicks[symbol]
+	return last.at, ok
+}
+
+// TickAges lists every symbol that has ticked with its age relative to now, sorted by symbol.
+func (f *Feed) TickAges(now time.Time) []TickAge {
+	f.mu.RLock()
+	out := make([]TickAge, 0, len(f.lastTicks))
+	for sym, last := range f.lastTicks {
+		out = append(out, TickAge{Provider: last.provider, Symbol: sym, LastTick: last.at, Age: now.Sub(last.at)})
+	}
+	f.mu.RUnlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].Symbol < out[j].Symbol })
+	return out
+}
+
+// LastTick returns the most recent tick time for symbol across every child feed.
+func (c *Composite) LastTick(symbol string) (time.Time, bool) {
+	var (
+		latest time.Time
+		found  bool
+	)
+	for _, feed := range c.feeds {
+		if at, ok := feed.LastTick(symbol); ok && at.After(latest) {
+			latest, found = at, true
+		}
+	}
+	return latest, found
+}
+
+// TickAges merges the per-symbol ages of every child feed, sorted by symbol then provider.
+func (c *Composite) TickAges(now time.Time) []TickAge {
+	var out []TickAge
+	for _, feed := range c.feeds {
+		out = append(out, feed.TickAges(now)...)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Symbol != out[j].Symbol {
+			return out[i].Symbol < out[j].Symbol
+		}
+		return out[i].Provider < out[j].Provider
+	})
+	return out
+}
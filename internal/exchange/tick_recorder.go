@@ -0,0 +1,114 @@
+package exchange
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"memebot-go/internal/metrics"
+	"memebot-go/internal/signal"
+)
+
+// TickRecorder captures ticks emitted by a feed for later replay.
+type TickRecorder interface {
+	Record(signal.Tick)
+}
+
+// JSONLTickRecorder appends ticks as JSON lines.
+type JSONLTickRecorder struct {
+	log    zerolog.Logger
+	mu     sync.Mutex
+	file   *os.File
+	enc    *json.Encoder
+	failed bool // a write failed; later failures are counted but not logged again
+}
+
+// NewJSONLTickRecorder creates/opens the target file and returns a recorder.
+func NewJSONLTickRecorder(log zerolog.Logger, path string) (*JSONLTickRecorder, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONLTickRecorder{
+		log:  log,
+		file: file,
+		enc:  json.NewEncoder(file),
+	}, nil
+}
+
+// Record writes a single tick to the underlying JSONL file. Write failures (e.g. a full disk) are
+// counted in recorder_write_errors_total and the first one is logged, since the capture is then incomplete.
+func (r *JSONLTickRecorder) Record(tick signal.Tick) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return
+	}
+	if err := r.enc.Encode(tick); err != nil {
+		metrics.RecorderWriteErrors.WithLabelValues("ticks").Inc()
+		if !r.failed {
+			r.failed = true
+			r.log.Error().Err(err).Str("path", r.file.Name()).Msg("tick recording failed; capture is incomplete")
+		}
+	}
+}
+
+// Close flushes and closes the file handle.
+func (r *JSONLTickRecorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// TickReader streams ticks previously written by a JSONLTickRecorder.
+type TickReader struct {
+	closer io.Closer
+	dec    *json.Decoder
+}
+
+// OpenTickFile opens a recorded JSONL tick file for sequential reading.
+func OpenTickFile(path string) (*TickReader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	reader := NewTickReader(file)
+	reader.closer = file
+	return reader, nil
+}
+
+// NewTickReader decodes JSONL ticks from an arbitrary reader.
+func NewTickReader(r io.Reader) *TickReader {
+	return &TickReader{dec: json.NewDecoder(r)}
+}
+
+// Next returns the next recorded tick or io.EOF once the stream is exhausted.
+func (r *TickReader) Next() (signal.Tick, error) {
+	var tick signal.Tick
+	if err := r.dec.Decode(&tick); err != nil {
+		return signal.Tick{}, err
+	}
+	return tick, nil
+}
+
+// Close releases the underlying file when opened via OpenTickFile.
+func (r *TickReader) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	err := r.closer.Close()
+	r.closer = nil
+	return err
+}
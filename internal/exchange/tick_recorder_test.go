@@ -0,0 +1,86 @@
+package exchange
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+
+	"memebot-go/internal/metrics"
+	"memebot-go/internal/signal"
+)
+
+func TestJSONLTickRecorderRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ticks.jsonl")
+	recorder, err := NewJSONLTickRecorder(zerolog.Nop(), path)
+	if err != nil {
+		t.Fatalf("NewJSONLTickRecorder error: %v", err)
+	}
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	recorded := []signal.Tick{
+		{Symbol: "WIFSOL", Price: 2.5, Size: 10, Side: 1, Ts: start},
+		{Symbol: "WIFSOL", Price: 2.4, Size: 3, Side: -1, Ts: start.Add(time.Second)},
+	}
+	for _, tk := range recorded {
+		recorder.Record(tk)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close error: %v", err)
+	}
+
+	reader, err := OpenTickFile(path)
+	if err != nil {
+		t.Fatalf("OpenTickFile error: %v", err)
+	}
+	defer reader.Close()
+	for i, want := range recorded {
+		got, err := reader.Next()
+		if err != nil {
+			t.Fatalf("Next(%d) error: %v", i, err)
+		}
+		if got.Symbol != want.Symbol || got.Price != want.Price || got.Size != want.Size || got.Side != want.Side || !got.Ts.Equal(want.Ts) {
+			t.Fatalf("tick %d mismatch: got %+v want %+v", i, got, want)
+		}
+	}
+	if _, err := reader.Next(); !errors.Is(err, io.EOF) {
+		t.Fatalf("expected io.EOF after last tick, got %v", err)
+	}
+}
+
+func TestJSONLTickRecorderCountsWriteErrors(t *testing.T) {
+	recorder, err := NewJSONLTickRecorder(zerolog.Nop(), filepath.Join(t.TempDir(), "ticks.jsonl"))
+	if err != nil {
+		t.Fatalf("NewJSONLTickRecorder error: %v", err)
+	}
+	defer recorder.Close()
+	// Closing the file underneath the recorder makes every write fail, as a full disk would.
+	if err := recorder.file.Close(); err != nil {
+		t.Fatalf("close file: %v", err)
+	}
+	before := testutil.ToFloat64(metrics.RecorderWriteErrors.WithLabelValues("ticks"))
+	recorder.Record(signal.Tick{Symbol: "WIFSOL", Price: 1, Ts: time.Now()})
+	recorder.Record(signal.Tick{Symbol: "WIFSOL", Price: 1, Ts: time.Now()})
+	if got := testutil.ToFloat64(metrics.RecorderWriteErrors.WithLabelValues("ticks")) - before; got != 2 {
+		t.Fatalf("expected 2 counted write errors, got %v", got)
+	}
+	if !recorder.failed {
+		t.Fatalf("expected recorder to note the failure")
+	}
+}
+
+func TestReplayDelay(t *testing.T) {
+	base := time.Unix(0, 0)
+	if got := replayDelay(base, base.Add(10*time.Second), 10); got != time.Second {
+		t.Fatalf("expected 1s at 10x, got %v", got)
+	}
+	if got := replayDelay(base, base.Add(10*time.Second), 0); got != 0 {
+		t.Fatalf("expected no delay when speed disabled, got %v", got)
+	}
+	if got := replayDelay(time.Time{}, base, 1); got != 0 {
+		t.Fatalf("expected no delay for first tick, got %v", got)
+	}
+}
@@ -0,0 +1,163 @@
+package exchange
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"memebot-go/internal/metrics"
+)
+
+// Universe change actions.
+const (
+	UniverseAdded   = "added"
+	UniverseRemoved = "removed"
+)
+
+// Reasons a symbol left the universe; additions carry the symbol's origin instead.
+const (
+	RemovedDropped          = "dropped"           // fell out of the ranked discovered set
+	RemovedDenied           = "denied"            // put on the deny list
+	RemovedUnpinned         = "unpinned"          // taken off the allow list
+	RemovedReleased         = "released"          // held position closed after it left the discovered set
+	RemovedResidencyElapsed = "residency_elapsed" // min_residency ran out after it left the discovered set
+)
+
+// UniverseChange is one symbol entering or leaving the polled universe, with its discovery metrics at the time.
+// Metrics are zero for symbols discovery never ranked (manual and pinned entries).
+type UniverseChange struct {
+	Time         time.Time `json:"time"`
+	Action       string    `json:"action"`
+	Symbol       string    `json:"symbol"`
+	Reason       string    `json:"reason"`
+	Score        float64   `json:"score"`
+	LiquidityUSD float64   `json:"liquidity_usd"`
+	VolumeUSD    float64   `json:"volume_usd"`
+	Change24     float64   `json:"change_h24"`
+	Flags        []string  `json:"flags,omitempty"`
+}
+
+// UniverseHistory records universe changes emitted by discovery.
+type UniverseHistory interface {
+	Record(UniverseChange)
+}
+
+// UniverseQuery filters recorded changes; zero fields match everything.
+type UniverseQuery struct {
+	Symbol string // full symbol or pair address
+	Action string
+	Since  time.Time
+	Until  time.Time
+	Limit  int // keep only the most recent matches
+}
+
+func (q UniverseQuery) match(c UniverseChange) bool {
+	switch {
+	case q.Symbol != "" && c.Symbol != q.Symbol && symbolAddress(c.Symbol) != q.Symbol:
+		return false
+	case q.Action != "" && c.Action != q.Action:
+		return false
+	case !q.Since.IsZero() && c.Time.Before(q.Since):
+		return false
+	case !q.Until.IsZero() && c.Time.After(q.Until):
+		return false
+	}
+	return true
+}
+
+// JSONLUniverseHistory appends universe changes as JSON lines and answers queries by scanning the file.
+type JSONLUniverseHistory struct {
+	log    zerolog.Logger
+	path   string
+	mu     sync.Mutex
+	file   *os.File
+	enc    *json.Encoder
+	failed bool // a write has failed; later failures are only counted
+}
+
+// NewJSONLUniverseHistory creates/opens the target file and returns a history log.
+func NewJSONLUniverseHistory(log zerolog.Logger, path string) (*JSONLUniverseHistory, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONLUniverseHistory{log: log, path: path, file: file, enc: json.NewEncoder(file)}, nil
+}
+
+// Record writes a single change to the underlying JSONL file. Failures are counted in
+// recorder_write_errors_total{stream="universe_history"} and the first one is logged.
+func (h *JSONLUniverseHistory) Record(change UniverseChange) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.file == nil {
+		return
+	}
+	if err := h.enc.Encode(change); err != nil {
+		metrics.RecorderWriteErrors.WithLabelValues("universe_history").Inc()
+		if !h.failed {
+			h.failed = true
+			h.log.Error().Err(err).Str("path", h.path).Msg("universe history write failed; history is incomplete")
+		}
+	}
+}
+
+// Query returns the recorded changes matching q in recording order. Lines that do not decode, such as
+// one cut short by a crash, are skipped and logged rather than failing every query.
+func (h *JSONLUniverseHistory) Query(q UniverseQuery) ([]UniverseChange, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	file, err := os.Open(h.path)
+	if err != nil {
+		return nil, fmt.Errorf("open universe history: %w", err)
+	}
+	defer file.Close()
+	var out []UniverseChange
+	var skipped int
+	var firstErr error
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		var change UniverseChange
+		if err := json.Unmarshal(scanner.Bytes(), &change); err != nil {
+			if skipped == 0 {
+				firstErr = err
+			}
+			skipped++
+			continue
+		}
+		if q.match(change) {
+			out = append(out, change)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read universe history: %w", err)
+	}
+	if skipped > 0 {
+		h.log.Warn().Err(firstErr).Str("path", h.path).Int("skipped", skipped).Msg("skipped undecodable universe history lines")
+	}
+	if q.Limit > 0 && len(out) > q.Limit {
+		out = out[len(out)-q.Limit:]
+	}
+	return out, nil
+}
+
+// Close flushes and closes the file handle.
+func (h *JSONLUniverseHistory) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.file == nil {
+		return nil
+	}
+	err := h.file.Close()
+	h.file = nil
+	return err
+}
@@ -0,0 +1,104 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"memebot-go/internal/config"
+)
+
+func TestDiscoveryRecordsUniverseHistory(t *testing.T) {
+	var served atomic.Value
+	served.Store([]string{"AAA", "BBB"})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var pairs []string
+		for i, address := range served.Load().([]string) {
+			pairs = append(pairs, fmt.Sprintf(`{"chainId": "solana", "pairAddress": %q, "baseToken": {"symbol": %q},
+				"quoteToken": {"symbol": "SOL"}, "volume": {"h24": 5000}, "liquidity": {"usd": %d}, "priceChange": {"h24": 12.5}}`,
+				address, address, 20000-i*1000))
+		}
+		_, _ = fmt.Fprintf(w, `{"pairs": [%s]}`, strings.Join(pairs, ","))
+	}))
+	defer server.Close()
+
+	history, err := NewJSONLUniverseHistory(zerolog.Nop(), filepath.Join(t.TempDir(), "universe.jsonl"))
+	if err != nil {
+		t.Fatalf("NewJSONLUniverseHistory returned error: %v", err)
+	}
+	defer history.Close()
+
+	feed := NewFeed(ProviderDexScreener, nil, zerolog.Nop())
+	discCfg := config.Discovery{Enabled: true, Keywords: []string{"any"}, Chains: []string{"solana"}, MaxPairs: 5}
+	disc := NewDexScreenerDiscovery(zerolog.Nop(), feed, []string{"MAN@solana/MANUAL"}, config.DexScreener{BaseURL: server.URL}, discCfg)
+	disc.client = server.Client()
+	disc.SetHistory(history)
+	clock := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	disc.now = func() time.Time { return clock }
+
+	if err := disc.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+	served.Store([]string{"AAA"})
+	clock = clock.Add(time.Minute)
+	if err := disc.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+
+	all, err := history.Query(UniverseQuery{})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	var got []string
+	for _, change := range all {
+		got = append(got, change.Action+":"+symbolAddress(change.Symbol)+":"+change.Reason)
+	}
+	want := "added:AAA:discovered,added:BBB:discovered,added:MANUAL:manual,removed:BBB:dropped"
+	if strings.Join(got, ",") != want {
+		t.Fatalf("history %v, want %s", got, want)
+	}
+
+	removed, err := history.Query(UniverseQuery{Symbol: "BBB", Action: UniverseRemoved, Since: clock})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if len(removed) != 1 || removed[0].LiquidityUSD != 19000 || removed[0].VolumeUSD != 5000 || removed[0].Change24 != 12.5 ||
+		removed[0].Score == 0 || !removed[0].Time.Equal(clock) {
+		t.Fatalf("unexpected removal record %+v", removed)
+	}
+	if last, _ := history.Query(UniverseQuery{Action: UniverseAdded, Limit: 1}); len(last) != 1 || symbolAddress(last[0].Symbol) != "MANUAL" {
+		t.Fatalf("expected the most recent addition, got %+v", last)
+	}
+}
+
+func TestUniverseHistorySkipsCorruptLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "universe.jsonl")
+	history, err := NewJSONLUniverseHistory(zerolog.Nop(), path)
+	if err != nil {
+		t.Fatalf("NewJSONLUniverseHistory returned error: %v", err)
+	}
+	defer history.Close()
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	history.Record(UniverseChange{Time: at, Action: UniverseAdded, Symbol: "A@solana/AAA", Reason: OriginDiscovered})
+	// A line cut short by a crash mid-write.
+	if _, err := history.file.WriteString(`{"time":"2024-05-01T12:01:00Z","action":"rem` + "\n"); err != nil {
+		t.Fatalf("write corrupt line: %v", err)
+	}
+	history.Record(UniverseChange{Time: at.Add(2 * time.Minute), Action: UniverseRemoved, Symbol: "A@solana/AAA", Reason: RemovedDropped})
+
+	changes, err := history.Query(UniverseQuery{})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if len(changes) != 2 || changes[0].Action != UniverseAdded || changes[1].Action != UniverseRemoved {
+		t.Fatalf("expected the intact records around the corrupt line, got %+v", changes)
+	}
+}
@@ -0,0 +1,274 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"memebot-go/internal/cex/binance"
+	"memebot-go/internal/metrics"
+)
+
+// BinanceVenueName identifies the Binance spot venue.
+const BinanceVenueName = "binance"
+
+var _ Venue = (*BinanceVenue)(nil)
+
+// BinanceVenue places spot orders through the signed Binance REST API.
+type BinanceVenue struct {
+	log    zerolog.Logger
+	client *binance.Client
+	prefix string
+
+	mu       sync.Mutex
+	orderSeq uint64
+	rules    map[string]binance.SymbolRules
+	symbols  map[string]string // client order id -> symbol
+}
+
+// NewBinanceVenue wraps a configured client; symbol filters are fetched lazily per symbol.
+func NewBinanceVenue(log zerolog.Logger, client *binance.Client) *BinanceVenue {
+	return &BinanceVenue{
+		log:     log,
+		client:  client,
+		prefix:  fmt.Sprintf("memebot-%d", time.Now().Unix()),
+		rules:   make(map[string]binance.SymbolRules),
+		symbols: make(map[string]string),
+	}
+}
+
+// Name identifies the venue.
+func (v *BinanceVenue) Name() string { return BinanceVenueName }
+
+// Submit rounds the order to the symbol filters, places it, and returns any fills reported inline.
+func (v *BinanceVenue) Submit(ctx context.Context, order Order) ([]Fill, error) {
+	if order.Side != Buy && order.Side != Sell {
+		return nil, fmt.Errorf("unknown order side %q", order.Side)
+	}
+	rules, err := v.symbolRules(ctx, order.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	qty := rules.RoundQty(order.Qty)
+	req := binance.OrderRequest{
+		Symbol:   order.Symbol,
+		Side:     string(order.Side),
+		Type:     binance.OrderTypeMarket,
+		Quantity: rules.FormatQty(qty),
+	}
+	checkPrice := order.Price
+	if order.Type == Limit {
+		if order.Price <= 0 {
+			return nil, fmt.Errorf("limit order for %s requires a price", order.Symbol)
+		}
+		checkPrice = rules.RoundPrice(order.Price)
+		req.Type = binance.OrderTypeLimit
+		req.Price = rules.FormatPrice(checkPrice)
+	}
+	if err := rules.Validate(qty, checkPrice); err != nil {
+		return nil, err
+	}
+
+	v.mu.Lock()
+	if order.ID == "" {
+		v.orderSeq++
+		order.ID = fmt.Sprintf("%s-%d", v.prefix, v.orderSeq)
+	}
+	v.symbols[order.ID] = order.Symbol
+	v.mu.Unlock()
+	req.ClientOrderID = order.ID
+
+	metrics.OrdersTotal.WithLabelValues(order.Symbol, string(order.Side)).Inc()
+	started := time.Now()
+	placed, err := v.client.PlaceOrder(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("binance place order: %w", err)
+	}
+	latency := time.Since(started)
+	ts := time.UnixMilli(placed.TransactTime)
+	if placed.TransactTime == 0 {
+		ts = time.Now()
+	}
+
+	fills := make([]Fill, 0, len(placed.Fills))
+	for _, trade := range placed.Fills {
+		price, _ := strconv.ParseFloat(trade.Price, 64)
+		tradeQty, _ := strconv.ParseFloat(trade.Qty, 64)
+		if tradeQty <= 0 {
+			continue
+		}
+		fill := Fill{
+			OrderID: order.ID,
+			Symbol:  order.Symbol,
+			Side:    order.Side,
+			Qty:     tradeQty,
+			Price:   price,
+			Latency: latency,
+			Ts:      ts,
+		}
+		if order.Price > 0 {
+			fill.Slippage = price - order.Price
+		}
+		v.applyCommission(&fill, rules, trade)
+		fills = append(fills, fill)
+	}
+	v.log.Info().
+		Str("sym", order.Symbol).
+		Str("side", string(order.Side)).
+		Str("type", req.Type).
+		Str("client_order_id", order.ID).
+		Int64("order_id", placed.OrderID).
+		Str("status", placed.Status).
+		Str("qty", req.Quantity).
+		Str("executed_qty", placed.ExecutedQty).
+		Dur("latency", latency).
+		Msg("binance order placed")
+	return fills, nil
+}
+
+// applyCommission books a trade's commission so the account matches the wallet. A fee in the base asset changes
+// the tokens held: buys receive commission fewer tokens and sells give up commission more. Base and quote fees are
+// both recorded as Fee in quote terms so cash still moves by the traded notional. Fees in a third asset (e.g. BNB)
+// come from a separate balance and are only logged.
+func (v *BinanceVenue) applyCommission(fill *Fill, rules binance.SymbolRules, trade binance.OrderFill) {
+	commission, _ := strconv.ParseFloat(trade.Commission, 64)
+	if commission <= 0 {
+		return
+	}
+	switch trade.CommissionAsset {
+	case rules.BaseAsset:
+		if fill.Side == Buy {
+			fill.Qty -= commission
+		} else {
+			fill.Qty += commission
+		}
+		fill.Fee = commission * fill.Price
+	case rules.QuoteAsset:
+		fill.Fee = commission
+	default:
+		v.log.Debug().
+			Str("sym", fill.Symbol).
+			Str("asset", trade.CommissionAsset).
+			Float64("commission", commission).
+			Msg("binance commission paid outside the traded pair")
+	}
+}
+
+// Cancel cancels a resting order placed through this venue.
+func (v *BinanceVenue) Cancel(ctx context.Context, orderID string) error {
+	symbol, ok := v.lookup(orderID)
+	if !ok {
+		return ErrUnknownOrder
+	}
+	if _, err := v.client.CancelOrder(ctx, symbol, orderID); err != nil {
+		var apiErr *binance.APIError
+		if errors.As(err, &apiErr) && apiErr.Code == binance.ErrCodeUnknownOrder {
+			return ErrOrderClosed
+		}
+		return fmt.Errorf("binance cancel order: %w", err)
+	}
+	return nil
+}
+
+// QueryOrder fetches the exchange's view of an order placed through this venue.
+func (v *BinanceVenue) QueryOrder(ctx context.Context, orderID string) (OrderStatus, error) {
+	symbol, ok := v.lookup(orderID)
+	if !ok {
+		return OrderStatus{}, ErrUnknownOrder
+	}
+	order, err := v.client.QueryOrder(ctx, symbol, orderID)
+	if err != nil {
+		return OrderStatus{}, fmt.Errorf("binance query order: %w", err)
+	}
+	qty, _ := strconv.ParseFloat(order.OrigQty, 64)
+	executed, _ := strconv.ParseFloat(order.ExecutedQty, 64)
+	quote, _ := strconv.ParseFloat(order.CummulativeQuoteQty, 64)
+	status := OrderStatus{
+		ID:        orderID,
+		Symbol:    order.Symbol,
+		Side:      Side(order.Side),
+		State:     binanceOrderState(order.Status),
+		Qty:       qty,
+		FilledQty: executed,
+		UpdatedAt: time.UnixMilli(order.UpdateTime),
+	}
+	if executed > 0 {
+		status.AvgPrice = quote / executed
+	}
+	return status, nil
+}
+
+// Balances lists non-zero spot balances.
+func (v *BinanceVenue) Balances(ctx context.Context) ([]Balance, error) {
+	account, err := v.client.Account(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("binance account: %w", err)
+	}
+	out := make([]Balance, 0, len(account.Balances))
+	for _, entry := range account.Balances {
+		free, _ := strconv.ParseFloat(entry.Free, 64)
+		locked, _ := strconv.ParseFloat(entry.Locked, 64)
+		if free == 0 && locked == 0 {
+			continue
+		}
+		out = append(out, Balance{Asset: entry.Asset, Free: free, Locked: locked})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Asset < out[j].Asset })
+	return out, nil
+}
+
+// symbolRules returns cached exchange filters, loading them on first use.
+func (v *BinanceVenue) symbolRules(ctx context.Context, symbol string) (binance.SymbolRules, error) {
+	v.mu.Lock()
+	rules, ok := v.rules[symbol]
+	v.mu.Unlock()
+	if ok {
+		return rules, nil
+	}
+	loaded, err := v.client.ExchangeInfo(ctx, symbol)
+	if err != nil {
+		return binance.SymbolRules{}, fmt.Errorf("binance exchange info %s: %w", symbol, err)
+	}
+	rules, ok = loaded[symbol]
+	if !ok {
+		return binance.SymbolRules{}, fmt.Errorf("binance does not list %s", symbol)
+	}
+	if rules.Status != "" && rules.Status != "TRADING" {
+		return binance.SymbolRules{}, fmt.Errorf("binance symbol %s is %s", symbol, strings.ToLower(rules.Status))
+	}
+	v.mu.Lock()
+	v.rules[symbol] = rules
+	v.mu.Unlock()
+	return rules, nil
+}
+
+func (v *BinanceVenue) lookup(orderID string) (string, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	symbol, ok := v.symbols[orderID]
+	return symbol, ok
+}
+
+// binanceOrderState folds Binance order statuses into the venue-agnostic lifecycle.
+func binanceOrderState(status string) OrderState {
+	switch status {
+	case "NEW", "PENDING_NEW", "PENDING_CANCEL":
+		return OrderNew
+	case "PARTIALLY_FILLED":
+		return OrderPartiallyFilled
+	case "FILLED":
+		return OrderFilled
+	case "CANCELED", "EXPIRED", "EXPIRED_IN_MATCH":
+		return OrderCanceled
+	default:
+		return OrderRejected
+	}
+}
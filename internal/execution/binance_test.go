@@ -0,0 +1,172 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"memebot-go/internal/cex/binance"
+)
+
+// fakeBinance serves exchangeInfo, order, and account endpoints for one symbol.
+type fakeBinance struct {
+	mu     sync.Mutex
+	placed []map[string]string
+	info   int
+	fills  string // JSON fills array for market orders; empty uses two commission-free trades
+}
+
+func (f *fakeBinance) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	query := r.URL.Query()
+	switch {
+	case r.URL.Path == "/api/v3/exchangeInfo":
+		f.info++
+		fmt.Fprint(w, `{"symbols":[{"symbol":"WIFUSDT","status":"TRADING","baseAsset":"WIF","quoteAsset":"USDT","filters":[
+			{"filterType":"PRICE_FILTER","minPrice":"0.0001","maxPrice":"1000","tickSize":"0.0001"},
+			{"filterType":"LOT_SIZE","minQty":"0.1","maxQty":"90000","stepSize":"0.1"},
+			{"filterType":"NOTIONAL","minNotional":"5"}]}]}`)
+	case r.URL.Path == "/api/v3/order" && r.Method == http.MethodPost:
+		f.placed = append(f.placed, map[string]string{
+			"type": query.Get("type"), "quantity": query.Get("quantity"), "price": query.Get("price"), "id": query.Get("newClientOrderId"),
+		})
+		if query.Get("type") == "LIMIT" {
+			fmt.Fprintf(w, `{"symbol":"WIFUSDT","orderId":2,"clientOrderId":%q,"status":"NEW","executedQty":"0","transactTime":1700000000000,"fills":[]}`, query.Get("newClientOrderId"))
+			return
+		}
+		fills := f.fills
+		if fills == "" {
+			fills = `[{"price":"2.00","qty":"10.0"},{"price":"2.01","qty":"2.3"}]`
+		}
+		fmt.Fprintf(w, `{"symbol":"WIFUSDT","orderId":1,"clientOrderId":%q,"status":"FILLED","executedQty":"12.3","transactTime":1700000000000,
+			"fills":%s}`, query.Get("newClientOrderId"), fills)
+	case r.URL.Path == "/api/v3/order" && r.Method == http.MethodGet:
+		fmt.Fprintf(w, `{"symbol":"WIFUSDT","clientOrderId":%q,"side":"BUY","status":"PARTIALLY_FILLED","origQty":"10","executedQty":"4","cummulativeQuoteQty":"8","updateTime":1700000000000}`, query.Get("origClientOrderId"))
+	case r.URL.Path == "/api/v3/order" && r.Method == http.MethodDelete:
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"code":-2011,"msg":"Unknown order sent."}`)
+	case r.URL.Path == "/api/v3/account":
+		fmt.Fprint(w, `{"canTrade":true,"balances":[{"asset":"USDT","free":"100.5","locked":"0"},{"asset":"BNB","free":"0","locked":"0"},{"asset":"WIF","free":"3","locked":"1"}]}`)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestBinanceVenue(t *testing.T) (*BinanceVenue, *fakeBinance) {
+	t.Helper()
+	fake := &fakeBinance{}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	client := binance.NewClient("k", "s", true)
+	client.Base = server.URL
+	client.Http = server.Client()
+	return NewBinanceVenue(zerolog.Nop(), client), fake
+}
+
+func TestBinanceVenueMarketOrderFills(t *testing.T) {
+	venue, fake := newTestBinanceVenue(t)
+	fills, err := venue.Submit(context.Background(), Order{Symbol: "WIFUSDT", Side: Buy, Qty: 12.3456, Price: 2})
+	if err != nil {
+		t.Fatalf("Submit returned error: %v", err)
+	}
+	if len(fills) != 2 {
+		t.Fatalf("expected two fills, got %d", len(fills))
+	}
+	if fills[1].Qty != 2.3 || fills[1].Price != 2.01 || fills[1].OrderID == "" {
+		t.Fatalf("unexpected fill %+v", fills[1])
+	}
+	if got := fake.placed[0]; got["type"] != "MARKET" || got["quantity"] != "12.3" || got["price"] != "" {
+		t.Fatalf("unexpected order params %+v", got)
+	}
+
+	if _, err := venue.Submit(context.Background(), Order{Symbol: "WIFUSDT", Side: Sell, Type: Limit, Qty: 5, Price: 2.345678}); err != nil {
+		t.Fatalf("limit Submit returned error: %v", err)
+	}
+	if got := fake.placed[1]; got["type"] != "LIMIT" || got["price"] != "2.3456" {
+		t.Fatalf("unexpected limit params %+v", got)
+	}
+	if fake.info != 1 {
+		t.Fatalf("expected exchange info to be cached, fetched %d times", fake.info)
+	}
+}
+
+func TestBinanceVenueBooksCommission(t *testing.T) {
+	venue, fake := newTestBinanceVenue(t)
+	fake.fills = `[{"price":"2.00","qty":"10.0","commission":"0.01","commissionAsset":"WIF"},
+		{"price":"2.00","qty":"2.3","commission":"0.0046","commissionAsset":"USDT"},
+		{"price":"2.00","qty":"1.0","commission":"0.00001","commissionAsset":"BNB"}]`
+
+	buys, err := venue.Submit(context.Background(), Order{Symbol: "WIFUSDT", Side: Buy, Qty: 13.3, Price: 2})
+	if err != nil {
+		t.Fatalf("Submit returned error: %v", err)
+	}
+	if base := buys[0]; math.Abs(base.Qty-9.99) > 1e-9 || math.Abs(base.Fee-0.02) > 1e-9 {
+		t.Fatalf("expected base-asset commission netted from the bought qty, got %+v", base)
+	}
+	if quote := buys[1]; quote.Qty != 2.3 || math.Abs(quote.Fee-0.0046) > 1e-9 {
+		t.Fatalf("expected quote-asset commission recorded as fee, got %+v", quote)
+	}
+	if other := buys[2]; other.Qty != 1 || other.Fee != 0 {
+		t.Fatalf("expected third-asset commission to leave the fill alone, got %+v", other)
+	}
+
+	fake.fills = `[{"price":"2.00","qty":"10.0","commission":"0.01","commissionAsset":"WIF"}]`
+	sells, err := venue.Submit(context.Background(), Order{Symbol: "WIFUSDT", Side: Sell, Qty: 10, Price: 2})
+	if err != nil {
+		t.Fatalf("Submit returned error: %v", err)
+	}
+	if sell := sells[0]; math.Abs(sell.Qty-10.01) > 1e-9 || math.Abs(sell.Fee-0.02) > 1e-9 {
+		t.Fatalf("expected base-asset commission added to the sold qty, got %+v", sell)
+	}
+}
+
+func TestBinanceVenueRejectsBelowFilters(t *testing.T) {
+	venue, fake := newTestBinanceVenue(t)
+	if _, err := venue.Submit(context.Background(), Order{Symbol: "WIFUSDT", Side: Buy, Qty: 1, Price: 2}); err == nil {
+		t.Fatalf("expected min notional rejection")
+	}
+	if len(fake.placed) != 0 {
+		t.Fatalf("expected no order to reach the exchange")
+	}
+}
+
+func TestBinanceVenueQueryCancelBalances(t *testing.T) {
+	venue, fake := newTestBinanceVenue(t)
+	ctx := context.Background()
+	if _, err := venue.Submit(ctx, Order{ID: "c-1", Symbol: "WIFUSDT", Side: Buy, Type: Limit, Qty: 10, Price: 2}); err != nil {
+		t.Fatalf("Submit returned error: %v", err)
+	}
+	if fake.placed[0]["id"] != "c-1" {
+		t.Fatalf("expected client order id to be forwarded, got %+v", fake.placed[0])
+	}
+
+	status, err := venue.QueryOrder(ctx, "c-1")
+	if err != nil {
+		t.Fatalf("QueryOrder returned error: %v", err)
+	}
+	if status.State != OrderPartiallyFilled || status.FilledQty != 4 || status.AvgPrice != 2 {
+		t.Fatalf("unexpected status %+v", status)
+	}
+	if err := venue.Cancel(ctx, "c-1"); !errors.Is(err, ErrOrderClosed) {
+		t.Fatalf("expected ErrOrderClosed, got %v", err)
+	}
+	if _, err := venue.QueryOrder(ctx, "missing"); !errors.Is(err, ErrUnknownOrder) {
+		t.Fatalf("expected ErrUnknownOrder, got %v", err)
+	}
+
+	balances, err := venue.Balances(ctx)
+	if err != nil {
+		t.Fatalf("Balances returned error: %v", err)
+	}
+	if len(balances) != 2 || balances[0].Asset != "USDT" || balances[1].Asset != "WIF" || balances[1].Locked != 1 {
+		t.Fatalf("unexpected balances %+v", balances)
+	}
+}
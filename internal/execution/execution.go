@@ -2,7 +2,12 @@
 package execution
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"math/rand"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -10,10 +15,6 @@ import (
 	"memebot-go/internal/metrics"
 )
 
-func init() {
-	rand.Seed(time.Now().UnixNano())
-}
-
 // Side enumerates order directions used by the executor.
 type Side string
 
@@ -24,13 +25,25 @@ const (
 	Sell Side = "SELL"
 )
 
+// OrderType distinguishes immediate-or-better market orders from resting limit orders.
+type OrderType string
+
+const (
+	// Market executes immediately against the book; Order.Price is only a reference.
+	Market OrderType = "MARKET"
+	// Limit rests at Order.Price until filled or cancelled.
+	Limit OrderType = "LIMIT"
+)
+
 // Fill models a simulated execution result.
 type Fill struct {
+	OrderID  string        `json:"order_id,omitempty"`
 	Symbol   string        `json:"symbol"`
 	Side     Side          `json:"side"`
 	Qty      float64       `json:"qty"`
 	Price    float64       `json:"price"`
 	Slippage float64       `json:"slippage"`
+	Fee      float64       `json:"fee,omitempty"` // commission in quote currency, charged on top of Qty*Price
 	Latency  time.Duration `json:"latency"`
 	Ts       time.Time     `json:"ts"`
 }
@@ -45,31 +58,99 @@ type Config struct {
 
 // Order represents a placement request the executor can process.
 type Order struct {
+	ID     string // optional client order id; venues assign one when empty
 	Symbol string
 	Side   Side
+	Type   OrderType // empty is treated as Market
 	Qty    float64
-	Price  float64 // 0 for market (avoid in real life)
+	Price  float64 // limit price, or reference price for market orders
 }
 
-// Executor implements a logger-backed submitter for orders.
+// PaperVenue is the venue name reported by the simulated Executor.
+const PaperVenue = "paper"
+
+// PaperQuoteAsset is the asset the paper venue debits and credits for order notional.
+const PaperQuoteAsset = "USD"
+
+// maxTrackedOrders bounds how many order statuses a venue remembers for QueryOrder.
+const maxTrackedOrders = 4096
+
+var _ Venue = (*Executor)(nil)
+
+// Executor is the paper venue: it simulates fills with slippage, latency, and partials.
 type Executor struct {
-	log    zerolog.Logger
-	config Config
+	log      zerolog.Logger
+	config   Config
+	mu       sync.Mutex
+	rng      *rand.Rand
+	now      func() time.Time
+	orderSeq uint64
+	orders   map[string]OrderStatus
+	orderIDs []string
+	balances map[string]float64
 }
 
 // NewExecutor wraps a zerolog logger for future order submissions.
 func NewExecutor(log zerolog.Logger) *Executor {
-	return &Executor{log: log, config: Config{MaxLatencyMs: 150, SlippageBps: 5, PartialFillProbability: 0.0, MaxPartialFills: 1}}
+	return &Executor{
+		log:      log,
+		config:   Config{MaxLatencyMs: 150, SlippageBps: 5, PartialFillProbability: 0.0, MaxPartialFills: 1},
+		rng:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		now:      time.Now,
+		orders:   make(map[string]OrderStatus),
+		balances: make(map[string]float64),
+	}
 }
 
+// Name identifies the paper venue.
+func (executor *Executor) Name() string { return PaperVenue }
+
 // SetConfig updates paper execution behaviour.
 func (executor *Executor) SetConfig(cfg Config) { executor.config = cfg }
 
-// Submit logs the order request and returns simulated fills; wire real exchange APIs later.
-func (executor *Executor) Submit(order Order) ([]Fill, error) {
+// SetSeed reseeds the fill simulator so repeated runs produce identical fills.
+func (executor *Executor) SetSeed(seed int64) {
+	executor.mu.Lock()
+	executor.rng = rand.New(rand.NewSource(seed))
+	executor.mu.Unlock()
+}
+
+// SetClock overrides the time source used to stamp fills (e.g. simulated time in backtests).
+func (executor *Executor) SetClock(now func() time.Time) {
+	if now == nil {
+		now = time.Now
+	}
+	executor.mu.Lock()
+	executor.now = now
+	executor.mu.Unlock()
+}
+
+// SetBalance seeds the simulated balance for an asset (e.g. PaperQuoteAsset starting cash).
+func (executor *Executor) SetBalance(asset string, amount float64) {
+	executor.mu.Lock()
+	executor.balances[asset] = amount
+	executor.mu.Unlock()
+}
+
+// Submit logs the order request and returns simulated fills.
+func (executor *Executor) Submit(ctx context.Context, order Order) ([]Fill, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if order.Qty <= 0 {
+		return nil, errors.New("order quantity must be positive")
+	}
 	metrics.OrdersTotal.WithLabelValues(order.Symbol, string(order.Side)).Inc()
 
+	executor.mu.Lock()
+	if order.ID == "" {
+		executor.orderSeq++
+		order.ID = fmt.Sprintf("paper-%d", executor.orderSeq)
+	}
 	fills := executor.generateFills(order)
+	executor.book(order, fills)
+	executor.mu.Unlock()
+
 	for _, fill := range fills {
 		executor.log.Info().
 			Str("sym", order.Symbol).
@@ -79,17 +160,97 @@ func (executor *Executor) Submit(order Order) ([]Fill, error) {
 			Float64("fill_px", fill.Price).
 			Float64("slippage", fill.Slippage).
 			Dur("latency", fill.Latency).
-			Msg("submit order (stub)")
+			Msg("submit order (paper)")
 	}
 	return fills, nil
 }
 
+// Cancel reports whether a paper order can be cancelled; market orders fill immediately so they are always closed.
+func (executor *Executor) Cancel(ctx context.Context, orderID string) error {
+	executor.mu.Lock()
+	defer executor.mu.Unlock()
+	status, ok := executor.orders[orderID]
+	if !ok {
+		return ErrUnknownOrder
+	}
+	if !status.State.Open() {
+		return ErrOrderClosed
+	}
+	status.State = OrderCanceled
+	status.UpdatedAt = executor.now()
+	executor.orders[orderID] = status
+	return nil
+}
+
+// QueryOrder returns the recorded status of a paper order.
+func (executor *Executor) QueryOrder(ctx context.Context, orderID string) (OrderStatus, error) {
+	executor.mu.Lock()
+	defer executor.mu.Unlock()
+	status, ok := executor.orders[orderID]
+	if !ok {
+		return OrderStatus{}, ErrUnknownOrder
+	}
+	return status, nil
+}
+
+// Balances reports simulated asset balances accumulated from fills.
+func (executor *Executor) Balances(ctx context.Context) ([]Balance, error) {
+	executor.mu.Lock()
+	defer executor.mu.Unlock()
+	out := make([]Balance, 0, len(executor.balances))
+	for asset, free := range executor.balances {
+		out = append(out, Balance{Asset: asset, Free: free})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Asset < out[j].Asset })
+	return out, nil
+}
+
+// book records order state and balance changes; callers hold executor.mu.
+func (executor *Executor) book(order Order, fills []Fill) {
+	status := OrderStatus{ID: order.ID, Symbol: order.Symbol, Side: order.Side, Qty: order.Qty, State: OrderNew}
+	var notional float64
+	for _, fill := range fills {
+		status.FilledQty += fill.Qty
+		notional += fill.Qty * fill.Price
+		if fill.Ts.After(status.UpdatedAt) {
+			status.UpdatedAt = fill.Ts
+		}
+	}
+	if status.FilledQty > 0 {
+		status.AvgPrice = notional / status.FilledQty
+		status.State = OrderPartiallyFilled
+		if status.FilledQty >= order.Qty-1e-12 {
+			status.State = OrderFilled
+		}
+	}
+	switch order.Side {
+	case Buy:
+		executor.balances[order.Symbol] += status.FilledQty
+		executor.balances[PaperQuoteAsset] -= notional
+	case Sell:
+		executor.balances[order.Symbol] -= status.FilledQty
+		executor.balances[PaperQuoteAsset] += notional
+	}
+
+	if _, exists := executor.orders[order.ID]; !exists {
+		executor.orderIDs = append(executor.orderIDs, order.ID)
+	}
+	executor.orders[order.ID] = status
+	if len(executor.orderIDs) > maxTrackedOrders {
+		evict := executor.orderIDs[0]
+		executor.orderIDs = executor.orderIDs[1:]
+		delete(executor.orders, evict)
+	}
+}
+
+// generateFills splits an order into simulated executions; callers hold executor.mu.
 func (executor *Executor) generateFills(order Order) []Fill {
+	now := executor.now()
 	parts := executor.sampleParts()
 	weights := make([]float64, parts)
 	total := 0.0
 	for i := range weights {
-		w := rand.Float64()
+		w := executor.rng.Float64()
 		if w <= 0 {
 			w = 1e-6
 		}
@@ -109,13 +270,14 @@ func (executor *Executor) generateFills(order Order) []Fill {
 		latency := executor.sampleLatency()
 		price := executor.applySlippage(order.Price, order.Side)
 		fills[i] = Fill{
+			OrderID:  order.ID,
 			Symbol:   order.Symbol,
 			Side:     order.Side,
 			Qty:      qty,
 			Price:    price,
 			Slippage: price - order.Price,
 			Latency:  latency,
-			Ts:       time.Now().Add(latency),
+			Ts:       now.Add(latency),
 		}
 	}
 	return fills
@@ -126,10 +288,10 @@ func (executor *Executor) sampleParts() int {
 	if max < 2 || executor.config.PartialFillProbability <= 0 {
 		return 1
 	}
-	if rand.Float64() >= executor.config.PartialFillProbability {
+	if executor.rng.Float64() >= executor.config.PartialFillProbability {
 		return 1
 	}
-	return 1 + rand.Intn(max)
+	return 1 + executor.rng.Intn(max)
 }
 
 func (executor *Executor) sampleLatency() time.Duration {
@@ -137,7 +299,7 @@ func (executor *Executor) sampleLatency() time.Duration {
 	if max <= 0 {
 		return 0
 	}
-	return time.Duration(rand.Intn(max+1)) * time.Millisecond
+	return time.Duration(executor.rng.Intn(max+1)) * time.Millisecond
 }
 
 func (executor *Executor) applySlippage(px float64, side Side) float64 {
@@ -152,6 +314,6 @@ func (executor *Executor) applySlippage(px float64, side Side) float64 {
 	if side == Sell {
 		direction = -1.0
 	}
-	magnitude := (rand.Float64()*2 - 1) * bps / 10000 // uniform in [-bps,bps]
+	magnitude := (executor.rng.Float64()*2 - 1) * bps / 10000 // uniform in [-bps,bps]
 	return px * (1 + magnitude*direction)
 }
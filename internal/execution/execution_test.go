@@ -2,6 +2,7 @@ package execution
 
 import (
 	"bytes"
+	"context"
 	"strings"
 	"testing"
 	"time"
@@ -15,7 +16,7 @@ func TestSubmitLogsOrder(t *testing.T) {
 
 	exec := NewExecutor(logger)
 	exec.SetConfig(Config{MaxLatencyMs: 1, SlippageBps: 0, PartialFillProbability: 0, MaxPartialFills: 1})
-	fills, err := exec.Submit(Order{Symbol: "BTCUSDT", Side: Buy, Qty: 1, Price: 1000})
+	fills, err := exec.Submit(context.Background(), Order{Symbol: "BTCUSDT", Side: Buy, Qty: 1, Price: 1000})
 	if err != nil {
 		t.Fatalf("Submit returned error: %v", err)
 	}
@@ -38,7 +39,7 @@ func TestSubmitSlippageLatencyAndPartials(t *testing.T) {
 	exec := NewExecutor(logger)
 	exec.SetConfig(Config{MaxLatencyMs: 20, SlippageBps: 10, PartialFillProbability: 1.0, MaxPartialFills: 3})
 
-	fills, err := exec.Submit(Order{Symbol: "ETHUSDT", Side: Sell, Qty: 2, Price: 2000})
+	fills, err := exec.Submit(context.Background(), Order{Symbol: "ETHUSDT", Side: Sell, Qty: 2, Price: 2000})
 	if err != nil {
 		t.Fatalf("Submit returned error: %v", err)
 	}
@@ -56,3 +57,73 @@ func TestSubmitSlippageLatencyAndPartials(t *testing.T) {
 		t.Fatalf("total quantity should be positive")
 	}
 }
+
+func TestSubmitDeterministicWithSeedAndClock(t *testing.T) {
+	clock := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	cfg := Config{MaxLatencyMs: 50, SlippageBps: 10, PartialFillProbability: 1.0, MaxPartialFills: 3}
+	run := func() []Fill {
+		exec := NewExecutor(zerolog.Nop())
+		exec.SetConfig(cfg)
+		exec.SetSeed(42)
+		exec.SetClock(func() time.Time { return clock })
+		fills, err := exec.Submit(context.Background(), Order{Symbol: "BTCUSDT", Side: Buy, Qty: 1, Price: 100})
+		if err != nil {
+			t.Fatalf("Submit returned error: %v", err)
+		}
+		return fills
+	}
+	first, second := run(), run()
+	if len(first) != len(second) {
+		t.Fatalf("expected identical fill counts, got %d and %d", len(first), len(second))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("fill %d differs: %+v vs %+v", i, first[i], second[i])
+		}
+		if first[i].Ts.Before(clock) || first[i].Ts.After(clock.Add(50*time.Millisecond)) {
+			t.Fatalf("fill timestamp not derived from clock: %v", first[i].Ts)
+		}
+	}
+}
+
+func TestPaperVenueTracksOrdersAndBalances(t *testing.T) {
+	ctx := context.Background()
+	var venue Venue = NewExecutor(zerolog.Nop())
+	exec := venue.(*Executor)
+	exec.SetConfig(Config{SlippageBps: 0, MaxPartialFills: 1})
+	exec.SetBalance(PaperQuoteAsset, 1000)
+
+	fills, err := venue.Submit(ctx, Order{Symbol: "WIF", Side: Buy, Qty: 10, Price: 2})
+	if err != nil {
+		t.Fatalf("Submit returned error: %v", err)
+	}
+	orderID := fills[0].OrderID
+	if orderID == "" {
+		t.Fatalf("expected venue to assign an order id")
+	}
+	status, err := venue.QueryOrder(ctx, orderID)
+	if err != nil {
+		t.Fatalf("QueryOrder returned error: %v", err)
+	}
+	if status.State != OrderFilled || status.FilledQty != 10 || status.AvgPrice != 2 {
+		t.Fatalf("unexpected order status %+v", status)
+	}
+	if err := venue.Cancel(ctx, orderID); err != ErrOrderClosed {
+		t.Fatalf("expected ErrOrderClosed cancelling filled order, got %v", err)
+	}
+	if _, err := venue.QueryOrder(ctx, "missing"); err != ErrUnknownOrder {
+		t.Fatalf("expected ErrUnknownOrder, got %v", err)
+	}
+
+	balances, err := venue.Balances(ctx)
+	if err != nil {
+		t.Fatalf("Balances returned error: %v", err)
+	}
+	got := map[string]float64{}
+	for _, bal := range balances {
+		got[bal.Asset] = bal.Free
+	}
+	if got[PaperQuoteAsset] != 980 || got["WIF"] != 10 {
+		t.Fatalf("unexpected balances %+v", balances)
+	}
+}
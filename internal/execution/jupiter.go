@@ -0,0 +1,476 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	solana "github.com/gagliardetto/solana-go"
+	"github.com/rs/zerolog"
+
+	dex "memebot-go/internal/dex/solana"
+	"memebot-go/internal/instrument"
+	"memebot-go/internal/metrics"
+)
+
+// JupiterVenueName identifies the Jupiter-backed Solana venue.
+const JupiterVenueName = "jupiter"
+
+// JupiterInstrument maps a feed symbol to the SPL mint traded for it.
+type JupiterInstrument struct {
+	Symbol   string
+	Mint     string
+	Decimals int
+}
+
+// JupiterConfig controls how orders are translated into Jupiter swaps.
+type JupiterConfig struct {
+	SettlementMint     string        // USD-pegged mint spent on buys and received on sells (e.g. USDC)
+	SettlementDecimals int           // base-unit decimals of the settlement mint
+	SlippageBps        int           // max slippage passed to the quote endpoint
+	ConfirmTimeout     time.Duration // how long to wait for a swap to reach the client commitment
+	ConfirmPoll        time.Duration // signature status polling cadence
+	Instruments        []JupiterInstrument
+	// Registry, when set, resolves symbols without a static mapping from registered instruments whose base
+	// mint and decimals are known, and rejects static mappings whose mint disagrees with the registry.
+	Registry *instrument.Registry
+}
+
+var _ Venue = (*JupiterVenue)(nil)
+
+const (
+	// pendingSwapQuery bounds how long QueryOrder waits for a pending swap's transaction.
+	pendingSwapQuery = 5 * time.Second
+	// pendingSwapExpiry is how long after broadcast an unseen swap is given up: its blockhash has expired by
+	// then, so it can no longer land.
+	pendingSwapExpiry = 3 * time.Minute
+)
+
+// pendingSwap is a broadcast swap whose confirmation timed out, kept so QueryOrder can settle it.
+type pendingSwap struct {
+	order  Order
+	inst   JupiterInstrument
+	sig    solana.Signature
+	sentAt time.Time
+}
+
+// JupiterVenue routes orders through Jupiter swaps signed by the configured wallet.
+type JupiterVenue struct {
+	log         zerolog.Logger
+	client      *dex.JupiterClient
+	cfg         JupiterConfig
+	instruments map[string]JupiterInstrument
+
+	mu       sync.Mutex
+	orderSeq uint64
+	orders   map[string]OrderStatus
+	orderIDs []string
+	pending  map[string]pendingSwap
+}
+
+// NewJupiterVenue validates instrument mappings and returns a live Solana venue.
+func NewJupiterVenue(log zerolog.Logger, client *dex.JupiterClient, cfg JupiterConfig) (*JupiterVenue, error) {
+	if client == nil {
+		return nil, errors.New("jupiter venue requires a client")
+	}
+	if _, err := solana.PublicKeyFromBase58(cfg.SettlementMint); err != nil {
+		return nil, fmt.Errorf("settlement mint: %w", err)
+	}
+	if cfg.SettlementDecimals <= 0 {
+		cfg.SettlementDecimals = 6
+	}
+	if cfg.SlippageBps <= 0 {
+		cfg.SlippageBps = 100
+	}
+	if cfg.ConfirmTimeout <= 0 {
+		cfg.ConfirmTimeout = 45 * time.Second
+	}
+	instruments := make(map[string]JupiterInstrument, len(cfg.Instruments))
+	for _, inst := range cfg.Instruments {
+		if inst.Symbol == "" {
+			return nil, errors.New("jupiter instrument missing symbol")
+		}
+		if _, err := solana.PublicKeyFromBase58(inst.Mint); err != nil {
+			return nil, fmt.Errorf("instrument %s mint: %w", inst.Symbol, err)
+		}
+		if inst.Decimals < 0 {
+			return nil, fmt.Errorf("instrument %s has negative decimals", inst.Symbol)
+		}
+		instruments[inst.Symbol] = inst
+	}
+	return &JupiterVenue{
+		log:         log,
+		client:      client,
+		cfg:         cfg,
+		instruments: instruments,
+		orders:      make(map[string]OrderStatus),
+		pending:     make(map[string]pendingSwap),
+	}, nil
+}
+
+// Name identifies the venue.
+func (v *JupiterVenue) Name() string { return JupiterVenueName }
+
+// Submit converts the order into an exact-in swap, waits for confirmation, and reports the settled amounts as a fill.
+func (v *JupiterVenue) Submit(ctx context.Context, order Order) ([]Fill, error) {
+	inst, err := v.instrument(order.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	if order.Qty <= 0 {
+		return nil, errors.New("order quantity must be positive")
+	}
+	if order.Type == Limit {
+		return nil, errors.New("jupiter venue only supports market orders")
+	}
+
+	v.mu.Lock()
+	if order.ID == "" {
+		v.orderSeq++
+		order.ID = fmt.Sprintf("jup-%d", v.orderSeq)
+	}
+	v.trackLocked(OrderStatus{ID: order.ID, Symbol: order.Symbol, Side: order.Side, Qty: order.Qty, State: OrderNew, UpdatedAt: time.Now()})
+	v.mu.Unlock()
+
+	inputMint, outputMint, amount, err := v.swapLeg(order, inst)
+	if err != nil {
+		v.finish(order, OrderRejected, 0, 0)
+		return nil, err
+	}
+	metrics.OrdersTotal.WithLabelValues(order.Symbol, string(order.Side)).Inc()
+
+	started := time.Now()
+	quote, err := v.client.GetQuote(ctx, inputMint, outputMint, amount, v.cfg.SlippageBps)
+	if err != nil {
+		v.finish(order, OrderRejected, 0, 0)
+		return nil, fmt.Errorf("jupiter quote: %w", err)
+	}
+	sig, err := v.client.BuildAndSendSwap(ctx, quote)
+	if err != nil {
+		v.finish(order, OrderRejected, 0, 0)
+		return nil, fmt.Errorf("jupiter swap: %w", err)
+	}
+	confirmCtx, cancel := context.WithTimeout(ctx, v.cfg.ConfirmTimeout)
+	defer cancel()
+	if err := v.client.AwaitConfirmation(confirmCtx, sig, v.cfg.ConfirmPoll); err != nil {
+		if confirmCtx.Err() == nil {
+			v.finish(order, OrderRejected, 0, 0)
+			return nil, err
+		}
+		// The swap was broadcast and may still land; leave the order open so QueryOrder can book it.
+		v.mu.Lock()
+		if _, ok := v.orders[order.ID]; ok {
+			v.pending[order.ID] = pendingSwap{order: order, inst: inst, sig: sig, sentAt: started}
+		}
+		v.mu.Unlock()
+		v.log.Warn().
+			Err(err).
+			Str("sym", order.Symbol).
+			Str("order_id", order.ID).
+			Str("signature", sig.String()).
+			Msg("jupiter swap unconfirmed; outcome pending")
+		return nil, fmt.Errorf("jupiter swap %s: %w", sig, ErrOrderPending)
+	}
+
+	in, out, err := v.settledAmounts(confirmCtx, sig, order.Side, inst, quote)
+	if err != nil {
+		v.finish(order, OrderRejected, 0, 0)
+		return nil, err
+	}
+	qty, price, err := v.fillFromAmounts(order.Side, inst, in, out)
+	if err != nil {
+		v.finish(order, OrderRejected, 0, 0)
+		return nil, err
+	}
+	latency := time.Since(started)
+	fill := Fill{
+		OrderID:  order.ID,
+		Symbol:   order.Symbol,
+		Side:     order.Side,
+		Qty:      qty,
+		Price:    price,
+		Slippage: price - order.Price,
+		Latency:  latency,
+		Ts:       time.Now(),
+	}
+	v.finish(order, OrderFilled, qty, price)
+	v.log.Info().
+		Str("sym", order.Symbol).
+		Str("side", string(order.Side)).
+		Str("signature", sig.String()).
+		Float64("qty", qty).
+		Float64("px", order.Price).
+		Float64("fill_px", price).
+		Dur("latency", latency).
+		Msg("jupiter swap confirmed")
+	return []Fill{fill}, nil
+}
+
+// Cancel always fails for known orders because swaps settle atomically.
+func (v *JupiterVenue) Cancel(ctx context.Context, orderID string) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if _, ok := v.orders[orderID]; !ok {
+		return ErrUnknownOrder
+	}
+	return ErrOrderClosed
+}
+
+// QueryOrder returns the recorded status of a submitted swap. A swap whose confirmation timed out is settled
+// from its transaction's token balance changes once the transaction is visible.
+func (v *JupiterVenue) QueryOrder(ctx context.Context, orderID string) (OrderStatus, error) {
+	v.mu.Lock()
+	status, ok := v.orders[orderID]
+	swap, pending := v.pending[orderID]
+	v.mu.Unlock()
+	if !ok {
+		return OrderStatus{}, ErrUnknownOrder
+	}
+	if !pending {
+		return status, nil
+	}
+	if err := v.settlePending(ctx, swap); err != nil {
+		return status, err
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	status, ok = v.orders[orderID]
+	if !ok {
+		return OrderStatus{}, ErrUnknownOrder
+	}
+	return status, nil
+}
+
+// settlePending books a pending swap as filled or rejected once its transaction is visible, and rejects it when
+// it was never seen before its blockhash expired. A swap still in flight stays pending.
+func (v *JupiterVenue) settlePending(ctx context.Context, swap pendingSwap) error {
+	queryCtx, cancel := context.WithTimeout(ctx, pendingSwapQuery)
+	defer cancel()
+	deltas, err := v.client.TokenDeltas(queryCtx, swap.sig, v.cfg.ConfirmPoll)
+	if err != nil {
+		if queryCtx.Err() == nil || ctx.Err() != nil {
+			return fmt.Errorf("jupiter swap %s: %w", swap.sig, err)
+		}
+		if time.Since(swap.sentAt) < pendingSwapExpiry {
+			return nil
+		}
+		v.log.Warn().Str("order_id", swap.order.ID).Str("signature", swap.sig.String()).Msg("pending jupiter swap never landed")
+		v.settle(swap.order, OrderRejected, 0, 0)
+		return nil
+	}
+	spent, received := v.cfg.SettlementMint, swap.inst.Mint
+	if swap.order.Side == Sell {
+		spent, received = swap.inst.Mint, v.cfg.SettlementMint
+	}
+	if deltas[spent] >= 0 || deltas[received] <= 0 {
+		// The transaction landed without moving the wallet's tokens: the swap failed on chain.
+		v.settle(swap.order, OrderRejected, 0, 0)
+		return nil
+	}
+	qty, price, err := v.fillFromAmounts(swap.order.Side, swap.inst, uint64(-deltas[spent]), uint64(deltas[received]))
+	if err != nil {
+		v.settle(swap.order, OrderRejected, 0, 0)
+		return err
+	}
+	v.settle(swap.order, OrderFilled, qty, price)
+	v.log.Info().
+		Str("sym", swap.order.Symbol).
+		Str("side", string(swap.order.Side)).
+		Str("order_id", swap.order.ID).
+		Str("signature", swap.sig.String()).
+		Float64("qty", qty).
+		Float64("fill_px", price).
+		Msg("pending jupiter swap settled")
+	return nil
+}
+
+// Balances reports SOL, the settlement mint, and every mapped instrument mint held by the wallet.
+func (v *JupiterVenue) Balances(ctx context.Context) ([]Balance, error) {
+	lamports, err := v.client.NativeBalance(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("native balance: %w", err)
+	}
+	out := []Balance{{Asset: "SOL", Free: float64(lamports) / 1e9}}
+
+	settlement, err := v.SettlementBalance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out = append(out, Balance{Asset: v.cfg.SettlementMint, Free: settlement})
+
+	symbols := make([]string, 0, len(v.instruments))
+	for sym := range v.instruments {
+		symbols = append(symbols, sym)
+	}
+	sort.Strings(symbols)
+	for _, sym := range symbols {
+		inst := v.instruments[sym]
+		amount, err := v.tokenBalance(ctx, inst.Mint, inst.Decimals)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, Balance{Asset: sym, Free: amount})
+	}
+	return out, nil
+}
+
+// SettlementBalance returns the wallet's settlement-mint balance in whole units.
+func (v *JupiterVenue) SettlementBalance(ctx context.Context) (float64, error) {
+	return v.tokenBalance(ctx, v.cfg.SettlementMint, v.cfg.SettlementDecimals)
+}
+
+// instrument resolves the mint traded for symbol from the static mapping, falling back to the registry.
+func (v *JupiterVenue) instrument(symbol string) (JupiterInstrument, error) {
+	var registered instrument.Instrument
+	var known bool
+	if v.cfg.Registry != nil {
+		registered, known = v.cfg.Registry.Lookup(symbol)
+	}
+	if inst, ok := v.instruments[symbol]; ok {
+		if known && registered.BaseMint != "" && registered.BaseMint != inst.Mint {
+			return JupiterInstrument{}, fmt.Errorf("instrument %s maps to mint %s but the market trades %s", symbol, inst.Mint, registered.BaseMint)
+		}
+		return inst, nil
+	}
+	if !known || registered.BaseMint == "" || registered.BaseDecimals <= 0 {
+		return JupiterInstrument{}, fmt.Errorf("no jupiter instrument mapped for %s", symbol)
+	}
+	if _, err := solana.PublicKeyFromBase58(registered.BaseMint); err != nil {
+		return JupiterInstrument{}, fmt.Errorf("instrument %s mint: %w", symbol, err)
+	}
+	return JupiterInstrument{Symbol: symbol, Mint: registered.BaseMint, Decimals: registered.BaseDecimals}, nil
+}
+
+// swapLeg picks the input/output mints and the exact-in base-unit amount for an order.
+func (v *JupiterVenue) swapLeg(order Order, inst JupiterInstrument) (string, string, uint64, error) {
+	switch order.Side {
+	case Buy:
+		if order.Price <= 0 {
+			return "", "", 0, fmt.Errorf("buy %s requires a reference price to size the USD notional", order.Symbol)
+		}
+		amount := toBaseUnits(order.Qty*order.Price, v.cfg.SettlementDecimals)
+		if amount == 0 {
+			return "", "", 0, fmt.Errorf("buy %s notional rounds to zero", order.Symbol)
+		}
+		return v.cfg.SettlementMint, inst.Mint, amount, nil
+	case Sell:
+		amount := toBaseUnits(order.Qty, inst.Decimals)
+		if amount == 0 {
+			return "", "", 0, fmt.Errorf("sell %s quantity rounds to zero", order.Symbol)
+		}
+		return inst.Mint, v.cfg.SettlementMint, amount, nil
+	default:
+		return "", "", 0, fmt.Errorf("unknown order side %q", order.Side)
+	}
+}
+
+// settledAmounts returns the base units the wallet actually spent and received in the confirmed swap, read from
+// the transaction's token balance changes. When those are unavailable it falls back to the quote's input and its
+// minimum output (otherAmountThreshold), so the fill never overstates what the wallet holds.
+func (v *JupiterVenue) settledAmounts(ctx context.Context, sig solana.Signature, side Side, inst JupiterInstrument, quote *dex.Quote) (uint64, uint64, error) {
+	spent, received := v.cfg.SettlementMint, inst.Mint
+	if side == Sell {
+		spent, received = inst.Mint, v.cfg.SettlementMint
+	}
+	deltas, err := v.client.TokenDeltas(ctx, sig, v.cfg.ConfirmPoll)
+	if err == nil && deltas[spent] < 0 && deltas[received] > 0 {
+		return uint64(-deltas[spent]), uint64(deltas[received]), nil
+	}
+	in, parseErr := strconv.ParseUint(quote.InAmount, 10, 64)
+	if parseErr != nil {
+		return 0, 0, fmt.Errorf("parse quote inAmount: %w", parseErr)
+	}
+	out, parseErr := strconv.ParseUint(quote.OtherAmount, 10, 64)
+	if parseErr != nil {
+		return 0, 0, fmt.Errorf("parse quote otherAmountThreshold: %w", parseErr)
+	}
+	event := v.log.Warn().Str("signature", sig.String())
+	if err != nil {
+		event = event.Err(err)
+	}
+	event.Msg("swap settlement unavailable; booking the quote's minimum output")
+	return in, out, nil
+}
+
+// fillFromAmounts converts spent and received base units back into token quantity and USD price.
+func (v *JupiterVenue) fillFromAmounts(side Side, inst JupiterInstrument, in, out uint64) (float64, float64, error) {
+	var tokens, usd float64
+	if side == Buy {
+		usd = fromBaseUnits(in, v.cfg.SettlementDecimals)
+		tokens = fromBaseUnits(out, inst.Decimals)
+	} else {
+		tokens = fromBaseUnits(in, inst.Decimals)
+		usd = fromBaseUnits(out, v.cfg.SettlementDecimals)
+	}
+	if tokens <= 0 {
+		return 0, 0, errors.New("swap returned zero token quantity")
+	}
+	return tokens, usd / tokens, nil
+}
+
+func (v *JupiterVenue) tokenBalance(ctx context.Context, mint string, decimals int) (float64, error) {
+	key, err := solana.PublicKeyFromBase58(mint)
+	if err != nil {
+		return 0, err
+	}
+	raw, err := v.client.TokenBalance(ctx, key)
+	if err != nil {
+		return 0, fmt.Errorf("token balance %s: %w", mint, err)
+	}
+	return fromBaseUnits(raw, decimals), nil
+}
+
+func (v *JupiterVenue) finish(order Order, state OrderState, qty, price float64) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	status, ok := v.orders[order.ID]
+	if !ok {
+		return // evicted while in flight
+	}
+	status.State = state
+	status.FilledQty = qty
+	status.AvgPrice = price
+	status.UpdatedAt = time.Now()
+	v.orders[order.ID] = status
+}
+
+// settle finishes a pending swap unless another query already did.
+func (v *JupiterVenue) settle(order Order, state OrderState, qty, price float64) {
+	v.mu.Lock()
+	_, ok := v.pending[order.ID]
+	delete(v.pending, order.ID)
+	v.mu.Unlock()
+	if ok {
+		v.finish(order, state, qty, price)
+	}
+}
+
+// trackLocked records an order status, forgetting the oldest orders beyond maxTrackedOrders; callers hold v.mu.
+func (v *JupiterVenue) trackLocked(status OrderStatus) {
+	if _, exists := v.orders[status.ID]; !exists {
+		v.orderIDs = append(v.orderIDs, status.ID)
+	}
+	v.orders[status.ID] = status
+	if len(v.orderIDs) > maxTrackedOrders {
+		evict := v.orderIDs[0]
+		v.orderIDs = v.orderIDs[1:]
+		delete(v.orders, evict)
+		delete(v.pending, evict)
+	}
+}
+
+func toBaseUnits(amount float64, decimals int) uint64 {
+	if amount <= 0 {
+		return 0
+	}
+	return uint64(math.Floor(amount * math.Pow10(decimals)))
+}
+
+func fromBaseUnits(amount uint64, decimals int) float64 {
+	return float64(amount) / math.Pow10(decimals)
+}
@@ -0,0 +1,212 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	solana "github.com/gagliardetto/solana-go"
+	"github.com/rs/zerolog"
+
+	dex "memebot-go/internal/dex/solana"
+	"memebot-go/internal/dex/solana/solanatest"
+	"memebot-go/internal/instrument"
+)
+
+const (
+	testUSDC = "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"
+	testWIF  = "EKpQGSJtjMFqKZ9KQanSqYXRcF8fBopzLHYxdM65zcjm"
+)
+
+// newTestJupiterVenue prices WIF at $2 (both mints use 6 decimals) against a local stand-in.
+func newTestJupiterVenue(t *testing.T) (*JupiterVenue, *solanatest.Server, solana.PrivateKey) {
+	t.Helper()
+	server := solanatest.NewServer()
+	t.Cleanup(server.Close)
+	server.SetQuote(func(inputMint, _ string, amount uint64) uint64 {
+		if inputMint == testUSDC {
+			return amount / 2
+		}
+		return amount * 2
+	})
+
+	owner := solana.NewWallet().PrivateKey
+	client := dex.NewJupiterClient(server.RPCURL(), server.JupiterBase(), owner, "confirmed")
+	venue, err := NewJupiterVenue(zerolog.Nop(), client, JupiterConfig{
+		SettlementMint:     testUSDC,
+		SettlementDecimals: 6,
+		SlippageBps:        50,
+		ConfirmTimeout:     2 * time.Second,
+		ConfirmPoll:        5 * time.Millisecond,
+		Instruments:        []JupiterInstrument{{Symbol: "WIFSOL", Mint: testWIF, Decimals: 6}},
+	})
+	if err != nil {
+		t.Fatalf("NewJupiterVenue returned error: %v", err)
+	}
+	return venue, server, owner
+}
+
+func TestJupiterVenueBuyAndSell(t *testing.T) {
+	venue, server, _ := newTestJupiterVenue(t)
+	ctx := context.Background()
+
+	fills, err := venue.Submit(ctx, Order{Symbol: "WIFSOL", Side: Buy, Qty: 5, Price: 2})
+	if err != nil {
+		t.Fatalf("buy Submit returned error: %v", err)
+	}
+	if len(fills) != 1 {
+		t.Fatalf("expected single fill, got %d", len(fills))
+	}
+	buy := fills[0]
+	if math.Abs(buy.Qty-5) > 1e-9 || math.Abs(buy.Price-2) > 1e-9 {
+		t.Fatalf("unexpected buy fill: %+v", buy)
+	}
+	if buy.OrderID == "" {
+		t.Fatalf("expected order id on fill")
+	}
+
+	fills, err = venue.Submit(ctx, Order{Symbol: "WIFSOL", Side: Sell, Qty: 5, Price: 2})
+	if err != nil {
+		t.Fatalf("sell Submit returned error: %v", err)
+	}
+	if sell := fills[0]; math.Abs(sell.Qty-5) > 1e-9 || math.Abs(sell.Price-2) > 1e-9 {
+		t.Fatalf("unexpected sell fill: %+v", sell)
+	}
+	if got := len(server.Sent()); got != 2 {
+		t.Fatalf("expected 2 transactions sent, got %d", got)
+	}
+
+	status, err := venue.QueryOrder(ctx, buy.OrderID)
+	if err != nil {
+		t.Fatalf("QueryOrder returned error: %v", err)
+	}
+	if status.State != OrderFilled || math.Abs(status.FilledQty-5) > 1e-9 {
+		t.Fatalf("unexpected order status: %+v", status)
+	}
+	if err := venue.Cancel(ctx, buy.OrderID); !errors.Is(err, ErrOrderClosed) {
+		t.Fatalf("expected ErrOrderClosed, got %v", err)
+	}
+	if err := venue.Cancel(ctx, "missing"); !errors.Is(err, ErrUnknownOrder) {
+		t.Fatalf("expected ErrUnknownOrder, got %v", err)
+	}
+}
+
+func TestJupiterVenueBooksSettledAmounts(t *testing.T) {
+	venue, server, _ := newTestJupiterVenue(t)
+	// The swap delivers 1.5% less than quoted.
+	server.SetExecution(func(quotedOut uint64) uint64 { return quotedOut * 985 / 1000 })
+
+	fills, err := venue.Submit(context.Background(), Order{Symbol: "WIFSOL", Side: Buy, Qty: 5, Price: 2})
+	if err != nil {
+		t.Fatalf("buy Submit returned error: %v", err)
+	}
+	buy := fills[0]
+	if math.Abs(buy.Qty-4.925) > 1e-9 || math.Abs(buy.Price-10/4.925) > 1e-9 {
+		t.Fatalf("expected fill at the settled 4.925 WIF for $10, got %+v", buy)
+	}
+
+	fills, err = venue.Submit(context.Background(), Order{Symbol: "WIFSOL", Side: Sell, Qty: buy.Qty, Price: 2})
+	if err != nil {
+		t.Fatalf("sell Submit returned error: %v", err)
+	}
+	if sell := fills[0]; math.Abs(sell.Qty-4.925) > 1e-9 || math.Abs(sell.Price-2*0.985) > 1e-9 {
+		t.Fatalf("expected sell of the settled quantity at the settled price, got %+v", sell)
+	}
+}
+
+func TestJupiterVenueRejectsFailedSwap(t *testing.T) {
+	venue, server, _ := newTestJupiterVenue(t)
+	server.FailSwaps(true)
+
+	_, err := venue.Submit(context.Background(), Order{ID: "o-1", Symbol: "WIFSOL", Side: Buy, Qty: 1, Price: 2})
+	if err == nil {
+		t.Fatalf("expected failed swap to return error")
+	}
+	status, err := venue.QueryOrder(context.Background(), "o-1")
+	if err != nil {
+		t.Fatalf("QueryOrder returned error: %v", err)
+	}
+	if status.State != OrderRejected {
+		t.Fatalf("expected rejected order, got %s", status.State)
+	}
+}
+
+func TestJupiterVenueSettlesUnconfirmedSwap(t *testing.T) {
+	venue, server, _ := newTestJupiterVenue(t)
+	venue.cfg.ConfirmTimeout = 50 * time.Millisecond
+	server.HoldConfirmations(true)
+
+	fills, err := venue.Submit(context.Background(), Order{ID: "o-1", Symbol: "WIFSOL", Side: Buy, Qty: 5, Price: 2})
+	if !errors.Is(err, ErrOrderPending) || len(fills) != 0 {
+		t.Fatalf("expected pending outcome without fills, got %v, %v", fills, err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	status, err := venue.QueryOrder(ctx, "o-1")
+	if err == nil || status.State != OrderNew {
+		t.Fatalf("expected the unlanded swap to stay open, got %+v, %v", status, err)
+	}
+
+	server.HoldConfirmations(false)
+	status, err = venue.QueryOrder(context.Background(), "o-1")
+	if err != nil {
+		t.Fatalf("QueryOrder returned error: %v", err)
+	}
+	if status.State != OrderFilled || math.Abs(status.FilledQty-5) > 1e-9 || math.Abs(status.AvgPrice-2) > 1e-9 {
+		t.Fatalf("expected the landed swap booked as filled, got %+v", status)
+	}
+}
+
+func TestJupiterVenueRequiresInstrument(t *testing.T) {
+	venue, server, _ := newTestJupiterVenue(t)
+	if _, err := venue.Submit(context.Background(), Order{Symbol: "BODEN", Side: Buy, Qty: 1, Price: 1}); err == nil {
+		t.Fatalf("expected unmapped symbol to be rejected")
+	}
+	if len(server.Sent()) != 0 {
+		t.Fatalf("expected no transactions for unmapped symbol")
+	}
+}
+
+func TestJupiterVenueResolvesRegisteredInstruments(t *testing.T) {
+	venue, server, _ := newTestJupiterVenue(t)
+	reg := instrument.NewRegistry(zerolog.Nop())
+	venue.cfg.Registry = reg
+	bonk := reg.RegisterPair(instrument.Instrument{Chain: "solana", PairAddress: "POOL1", BaseMint: testWIF, BaseDecimals: 6}, "BONKSOL")
+	if _, err := venue.Submit(context.Background(), Order{Symbol: bonk.Symbol, Side: Buy, Qty: 1, Price: 2}); err != nil {
+		t.Fatalf("expected registered instrument to trade, got %v", err)
+	}
+
+	// A static mapping that disagrees with the market's mint is refused before anything is sent.
+	sent := len(server.Sent())
+	if _, err := reg.Register(instrument.Instrument{Symbol: "WIFSOL", Venue: "solana", Chain: "solana", PairAddress: "POOL2", BaseMint: testUSDC}); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	if _, err := venue.Submit(context.Background(), Order{Symbol: "WIFSOL", Side: Buy, Qty: 1, Price: 2}); err == nil {
+		t.Fatalf("expected mint mismatch to be rejected")
+	}
+	if len(server.Sent()) != sent {
+		t.Fatalf("expected no transaction for a mismatched mint")
+	}
+}
+
+func TestJupiterVenueBalances(t *testing.T) {
+	venue, server, owner := newTestJupiterVenue(t)
+	server.SetNativeBalance(2_500_000_000)
+	server.SetTokenBalance(owner.PublicKey(), solana.MustPublicKeyFromBase58(testUSDC), 150_000_000)
+
+	balances, err := venue.Balances(context.Background())
+	if err != nil {
+		t.Fatalf("Balances returned error: %v", err)
+	}
+	want := map[string]float64{"SOL": 2.5, testUSDC: 150, "WIFSOL": 0}
+	if len(balances) != len(want) {
+		t.Fatalf("unexpected balances: %+v", balances)
+	}
+	for _, balance := range balances {
+		if expected, ok := want[balance.Asset]; !ok || math.Abs(balance.Free-expected) > 1e-9 {
+			t.Fatalf("unexpected balance %+v", balance)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrUnknownOrder is returned when a venue has no record of the requested order.
+	ErrUnknownOrder = errors.New("unknown order")
+	// ErrOrderClosed is returned when cancelling an order that is already filled, cancelled, or rejected.
+	ErrOrderClosed = errors.New("order already closed")
+	// ErrOrderPending is returned when a venue accepted an order but could not learn its outcome in time;
+	// QueryOrder reports the settled state once the venue sees it.
+	ErrOrderPending = errors.New("order outcome pending")
+)
+
+// Venue routes orders to a trading destination such as the paper simulator, a DEX aggregator, or a CEX.
+type Venue interface {
+	// Name identifies the venue in logs and metrics.
+	Name() string
+	// Submit places an order and returns any fills that completed synchronously.
+	Submit(ctx context.Context, order Order) ([]Fill, error)
+	// Cancel requests cancellation of a resting order.
+	Cancel(ctx context.Context, orderID string) error
+	// QueryOrder returns the latest known state of an order.
+	QueryOrder(ctx context.Context, orderID string) (OrderStatus, error)
+	// Balances lists asset balances held at the venue.
+	Balances(ctx context.Context) ([]Balance, error)
+}
+
+// OrderState enumerates the lifecycle stages reported by venues.
+type OrderState string
+
+const (
+	// OrderNew marks an accepted order with no fills yet.
+	OrderNew OrderState = "NEW"
+	// OrderPartiallyFilled marks an order with some but not all quantity executed.
+	OrderPartiallyFilled OrderState = "PARTIALLY_FILLED"
+	// OrderFilled marks a fully executed order.
+	OrderFilled OrderState = "FILLED"
+	// OrderCanceled marks an order cancelled before completion.
+	OrderCanceled OrderState = "CANCELED"
+	// OrderRejected marks an order the venue refused.
+	OrderRejected OrderState = "REJECTED"
+)
+
+// Open reports whether the order can still receive fills.
+func (s OrderState) Open() bool {
+	return s == OrderNew || s == OrderPartiallyFilled
+}
+
+// OrderStatus is a venue-agnostic view of an order's progress.
+type OrderStatus struct {
+	ID        string     `json:"id"`
+	Symbol    string     `json:"symbol"`
+	Side      Side       `json:"side"`
+	State     OrderState `json:"state"`
+	Qty       float64    `json:"qty"`
+	FilledQty float64    `json:"filled_qty"`
+	AvgPrice  float64    `json:"avg_price"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// Balance reports free and locked quantities for a single asset.
+type Balance struct {
+	Asset  string  `json:"asset"`
+	Free   float64 `json:"free"`
+	Locked float64 `json:"locked"`
+}
@@ -0,0 +1,91 @@
+// Package instrument models tradable markets and the registry that gives each one a unique symbol.
+package instrument
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Instrument describes one market. Symbol is the unique ID carried by ticks, orders, fills, and positions.
+type Instrument struct {
+	Symbol        string `json:"symbol"`
+	Venue         string `json:"venue"`
+	Chain         string `json:"chain,omitempty"`
+	PairAddress   string `json:"pair_address,omitempty"`
+	BaseSymbol    string `json:"base_symbol,omitempty"`
+	QuoteSymbol   string `json:"quote_symbol,omitempty"`
+	BaseMint      string `json:"base_mint,omitempty"`
+	QuoteMint     string `json:"quote_mint,omitempty"`
+	BaseDecimals  int    `json:"base_decimals,omitempty"`
+	QuoteDecimals int    `json:"quote_decimals,omitempty"`
+}
+
+// Key identifies the market independently of its symbol: chain/pair for on-chain pairs, venue:symbol otherwise.
+func (i Instrument) Key() string {
+	if i.PairAddress != "" {
+		return strings.ToLower(i.Chain) + "/" + i.PairAddress
+	}
+	return strings.ToLower(i.Venue) + ":" + i.Symbol
+}
+
+// FeedSymbol renders the SYMBOL@chain/pair form accepted by exchange.symbols, or the bare symbol off-chain.
+func (i Instrument) FeedSymbol() string {
+	if i.PairAddress == "" {
+		return i.Symbol
+	}
+	return fmt.Sprintf("%s@%s/%s", i.Symbol, i.Chain, i.PairAddress)
+}
+
+// merge fills i's unknown metadata from other.
+func (i *Instrument) merge(other Instrument) {
+	fill := func(dst *string, src string) {
+		if *dst == "" {
+			*dst = src
+		}
+	}
+	fill(&i.Venue, other.Venue)
+	fill(&i.BaseSymbol, other.BaseSymbol)
+	fill(&i.QuoteSymbol, other.QuoteSymbol)
+	fill(&i.BaseMint, other.BaseMint)
+	fill(&i.QuoteMint, other.QuoteMint)
+	if i.BaseDecimals == 0 {
+		i.BaseDecimals = other.BaseDecimals
+	}
+	if i.QuoteDecimals == 0 {
+		i.QuoteDecimals = other.QuoteDecimals
+	}
+}
+
+// Sanitize upper-cases name and strips everything but ASCII letters and digits.
+func Sanitize(name string) string {
+	var b strings.Builder
+	b.Grow(len(name))
+	for _, r := range strings.TrimSpace(name) {
+		if (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if r >= 'a' && r <= 'z' {
+				r -= 32
+			}
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Alias composes BASE_SUFFIX from a sanitized name and the last suffixLen characters of address.
+func Alias(base, address string, suffixLen int) string {
+	base = Sanitize(base)
+	suffix := Sanitize(address)
+	if len(suffix) > suffixLen {
+		suffix = suffix[len(suffix)-suffixLen:]
+	}
+	if base == "" {
+		if suffix == "" {
+			return "PAIR"
+		}
+		return "PAIR_" + suffix
+	}
+	if suffix == "" {
+		return base
+	}
+	return base + "_" + suffix
+}
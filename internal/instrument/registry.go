@@ -0,0 +1,142 @@
+package instrument
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"memebot-go/internal/metrics"
+)
+
+// DefaultSuffixLen is how many trailing pair address characters a derived alias keeps.
+const DefaultSuffixLen = 6
+
+// CollisionError reports a symbol already registered for a different market.
+type CollisionError struct {
+	Symbol   string
+	Existing Instrument
+	Incoming Instrument
+}
+
+func (e *CollisionError) Error() string {
+	return fmt.Sprintf("instrument symbol %s already names %s, cannot register %s", e.Symbol, e.Existing.Key(), e.Incoming.Key())
+}
+
+// Registry assigns unique symbols to markets and maps between symbols and markets. It is safe for concurrent use.
+type Registry struct {
+	log      zerolog.Logger
+	mu       sync.RWMutex
+	bySymbol map[string]Instrument
+	byKey    map[string]string // market key -> symbol
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry(log zerolog.Logger) *Registry {
+	return &Registry{log: log, bySymbol: make(map[string]Instrument), byKey: make(map[string]string)}
+}
+
+// Register adds inst under its explicit symbol, or merges metadata into the market already registered.
+// A symbol held by a different market is a *CollisionError.
+func (r *Registry) Register(inst Instrument) (Instrument, error) {
+	if inst.Symbol == "" {
+		return Instrument{}, fmt.Errorf("instrument %s has no symbol", inst.Key())
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.existingLocked(inst); ok {
+		return existing, nil
+	}
+	if existing, ok := r.bySymbol[inst.Symbol]; ok {
+		return Instrument{}, &CollisionError{Symbol: inst.Symbol, Existing: existing, Incoming: inst}
+	}
+	r.storeLocked(inst)
+	return inst, nil
+}
+
+// RegisterPair adds an on-chain pair under an alias derived from name and its pair address. When another
+// pair already owns that alias the address suffix grows until the alias is unique, so two pairs never
+// share a symbol. Re-registering a known pair keeps its symbol and merges metadata.
+func (r *Registry) RegisterPair(inst Instrument, name string) Instrument {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.existingLocked(inst); ok {
+		return existing
+	}
+	preferred := Alias(name, inst.PairAddress, DefaultSuffixLen)
+	inst.Symbol = preferred
+	address := inst.PairAddress
+	for n := DefaultSuffixLen; r.takenLocked(inst.Symbol); n += 2 {
+		if n < len(address) {
+			inst.Symbol = Alias(name, address, n+2)
+			continue
+		}
+		// Even the full address collides (names differing only in stripped characters); number it.
+		inst.Symbol = fmt.Sprintf("%s_%d", Alias(name, address, len(address)), n)
+	}
+	if inst.Symbol != preferred {
+		metrics.InstrumentAliasCollisions.Inc()
+		r.log.Warn().
+			Str("alias", preferred).
+			Str("taken_by", r.bySymbol[preferred].Key()).
+			Str("pair", inst.Key()).
+			Str("symbol", inst.Symbol).
+			Msg("instrument alias collision; using a longer alias")
+	}
+	r.storeLocked(inst)
+	return inst
+}
+
+// Lookup returns the instrument registered under symbol.
+func (r *Registry) Lookup(symbol string) (Instrument, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	inst, ok := r.bySymbol[symbol]
+	return inst, ok
+}
+
+// ByPair returns the instrument registered for an on-chain pair.
+func (r *Registry) ByPair(chain, address string) (Instrument, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	symbol, ok := r.byKey[Instrument{Chain: chain, PairAddress: address}.Key()]
+	if !ok {
+		return Instrument{}, false
+	}
+	return r.bySymbol[symbol], true
+}
+
+// All lists every registered instrument sorted by symbol.
+func (r *Registry) All() []Instrument {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]Instrument, 0, len(r.bySymbol))
+	for _, inst := range r.bySymbol {
+		out = append(out, inst)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Symbol < out[j].Symbol })
+	return out
+}
+
+// existingLocked merges inst into the registered market with the same key, if any.
+func (r *Registry) existingLocked(inst Instrument) (Instrument, bool) {
+	symbol, ok := r.byKey[inst.Key()]
+	if !ok {
+		return Instrument{}, false
+	}
+	existing := r.bySymbol[symbol]
+	existing.merge(inst)
+	r.bySymbol[symbol] = existing
+	return existing, true
+}
+
+func (r *Registry) takenLocked(symbol string) bool {
+	_, ok := r.bySymbol[symbol]
+	return ok
+}
+
+func (r *Registry) storeLocked(inst Instrument) {
+	r.bySymbol[inst.Symbol] = inst
+	r.byKey[inst.Key()] = inst.Symbol
+}
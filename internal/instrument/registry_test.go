@@ -0,0 +1,51 @@
+package instrument
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestRegisterPairDisambiguatesAliasCollisions(t *testing.T) {
+	reg := NewRegistry(zerolog.Nop())
+	// Same base name and the same last six address characters.
+	first := reg.RegisterPair(Instrument{Venue: "dexscreener", Chain: "solana", PairAddress: "AAAA1111xyzXYZ"}, "wif/sol")
+	second := reg.RegisterPair(Instrument{Venue: "dexscreener", Chain: "solana", PairAddress: "BBBB2222xyzXYZ", BaseMint: "MINT"}, "WIFSOL")
+	if first.Symbol != "WIFSOL_XYZXYZ" {
+		t.Fatalf("unexpected first alias %s", first.Symbol)
+	}
+	if second.Symbol != "WIFSOL_22XYZXYZ" {
+		t.Fatalf("expected the colliding pair to get a longer alias, got %s", second.Symbol)
+	}
+
+	// Re-registering keeps the symbol and fills in metadata.
+	again := reg.RegisterPair(Instrument{Chain: "Solana", PairAddress: "AAAA1111xyzXYZ", BaseMint: "WIFMINT", BaseDecimals: 6}, "OTHER")
+	if again.Symbol != first.Symbol || again.BaseMint != "WIFMINT" || again.Venue != "dexscreener" {
+		t.Fatalf("unexpected re-registration %+v", again)
+	}
+	if inst, ok := reg.ByPair("solana", "BBBB2222xyzXYZ"); !ok || inst.Symbol != second.Symbol || inst.FeedSymbol() != "WIFSOL_22XYZXYZ@solana/BBBB2222xyzXYZ" {
+		t.Fatalf("unexpected pair lookup %+v", inst)
+	}
+	if inst, ok := reg.Lookup(first.Symbol); !ok || inst.BaseDecimals != 6 {
+		t.Fatalf("unexpected symbol lookup %+v", inst)
+	}
+}
+
+func TestRegisterRejectsExplicitSymbolCollisions(t *testing.T) {
+	reg := NewRegistry(zerolog.Nop())
+	if _, err := reg.Register(Instrument{Symbol: "WIFUSDT", Venue: "binance"}); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	if _, err := reg.Register(Instrument{Symbol: "WIFUSDT", Venue: "binance"}); err != nil {
+		t.Fatalf("re-registering the same market should succeed, got %v", err)
+	}
+	_, err := reg.Register(Instrument{Symbol: "WIFUSDT", Venue: "solana", Chain: "solana", PairAddress: "POOL"})
+	var collision *CollisionError
+	if !errors.As(err, &collision) || collision.Existing.Venue != "binance" {
+		t.Fatalf("expected a collision error, got %v", err)
+	}
+	if len(reg.All()) != 1 {
+		t.Fatalf("colliding instrument should not be stored")
+	}
+}
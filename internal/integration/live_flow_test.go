@@ -0,0 +1,106 @@
+package integration
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	solana "github.com/gagliardetto/solana-go"
+	"github.com/rs/zerolog"
+
+	dex "memebot-go/internal/dex/solana"
+	"memebot-go/internal/dex/solana/solanatest"
+	"memebot-go/internal/engine"
+	"memebot-go/internal/execution"
+	"memebot-go/internal/paper"
+	"memebot-go/internal/risk"
+	sig "memebot-go/internal/signal"
+)
+
+const (
+	usdcMint = "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"
+	wifMint  = "EKpQGSJtjMFqKZ9KQanSqYXRcF8fBopzLHYxdM65zcjm"
+)
+
+type tickList []sig.Tick
+
+func (l tickList) Run(ctx context.Context, out chan<- sig.Tick) error {
+	for _, tk := range l {
+		select {
+		case out <- tk:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// sideStrategy turns the tick aggressor side into the signal direction.
+type sideStrategy struct{}
+
+func (sideStrategy) Name() string { return "side" }
+
+func (sideStrategy) OnTick(tk sig.Tick) *sig.Signal {
+	if tk.Side == 0 {
+		return nil
+	}
+	return &sig.Signal{Symbol: tk.Symbol, Score: float64(tk.Side), Ts: tk.Ts}
+}
+
+func TestLiveFlowRoutesThroughJupiter(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// WIF trades at $2 on buys and $2.20 on sells; both mints use 6 decimals.
+	server := solanatest.NewServer()
+	defer server.Close()
+	server.SetQuote(func(inputMint, _ string, amount uint64) uint64 {
+		if inputMint == usdcMint {
+			return amount / 2
+		}
+		return amount * 22 / 10
+	})
+	owner := solana.NewWallet().PrivateKey
+	server.SetTokenBalance(owner.PublicKey(), solana.MustPublicKeyFromBase58(usdcMint), 1_000_000_000)
+
+	client := dex.NewJupiterClient(server.RPCURL(), server.JupiterBase(), owner, "confirmed")
+	venue, err := execution.NewJupiterVenue(zerolog.Nop(), client, execution.JupiterConfig{
+		SettlementMint:     usdcMint,
+		SettlementDecimals: 6,
+		ConfirmPoll:        5 * time.Millisecond,
+		Instruments:        []execution.JupiterInstrument{{Symbol: "WIFSOL", Mint: wifMint, Decimals: 6}},
+	})
+	if err != nil {
+		t.Fatalf("NewJupiterVenue returned error: %v", err)
+	}
+	cash, err := venue.SettlementBalance(ctx)
+	if err != nil {
+		t.Fatalf("SettlementBalance returned error: %v", err)
+	}
+
+	now := time.Now()
+	feed := tickList{
+		{Symbol: "WIFSOL", Price: 2, Side: 1, Ts: now},
+		{Symbol: "WIFSOL", Price: 2.2, Side: -1, Ts: now.Add(time.Second)},
+	}
+	account := paper.NewAccount(cash, 0, 0)
+	eng := engine.New(zerolog.Nop(), feed, sideStrategy{}, risk.Limits{MaxNotionalPerTrade: 50}, venue, account)
+	if err := eng.Run(ctx); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if got := len(server.Sent()); got != 2 {
+		t.Fatalf("expected buy and sell swaps, got %d transactions", got)
+	}
+	snap := eng.Snapshot()
+	if math.Abs(snap.RealizedPnL-5) > 1e-6 {
+		t.Fatalf("expected realized pnl 5, got %.6f", snap.RealizedPnL)
+	}
+	if math.Abs(snap.Cash-1005) > 1e-6 {
+		t.Fatalf("expected cash 1005, got %.6f", snap.Cash)
+	}
+	if pos := account.Position("WIFSOL"); pos != 0 {
+		t.Fatalf("expected flat position, got %.6f", pos)
+	}
+}
@@ -26,7 +26,7 @@ func TestPaperFlowProducesOrder(t *testing.T) {
 		_ = feed.Run(ctx, ticks)
 	}()
 
-	strat := strategy.NewOBIMomentum(0.05, 5)
+	strat := strategy.NewOBIMomentum(0.05, 5, 5)
 	limits := risk.Limits{MaxNotionalPerTrade: 20}
 
 	exec := NewTestExecutor(zerolog.New(io.Discard))
@@ -45,7 +45,7 @@ func TestPaperFlowProducesOrder(t *testing.T) {
 			if !limits.Allow(order.Qty * order.Price) {
 				t.Fatalf("expected notional under limit to pass")
 			}
-			fills, err := exec.Submit(order)
+			fills, err := exec.Submit(ctx, order)
 			if err != nil {
 				t.Fatalf("Submit returned error: %v", err)
 			}
@@ -28,10 +28,73 @@ var (
 		prometheus.GaugeOpts{Name: "paper_position", Help: "Paper trading position size"},
 		[]string{"symbol"},
 	)
+	// FeedLastTickTimestamp records the unix time of the latest tick per provider and symbol; age = time() - value.
+	FeedLastTickTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{Name: "feed_last_tick_timestamp_seconds", Help: "Unix time of the latest tick received per symbol"},
+		[]string{"provider", "symbol"},
+	)
+	// StalePositions flags open positions whose market data is older than the staleness threshold (1 = stale).
+	StalePositions = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{Name: "engine_stale_position", Help: "Open positions marked with stale market data"},
+		[]string{"symbol"},
+	)
+	// FeedMissedTrades counts trades skipped over by a stream, inferred from gaps in exchange trade IDs.
+	FeedMissedTrades = prometheus.NewCounterVec(
+		prometheus.CounterOpts{Name: "feed_missed_trades_total", Help: "Trades missed by the market data stream, from trade ID gaps"},
+		[]string{"provider", "symbol"},
+	)
+	// BusPublished counts events published on the in-process bus per kind.
+	BusPublished = prometheus.NewCounterVec(
+		prometheus.CounterOpts{Name: "bus_events_published_total", Help: "Events published on the event bus"},
+		[]string{"kind"},
+	)
+	// BusDropped counts events a subscriber lost to its overflow policy.
+	BusDropped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{Name: "bus_events_dropped_total", Help: "Events dropped because a subscriber buffer was full"},
+		[]string{"subscriber", "kind"},
+	)
+	// BusLagSeconds measures how long events wait in a subscriber queue before being received.
+	BusLagSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "bus_subscriber_lag_seconds",
+			Help:    "Delay between publishing an event and a subscriber receiving it",
+			Buckets: []float64{0.0001, 0.001, 0.01, 0.05, 0.1, 0.5, 1, 5},
+		},
+		[]string{"subscriber"},
+	)
+	// BusQueueDepth gauges how many events are waiting in each subscriber queue.
+	BusQueueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{Name: "bus_subscriber_queue_depth", Help: "Events queued per bus subscriber"},
+		[]string{"subscriber"},
+	)
+	// DiscoveryScreenFindings counts failed token safety checks during discovery by check and outcome (reject|penalize).
+	DiscoveryScreenFindings = prometheus.NewCounterVec(
+		prometheus.CounterOpts{Name: "discovery_screen_findings_total", Help: "Discovery candidates failing a token safety check"},
+		[]string{"check", "outcome"},
+	)
+	// InstrumentAliasCollisions counts pairs whose default alias was already taken by another pair.
+	InstrumentAliasCollisions = prometheus.NewCounter(
+		prometheus.CounterOpts{Name: "instrument_alias_collisions_total", Help: "Pairs given a longer alias because the default one was taken"},
+	)
+	// RecorderWriteErrors counts records a JSONL recorder failed to write, keyed by stream.
+	RecorderWriteErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{Name: "recorder_write_errors_total", Help: "Records a JSONL recorder failed to write"},
+		[]string{"stream"},
+	)
+	// DexScreenerPollSeconds records how long each Dexscreener poll cycle takes end to end.
+	DexScreenerPollSeconds = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "dexscreener_poll_duration_seconds",
+			Help:    "Duration of a full Dexscreener poll cycle across all tracked pairs",
+			Buckets: []float64{0.1, 0.25, 0.5, 1, 2, 4, 8, 16},
+		},
+	)
 )
 
 func init() {
-	prometheus.MustRegister(TicksTotal, OrdersTotal, PaperEquity, PaperPositions)
+	prometheus.MustRegister(TicksTotal, OrdersTotal, PaperEquity, PaperPositions, DexScreenerPollSeconds, FeedLastTickTimestamp, StalePositions, FeedMissedTrades,
+		BusPublished, BusDropped, BusLagSeconds, BusQueueDepth, DiscoveryScreenFindings,
+		InstrumentAliasCollisions, RecorderWriteErrors)
 }
 
 // Serve mounts the Prometheus handler on /metrics and launches the HTTP server in a goroutine.
@@ -0,0 +1,22 @@
+package signal
+
+import "time"
+
+// Bar is a closed OHLCV candle aggregated from ticks over one timeframe; Start is aligned to the timeframe.
+type Bar struct {
+	Provider   string        `json:"provider,omitempty"`
+	Symbol     string        `json:"symbol"`
+	Timeframe  time.Duration `json:"timeframe"`
+	Start      time.Time     `json:"start"`
+	Open       float64       `json:"open"`
+	High       float64       `json:"high"`
+	Low        float64       `json:"low"`
+	Close      float64       `json:"close"`
+	Volume     float64       `json:"volume"`
+	BuyVolume  float64       `json:"buy_volume"`  // volume of buyer-initiated ticks (Side > 0)
+	SellVolume float64       `json:"sell_volume"` // volume of seller-initiated ticks (Side < 0)
+	Trades     int           `json:"trades"`
+}
+
+// End returns the exclusive end of the bar's window.
+func (b Bar) End() time.Time { return b.Start.Add(b.Timeframe) }
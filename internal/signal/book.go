@@ -0,0 +1,47 @@
+package signal
+
+// BookLevel is a single aggregated price level of an order book side.
+type BookLevel struct {
+	Price float64 `json:"price"`
+	Qty   float64 `json:"qty"`
+}
+
+// Book is a top-of-book snapshot with bids sorted descending and asks ascending.
+type Book struct {
+	Bids []BookLevel `json:"bids"`
+	Asks []BookLevel `json:"asks"`
+}
+
+// Mid returns the midpoint between best bid and best ask, or 0 when either side is empty.
+func (b *Book) Mid() float64 {
+	if b == nil || len(b.Bids) == 0 || len(b.Asks) == 0 {
+		return 0
+	}
+	return (b.Bids[0].Price + b.Asks[0].Price) / 2
+}
+
+// Imbalance returns (bidQty - askQty) / (bidQty + askQty) over the top levels of each side, in [-1, 1].
+func (b *Book) Imbalance(levels int) float64 {
+	if b == nil {
+		return 0
+	}
+	bid := depth(b.Bids, levels)
+	ask := depth(b.Asks, levels)
+	if bid+ask <= 0 {
+		return 0
+	}
+	return (bid - ask) / (bid + ask)
+}
+
+func depth(side []BookLevel, levels int) float64 {
+	if levels <= 0 || levels > len(side) {
+		levels = len(side)
+	}
+	total := 0.0
+	for _, lvl := range side[:levels] {
+		if lvl.Qty > 0 {
+			total += lvl.Qty
+		}
+	}
+	return total
+}
@@ -4,12 +4,21 @@ package signal
 import "time"
 
 // Tick models the essential pieces of market data consumed by strategies.
+// Book updates arrive as ticks with Book set, Price at the mid, and zero Size/Side.
 type Tick struct {
-	Symbol string
-	Price  float64
-	Size   float64
-	Side   int // +1 buy, -1 sell (aggressor)
-	Ts     time.Time
+	Provider string    `json:"provider,omitempty"` // market data source that produced the tick
+	Symbol   string    `json:"symbol"`
+	Price    float64   `json:"price"`
+	Size     float64   `json:"size"`
+	Side     int       `json:"side"`             // +1 buy, -1 sell (aggressor)
+	Trades   int       `json:"trades,omitempty"` // trades aggregated into this tick when > 1 (e.g. polled flow)
+	Ts       time.Time `json:"ts"`
+	Book     *Book     `json:"book,omitempty"`
+	// Slot is the Solana slot of an on-chain tick. Ts on those ticks is the slot's block time when the
+	// feed can look it up, and the local receipt time otherwise (at processed commitment, or when the
+	// RPC node has no block time yet). Block time has one-second resolution, so use Slot to order or
+	// align on-chain events.
+	Slot uint64 `json:"slot,omitempty"`
 }
 
 // Signal expresses a trading bias produced by a strategy implementation.
@@ -0,0 +1,333 @@
+package strategy
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"memebot-go/internal/signal"
+)
+
+// Ensemble combination rules.
+const (
+	// RuleWeighted blends every member's normalized score by weight; silent members count as zero.
+	RuleWeighted = "weighted"
+	// RuleUnanimous signals only when every member signals the same direction.
+	RuleUnanimous = "unanimous"
+	// RuleVote signals when at least MinVotes members agree on a direction and outnumber the other side.
+	RuleVote = "vote"
+	// RuleVeto blends the non-veto members and drops the result when any veto member signals against it.
+	RuleVeto = "veto"
+)
+
+const ensembleName = "ensemble"
+
+func init() {
+	register(ensembleName, buildEnsemble, "composite")
+}
+
+// EnsembleParams configures Ensemble from the strategy.ensemble block; each member reads its own block.
+type EnsembleParams struct {
+	Rule      string           `yaml:"rule"`      // weighted, unanimous, vote, or veto
+	Threshold float64          `yaml:"threshold"` // minimum |combined score| to signal, in multiples of the members' own thresholds
+	MinVotes  int              `yaml:"min_votes"` // vote rule only; 0 means a majority of members
+	HoldSecs  int              `yaml:"hold_secs"` // how long a member's last signal keeps counting; 0 = the current event only
+	Members   []EnsembleMember `yaml:"members"`
+}
+
+// EnsembleMember names one child strategy of an ensemble.
+type EnsembleMember struct {
+	Mode   string   `yaml:"mode"`   // registered strategy name or alias
+	Weight *float64 `yaml:"weight"` // defaults to 1; must be positive when set
+	Veto   bool     `yaml:"veto"`   // veto rule only: the member blocks signals instead of contributing to them
+}
+
+// Validate implements Params.
+func (p *EnsembleParams) Validate() error {
+	p.Rule = strings.ToLower(strings.TrimSpace(p.Rule))
+	switch p.Rule {
+	case RuleWeighted, RuleUnanimous, RuleVote, RuleVeto:
+	default:
+		return fmt.Errorf("unknown rule %q (want %s, %s, %s, or %s)", p.Rule, RuleWeighted, RuleUnanimous, RuleVote, RuleVeto)
+	}
+	switch {
+	case len(p.Members) < 2:
+		return fmt.Errorf("members: need at least two, got %d", len(p.Members))
+	case p.Threshold < 0:
+		return fmt.Errorf("threshold must not be negative, got %v", p.Threshold)
+	case p.HoldSecs < 0:
+		return fmt.Errorf("hold_secs must not be negative, got %d", p.HoldSecs)
+	case p.MinVotes < 0 || p.MinVotes > len(p.Members):
+		return fmt.Errorf("min_votes must be between 0 and %d, got %d", len(p.Members), p.MinVotes)
+	}
+	seen := make(map[string]bool, len(p.Members))
+	vetoes := 0
+	for i, m := range p.Members {
+		name, ok := Canonical(m.Mode)
+		switch {
+		case strings.TrimSpace(m.Mode) == "":
+			return fmt.Errorf("members[%d]: mode is required", i)
+		case !ok:
+			return fmt.Errorf("members[%d]: unknown strategy mode %q (registered: %s)", i, m.Mode, strings.Join(Names(), ", "))
+		case name == ensembleName:
+			return fmt.Errorf("members[%d]: ensembles cannot be nested", i)
+		case seen[name]:
+			return fmt.Errorf("members[%d]: %s listed twice; members share their strategy block", i, name)
+		case m.Weight != nil && *m.Weight <= 0:
+			return fmt.Errorf("members[%d]: weight must be positive, got %v", i, *m.Weight)
+		case m.Veto && p.Rule != RuleVeto:
+			return fmt.Errorf("members[%d]: veto is only used by the %s rule", i, RuleVeto)
+		}
+		seen[name] = true
+		if m.Veto {
+			vetoes++
+		}
+	}
+	if p.Rule == RuleVeto && (vetoes == 0 || vetoes == len(p.Members)) {
+		return fmt.Errorf("the %s rule needs at least one veto member and one voting member", RuleVeto)
+	}
+	return nil
+}
+
+func buildEnsemble(decode Decoder) (Strategy, error) {
+	params := &EnsembleParams{Rule: RuleWeighted}
+	if err := decodeParams(decode, ensembleName, params); err != nil {
+		return nil, err
+	}
+	members := make([]Member, 0, len(params.Members))
+	for _, m := range params.Members {
+		child, err := Build(m.Mode, decode)
+		if err != nil {
+			return nil, fmt.Errorf("strategy.%s: member %s: %w", ensembleName, m.Mode, err)
+		}
+		weight := 1.0
+		if m.Weight != nil {
+			weight = *m.Weight
+		}
+		members = append(members, Member{Strategy: child, Weight: weight, Veto: m.Veto})
+	}
+	return NewEnsemble(params.Rule, members, params.Threshold, params.MinVotes, params.HoldSecs), nil
+}
+
+// Member is one child strategy of an Ensemble with its weight and veto role.
+type Member struct {
+	Strategy Strategy
+	Weight   float64
+	Veto     bool
+}
+
+// Ensemble feeds every event to its member strategies and combines their signals under one rule.
+// It forwards closed bars to members that consume them, so bar and tick strategies can be mixed.
+// Member scores are divided by the member's own threshold (see Thresholded) before they are blended,
+// so a member signalling at its threshold contributes 1 whatever its native scale.
+type Ensemble struct {
+	rule      string
+	threshold float64
+	minVotes  int
+	hold      time.Duration
+	members   []Member
+	scales    []float64 // per-member divisor that puts scores on a common scale
+	mu        sync.Mutex
+	held      map[string][]*signal.Signal // latest signal per symbol and member, used when hold > 0
+}
+
+// NewEnsemble combines members under rule; minVotes 0 means a majority and holdSecs 0 counts only
+// signals produced by the current event. Members without a positive weight get weight 1.
+func NewEnsemble(rule string, members []Member, threshold float64, minVotes, holdSecs int) *Ensemble {
+	members = slices.Clone(members)
+	scales := make([]float64, len(members))
+	for i := range members {
+		if members[i].Weight <= 0 {
+			members[i].Weight = 1
+		}
+		scales[i] = 1
+		if t, ok := members[i].Strategy.(Thresholded); ok && t.Threshold() > 0 {
+			scales[i] = t.Threshold()
+		}
+	}
+	if minVotes <= 0 {
+		minVotes = len(members)/2 + 1
+	}
+	return &Ensemble{
+		rule:      rule,
+		threshold: math.Max(0, threshold),
+		minVotes:  minVotes,
+		hold:      time.Duration(max(0, holdSecs)) * time.Second,
+		members:   members,
+		scales:    scales,
+		held:      make(map[string][]*signal.Signal),
+	}
+}
+
+// Name lists the members so reports show what was combined.
+func (e *Ensemble) Name() string {
+	names := make([]string, len(e.members))
+	for i, m := range e.members {
+		names[i] = m.Strategy.Name()
+	}
+	return "Ensemble(" + strings.Join(names, "+") + ")"
+}
+
+// OnTick passes the tick to every member and combines their views.
+func (e *Ensemble) OnTick(tk signal.Tick) *signal.Signal {
+	current := make([]*signal.Signal, len(e.members))
+	for i, m := range e.members {
+		current[i] = m.Strategy.OnTick(tk)
+	}
+	return e.combine(tk.Symbol, tk.Ts, current)
+}
+
+// Timeframes is the union of the members' bar timeframes.
+func (e *Ensemble) Timeframes() []time.Duration {
+	var out []time.Duration
+	for _, m := range e.members {
+		if consumer, ok := m.Strategy.(BarConsumer); ok {
+			for _, tf := range consumer.Timeframes() {
+				if !slices.Contains(out, tf) {
+					out = append(out, tf)
+				}
+			}
+		}
+	}
+	return out
+}
+
+// OnBar passes the bar to members that requested its timeframe and combines their views.
+func (e *Ensemble) OnBar(b signal.Bar) *signal.Signal {
+	current := make([]*signal.Signal, len(e.members))
+	for i, m := range e.members {
+		if consumer, ok := m.Strategy.(BarConsumer); ok && slices.Contains(consumer.Timeframes(), b.Timeframe) {
+			current[i] = consumer.OnBar(b)
+		}
+	}
+	return e.combine(b.Symbol, b.End(), current)
+}
+
+// combine merges the members' current signals with any still-held ones and applies the rule. Only an event
+// that produced a new member signal can emit: held signals complete a combination but never repeat one.
+func (e *Ensemble) combine(symbol string, now time.Time, current []*signal.Signal) *signal.Signal {
+	if !slices.ContainsFunc(current, func(s *signal.Signal) bool { return s != nil }) {
+		return nil
+	}
+	views := current
+	if e.hold > 0 {
+		e.mu.Lock()
+		held := e.held[symbol]
+		if held == nil {
+			held = make([]*signal.Signal, len(e.members))
+			e.held[symbol] = held
+		}
+		views = make([]*signal.Signal, len(e.members))
+		for i := range e.members {
+			if current[i] != nil {
+				held[i] = current[i]
+			}
+			if held[i] != nil && now.Sub(held[i].Ts) <= e.hold {
+				views[i] = held[i]
+			}
+		}
+		e.mu.Unlock()
+	}
+
+	score, ok := e.apply(views)
+	if !ok || score == 0 || math.Abs(score) < e.threshold {
+		return nil
+	}
+	return &signal.Signal{Symbol: symbol, Score: score, Reason: e.reason(score, views), Ts: now}
+}
+
+// apply returns the combined score of views under the ensemble rule and whether the rule allows a signal.
+func (e *Ensemble) apply(views []*signal.Signal) (float64, bool) {
+	switch e.rule {
+	case RuleUnanimous:
+		for _, v := range views {
+			if v == nil || sign(v.Score) != sign(views[0].Score) {
+				return 0, false
+			}
+		}
+		return e.weighted(views, func(int) bool { return true }), true
+	case RuleVote:
+		long, short := 0, 0
+		for _, v := range views {
+			switch sign(scoreOf(v)) {
+			case 1:
+				long++
+			case -1:
+				short++
+			}
+		}
+		dir := 0
+		if long >= e.minVotes && long > short {
+			dir = 1
+		} else if short >= e.minVotes && short > long {
+			dir = -1
+		}
+		if dir == 0 {
+			return 0, false
+		}
+		return e.weighted(views, func(i int) bool { return sign(scoreOf(views[i])) == dir }), true
+	case RuleVeto:
+		score := e.weighted(views, func(i int) bool { return !e.members[i].Veto })
+		for i, m := range e.members {
+			if m.Veto && sign(scoreOf(views[i])) == -sign(score) {
+				return 0, false
+			}
+		}
+		return score, true
+	default:
+		return e.weighted(views, func(int) bool { return true }), true
+	}
+}
+
+// weighted is the weight-averaged normalized score of the members include selects; silent members count as zero.
+func (e *Ensemble) weighted(views []*signal.Signal, include func(i int) bool) float64 {
+	var sum, weights float64
+	for i, m := range e.members {
+		if !include(i) {
+			continue
+		}
+		sum += m.Weight * scoreOf(views[i]) / e.scales[i]
+		weights += m.Weight
+	}
+	if weights == 0 {
+		return 0
+	}
+	return sum / weights
+}
+
+// reason records the rule, combined score, and each member's contribution with its own reason.
+func (e *Ensemble) reason(score float64, views []*signal.Signal) string {
+	parts := make([]string, len(e.members))
+	for i, m := range e.members {
+		role := fmt.Sprintf("w=%.2f", m.Weight)
+		if m.Veto {
+			role = "veto"
+		}
+		if views[i] == nil {
+			parts[i] = fmt.Sprintf("%s %s silent", m.Strategy.Name(), role)
+			continue
+		}
+		parts[i] = fmt.Sprintf("%s %s score=%+.4f (%s)", m.Strategy.Name(), role, views[i].Score, views[i].Reason)
+	}
+	return fmt.Sprintf("%s score=%+.4f: %s", e.rule, score, strings.Join(parts, "; "))
+}
+
+func scoreOf(s *signal.Signal) float64 {
+	if s == nil {
+		return 0
+	}
+	return s.Score
+}
+
+func sign(v float64) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
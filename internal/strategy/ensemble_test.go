@@ -0,0 +1,166 @@
+package strategy
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"memebot-go/internal/signal"
+)
+
+// fixedStrategy emits a preset score for every tick, or nothing when the score is zero.
+type fixedStrategy struct {
+	name      string
+	score     float64
+	threshold float64
+}
+
+func (f *fixedStrategy) Name() string { return f.name }
+
+func (f *fixedStrategy) Threshold() float64 { return f.threshold }
+
+func (f *fixedStrategy) OnTick(tk signal.Tick) *signal.Signal {
+	if f.score == 0 {
+		return nil
+	}
+	return &signal.Signal{Symbol: tk.Symbol, Score: f.score, Reason: "fixed", Ts: tk.Ts}
+}
+
+func members(scores ...float64) []Member {
+	out := make([]Member, len(scores))
+	for i, score := range scores {
+		out[i] = Member{Strategy: &fixedStrategy{name: string(rune('A' + i)), score: score}}
+	}
+	return out
+}
+
+func TestEnsembleRules(t *testing.T) {
+	tick := signal.Tick{Symbol: "WIF", Price: 1, Ts: time.Now()}
+	vetoed := members(0.6, 0.4, -0.1)
+	vetoed[2].Veto = true
+	agreeing := members(0.6, 0.4, 0.1)
+	agreeing[2].Veto = true
+
+	cases := []struct {
+		name      string
+		rule      string
+		members   []Member
+		threshold float64
+		minVotes  int
+		want      float64 // 0 means no signal
+	}{
+		{"weighted counts silent members as zero", RuleWeighted, members(0.6, 0, 0.3), 0, 0, 0.3},
+		{"weighted below threshold", RuleWeighted, members(0.6, 0, -0.3), 0.2, 0, 0},
+		{"unanimous agreement", RuleUnanimous, members(0.4, 0.2), 0, 0, 0.3},
+		{"unanimous with a silent member", RuleUnanimous, members(0.4, 0), 0, 0, 0},
+		{"unanimous disagreement", RuleUnanimous, members(0.4, -0.2), 0, 0, 0},
+		{"majority vote averages the winners", RuleVote, members(0.5, 0.3, -0.9), 0, 0, 0.4},
+		{"vote short of k", RuleVote, members(0.5, 0, 0), 0, 2, 0},
+		{"vote tie", RuleVote, members(0.5, -0.5, 0, 0), 0, 1, 0},
+		{"veto blocks opposing signal", RuleVeto, vetoed, 0, 0, 0},
+		{"veto passes agreeing signal", RuleVeto, agreeing, 0, 0, 0.5},
+	}
+	for _, tc := range cases {
+		sig := NewEnsemble(tc.rule, tc.members, tc.threshold, tc.minVotes, 0).OnTick(tick)
+		switch {
+		case tc.want == 0 && sig != nil:
+			t.Fatalf("%s: expected no signal, got %+v", tc.name, sig)
+		case tc.want != 0 && (sig == nil || math.Abs(sig.Score-tc.want) > 1e-9):
+			t.Fatalf("%s: expected score %v, got %+v", tc.name, tc.want, sig)
+		}
+	}
+}
+
+func TestEnsembleReasonRecordsMembers(t *testing.T) {
+	ens := NewEnsemble(RuleWeighted, []Member{
+		{Strategy: &fixedStrategy{name: "Fast", score: 0.8}, Weight: 3},
+		{Strategy: &fixedStrategy{name: "Slow"}, Weight: 1},
+	}, 0, 0, 0)
+	sig := ens.OnTick(signal.Tick{Symbol: "WIF", Price: 1, Ts: time.Now()})
+	if sig == nil {
+		t.Fatalf("expected signal")
+	}
+	for _, want := range []string{"weighted score=+0.6000", "Fast w=3.00 score=+0.8000 (fixed)", "Slow w=1.00 silent"} {
+		if !strings.Contains(sig.Reason, want) {
+			t.Fatalf("reason %q missing %q", sig.Reason, want)
+		}
+	}
+	if ens.Name() != "Ensemble(Fast+Slow)" {
+		t.Fatalf("unexpected name %s", ens.Name())
+	}
+}
+
+func TestEnsembleHoldsMemberSignals(t *testing.T) {
+	fast := &fixedStrategy{name: "Fast", score: 0.5}
+	slow := &fixedStrategy{name: "Slow"}
+	ens := NewEnsemble(RuleUnanimous, []Member{{Strategy: fast}, {Strategy: slow}}, 0, 0, 10)
+	now := time.Now()
+	if sig := ens.OnTick(signal.Tick{Symbol: "WIF", Price: 1, Ts: now}); sig != nil {
+		t.Fatalf("expected no signal before the slow member agrees, got %+v", sig)
+	}
+	fast.score, slow.score = 0, 0.3
+	if sig := ens.OnTick(signal.Tick{Symbol: "WIF", Price: 1, Ts: now.Add(5 * time.Second)}); sig == nil || math.Abs(sig.Score-0.4) > 1e-9 {
+		t.Fatalf("expected held fast signal to combine with slow, got %+v", sig)
+	}
+	slow.score = 0
+	if sig := ens.OnTick(signal.Tick{Symbol: "WIF", Price: 1, Ts: now.Add(6 * time.Second)}); sig != nil {
+		t.Fatalf("expected held signals alone not to repeat the combination, got %+v", sig)
+	}
+	slow.score = 0.3
+	if sig := ens.OnTick(signal.Tick{Symbol: "WIF", Price: 1, Ts: now.Add(15 * time.Second)}); sig != nil {
+		t.Fatalf("expected expired fast signal to stop counting, got %+v", sig)
+	}
+}
+
+func TestEnsembleNormalizesMemberScores(t *testing.T) {
+	// A 0.10 move against a 0.05 threshold is twice the conviction of a 0.25 score against 0.25.
+	ens := NewEnsemble(RuleWeighted, []Member{
+		{Strategy: &fixedStrategy{name: "Trend", score: 0.10, threshold: 0.05}},
+		{Strategy: &fixedStrategy{name: "OBI", score: -0.25, threshold: 0.25}},
+	}, 0, 0, 0)
+	sig := ens.OnTick(signal.Tick{Symbol: "WIF", Price: 1, Ts: time.Now()})
+	if sig == nil || math.Abs(sig.Score-0.5) > 1e-9 {
+		t.Fatalf("expected normalized scores (2 - 1) / 2, got %+v", sig)
+	}
+}
+
+func TestFromConfigBuildsEnsemble(t *testing.T) {
+	strat, err := FromConfig(strategyConfig(t, `
+mode: ensemble
+ensemble:
+  rule: vote
+  min_votes: 2
+  members:
+    - mode: obi
+      weight: 2
+    - mode: trend_follow
+trend_follow:
+  threshold: 0.1
+`))
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	ens := strat.(*Ensemble)
+	if ens.rule != RuleVote || ens.minVotes != 2 || len(ens.members) != 2 || ens.members[0].Weight != 2 || ens.members[1].Weight != 1 {
+		t.Fatalf("unexpected ensemble %+v", ens)
+	}
+	if trend := ens.members[1].Strategy.(*TrendFollower); trend.threshold != 0.1 {
+		t.Fatalf("expected member to read its own block, got threshold %v", trend.threshold)
+	}
+
+	bad := map[string]string{
+		"nested":          "mode: ensemble\nensemble:\n  members: [{mode: obi}, {mode: ensemble}]\n",
+		"duplicate":       "mode: ensemble\nensemble:\n  members: [{mode: obi}, {mode: obi_momentum}]\n",
+		"single member":   "mode: ensemble\nensemble:\n  members: [{mode: obi}]\n",
+		"zero weight":     "mode: ensemble\nensemble:\n  members: [{mode: obi, weight: 0}, {mode: trend}]\n",
+		"veto without":    "mode: ensemble\nensemble:\n  rule: veto\n  members: [{mode: obi}, {mode: trend}]\n",
+		"unknown member":  "mode: ensemble\nensemble:\n  members: [{mode: obi}, {mode: moonshot}]\n",
+		"bad member args": "mode: ensemble\nensemble:\n  members: [{mode: obi}, {mode: trend}]\ntrend_follow:\n  threshold: -1\n",
+	}
+	for name, raw := range bad {
+		if _, err := FromConfig(strategyConfig(t, raw)); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
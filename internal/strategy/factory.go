@@ -1,8 +1,12 @@
 package strategy
 
 import (
+	"fmt"
+	"sort"
 	"strings"
+	"time"
 
+	"memebot-go/internal/config"
 	sig "memebot-go/internal/signal"
 )
 
@@ -12,24 +16,111 @@ type Strategy interface {
 	Name() string
 }
 
-// Params expresses tunable knobs required by strategy constructors.
-type Params struct {
-	OBILevels         int
-	OBIThreshold      float64
-	VolWindowSecs     int
-	TrendThreshold    float64
-	TrendWindowSecs   int
-	TrendMinVolumeUSD float64
-}
-
-// Build returns a strategy implementation matching the configured mode.
-func Build(mode string, params Params) Strategy {
-	switch strings.ToLower(strings.TrimSpace(mode)) {
-	case "", "obi", "obi_momentum":
-		return NewOBIMomentum(params.OBIThreshold, params.VolWindowSecs)
-	case "trend", "trend_follow", "trend_follower":
-		return NewTrendFollower(params.TrendThreshold, params.TrendWindowSecs, params.TrendMinVolumeUSD)
-	default:
-		return NewOBIMomentum(params.OBIThreshold, params.VolWindowSecs)
+// BarConsumer is implemented by strategies that also (or only) react to closed OHLCV bars;
+// the engine aggregates ticks into the requested timeframes and calls OnBar as each bar closes.
+type BarConsumer interface {
+	Timeframes() []time.Duration
+	OnBar(b sig.Bar) *sig.Signal
+}
+
+// Thresholded is implemented by strategies that signal once |score| reaches a threshold. The threshold is the
+// strategy's unit of conviction; ensembles divide member scores by it so differently scaled scores can be blended.
+type Thresholded interface {
+	Threshold() float64
+}
+
+// Params is implemented by every strategy's typed parameter struct.
+type Params interface {
+	Validate() error
+}
+
+// Decoder fills out from the parameter block of the named strategy, leaving defaults for absent keys.
+type Decoder func(name string, out any) error
+
+// DefaultMode is the strategy built when strategy.mode is empty.
+const DefaultMode = "obi_momentum"
+
+type registration struct {
+	name  string
+	build func(decode Decoder) (Strategy, error)
+}
+
+var (
+	registry = make(map[string]*registration) // canonical names and aliases
+	names    []string                         // canonical names in registration order
+)
+
+// Register adds a strategy under name (its YAML block key) and optional aliases accepted in strategy.mode.
+// defaults returns a fresh parameter struct; the block is decoded over it and validated before build runs.
+// It panics on duplicate names, so registrations belong in init functions.
+func Register[P Params](name string, defaults func() P, build func(P) (Strategy, error), aliases ...string) {
+	register(name, func(decode Decoder) (Strategy, error) {
+		params := defaults()
+		if err := decodeParams(decode, name, params); err != nil {
+			return nil, err
+		}
+		return build(params)
+	}, aliases...)
+}
+
+// register adds a strategy whose build reads parameters itself, for composites that also build children.
+func register(name string, build func(decode Decoder) (Strategy, error), aliases ...string) {
+	reg := &registration{name: name, build: build}
+	for _, key := range append([]string{name}, aliases...) {
+		if _, dup := registry[key]; dup {
+			panic(fmt.Sprintf("strategy %q registered twice", key))
+		}
+		registry[key] = reg
+	}
+	names = append(names, name)
+}
+
+func decodeParams(decode Decoder, name string, params Params) error {
+	if err := decode(name, params); err != nil {
+		return err
+	}
+	if err := params.Validate(); err != nil {
+		return fmt.Errorf("strategy.%s: %w", name, err)
+	}
+	return nil
+}
+
+// Names lists the registered strategies by canonical name.
+func Names() []string {
+	out := append([]string(nil), names...)
+	sort.Strings(out)
+	return out
+}
+
+// Canonical resolves a mode or alias to its registered name.
+func Canonical(mode string) (string, bool) {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode == "" {
+		mode = DefaultMode
+	}
+	reg, ok := registry[mode]
+	if !ok {
+		return "", false
+	}
+	return reg.name, true
+}
+
+// Build constructs the strategy registered for mode with parameters read through decode.
+func Build(mode string, decode Decoder) (Strategy, error) {
+	name, ok := Canonical(mode)
+	if !ok {
+		return nil, fmt.Errorf("unknown strategy mode %q (registered: %s)", mode, strings.Join(Names(), ", "))
+	}
+	return registry[name].build(decode)
+}
+
+// FromConfig builds the configured strategy. Parameter blocks that name no registered strategy are
+// rejected so a typo cannot silently fall back to defaults.
+func FromConfig(cfg config.Strategy) (Strategy, error) {
+	for key := range cfg.Blocks {
+		if _, ok := registry[key]; !ok || registry[key].name != key {
+			return nil, fmt.Errorf("strategy.%s: not a registered strategy (registered: %s)", key, strings.Join(Names(), ", "))
+		}
 	}
+	return Build(cfg.Mode, cfg.Decode)
 }
@@ -0,0 +1,75 @@
+package strategy
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"memebot-go/internal/config"
+)
+
+func strategyConfig(t *testing.T, raw string) config.Strategy {
+	t.Helper()
+	var cfg config.Strategy
+	if err := yaml.Unmarshal([]byte(raw), &cfg); err != nil {
+		t.Fatalf("unmarshal strategy config: %v", err)
+	}
+	return cfg
+}
+
+func TestBuildResolvesAliasesAndDefaults(t *testing.T) {
+	for mode, want := range map[string]string{"": "OBIMomentum", "obi": "OBIMomentum", "TREND": "TrendFollower", "trend_follower": "TrendFollower"} {
+		strat, err := FromConfig(config.Strategy{Mode: mode})
+		if err != nil {
+			t.Fatalf("mode %q: %v", mode, err)
+		}
+		if strat.Name() != want {
+			t.Fatalf("mode %q built %s, want %s", mode, strat.Name(), want)
+		}
+	}
+	strat, _ := FromConfig(config.Strategy{Mode: "trend_follow"})
+	if trend := strat.(*TrendFollower); trend.threshold != 0.05 || trend.window.Seconds() != 180 {
+		t.Fatalf("expected trend defaults, got threshold %v window %v", trend.threshold, trend.window)
+	}
+}
+
+func TestBuildUnknownModeListsRegistered(t *testing.T) {
+	_, err := FromConfig(config.Strategy{Mode: "moonshot"})
+	if err == nil || !strings.Contains(err.Error(), "obi_momentum") || !strings.Contains(err.Error(), "trend_follow") {
+		t.Fatalf("expected unknown mode error listing strategies, got %v", err)
+	}
+}
+
+func TestFromConfigDecodesBlock(t *testing.T) {
+	cfg := strategyConfig(t, `
+mode: obi
+obi_momentum:
+  threshold: 0.4
+  levels: 3
+trend_follow:
+  window_secs: 30
+`)
+	strat, err := FromConfig(cfg)
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	obi := strat.(*OBIMomentum)
+	if obi.threshold != 0.4 || obi.levels != 3 || obi.window.Seconds() != 60 {
+		t.Fatalf("expected block over defaults, got threshold %v levels %d window %v", obi.threshold, obi.levels, obi.window)
+	}
+}
+
+func TestFromConfigRejectsBadBlocks(t *testing.T) {
+	cases := map[string]string{
+		"unknown block": "mode: obi_momentum\nobi_momentun:\n  threshold: 0.4\n",
+		"alias block":   "mode: obi_momentum\nobi:\n  threshold: 0.4\n",
+		"unknown key":   "mode: obi_momentum\nobi_momentum:\n  treshold: 0.4\n",
+		"invalid value": "mode: trend_follow\ntrend_follow:\n  window_secs: -5\n",
+	}
+	for name, raw := range cases {
+		if _, err := FromConfig(strategyConfig(t, raw)); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
@@ -10,10 +10,11 @@ import (
 	"memebot-go/internal/signal"
 )
 
-// OBIMomentum models a simple trade imbalance plus price momentum heuristic over a sliding window.
+// OBIMomentum blends order book imbalance (falling back to trade-flow imbalance) with price momentum over a sliding window.
 type OBIMomentum struct {
 	threshold float64
 	window    time.Duration
+	levels    int
 	mu        sync.Mutex
 	series    map[string]*tickSeries
 }
@@ -21,21 +22,56 @@ type OBIMomentum struct {
 // Name returns the identifier for the strategy implementation.
 func (s *OBIMomentum) Name() string { return "OBIMomentum" }
 
+// Threshold implements Thresholded.
+func (s *OBIMomentum) Threshold() float64 { return s.threshold }
+
 type tickSeries struct {
-	ticks []signal.Tick
+	ticks  []signal.Tick
+	book   *signal.Book
+	bookTs time.Time
+}
+
+func init() {
+	Register("obi_momentum", func() *OBIParams { return &OBIParams{Threshold: 0.25, WindowSecs: 60, Levels: 5} },
+		func(p *OBIParams) (Strategy, error) { return NewOBIMomentum(p.Threshold, p.WindowSecs, p.Levels), nil },
+		"obi")
 }
 
-// NewOBIMomentum builds an OBIMomentum instance using threshold and look-back window seconds.
-func NewOBIMomentum(threshold float64, windowSec int) *OBIMomentum {
+// OBIParams configures OBIMomentum from the strategy.obi_momentum block.
+type OBIParams struct {
+	Threshold  float64 `yaml:"threshold"`   // minimum |0.6*imbalance + 0.4*momentum| to signal
+	WindowSecs int     `yaml:"window_secs"` // look-back for trade flow and momentum
+	Levels     int     `yaml:"levels"`      // book depth levels used for imbalance
+}
+
+// Validate implements Params.
+func (p *OBIParams) Validate() error {
+	switch {
+	case p.Threshold <= 0:
+		return fmt.Errorf("threshold must be positive, got %v", p.Threshold)
+	case p.WindowSecs <= 0:
+		return fmt.Errorf("window_secs must be positive, got %d", p.WindowSecs)
+	case p.Levels <= 0:
+		return fmt.Errorf("levels must be positive, got %d", p.Levels)
+	}
+	return nil
+}
+
+// NewOBIMomentum builds an OBIMomentum instance using threshold, look-back window seconds, and book depth levels.
+func NewOBIMomentum(threshold float64, windowSec, levels int) *OBIMomentum {
 	if threshold <= 0 {
 		threshold = 0.25
 	}
 	if windowSec <= 0 {
 		windowSec = 60
 	}
+	if levels <= 0 {
+		levels = 5
+	}
 	return &OBIMomentum{
 		threshold: threshold,
 		window:    time.Duration(windowSec) * time.Second,
+		levels:    levels,
 		series:    make(map[string]*tickSeries),
 	}
 }
@@ -54,15 +90,25 @@ func (s *OBIMomentum) OnTick(t signal.Tick) *signal.Signal {
 		ts = &tickSeries{}
 		s.series[t.Symbol] = ts
 	}
-	ts.append(t, s.window)
+	if t.Book != nil {
+		ts.book = t.Book
+		ts.bookTs = t.Ts
+	} else {
+		ts.append(t, s.window)
+	}
 
 	obi, momentum := ts.computeFeatures(t)
+	source := "flow"
+	if ts.book != nil && t.Ts.Sub(ts.bookTs) <= s.window {
+		obi = clamp(ts.book.Imbalance(s.levels), -1, 1)
+		source = "book"
+	}
 	score := 0.6*obi + 0.4*momentum
 	if math.Abs(score) < s.threshold {
 		return nil
 	}
 
-	reason := fmt.Sprintf("obi=%.2f momentum=%.2f", obi, momentum)
+	reason := fmt.Sprintf("obi=%.2f (%s) momentum=%.2f", obi, source, momentum)
 	return &signal.Signal{Symbol: t.Symbol, Score: score, Reason: reason, Ts: t.Ts}
 }
 
@@ -1,6 +1,7 @@
 package strategy
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -8,7 +9,7 @@ import (
 )
 
 func TestOnTickReturnsSignalLong(t *testing.T) {
-	strat := NewOBIMomentum(0.1, 30)
+	strat := NewOBIMomentum(0.1, 30, 5)
 	now := time.Now()
 	ticks := []signal.Tick{
 		{Symbol: "BTCUSDT", Price: 100, Size: 1, Side: 1, Ts: now.Add(-2 * time.Second)},
@@ -29,7 +30,7 @@ func TestOnTickReturnsSignalLong(t *testing.T) {
 }
 
 func TestOnTickReturnsSignalShort(t *testing.T) {
-	strat := NewOBIMomentum(0.1, 30)
+	strat := NewOBIMomentum(0.1, 30, 5)
 	now := time.Now()
 	ticks := []signal.Tick{
 		{Symbol: "ETHUSDT", Price: 200, Size: 1, Side: -1, Ts: now.Add(-2 * time.Second)},
@@ -50,7 +51,7 @@ func TestOnTickReturnsSignalShort(t *testing.T) {
 }
 
 func TestOnTickBelowThreshold(t *testing.T) {
-	strat := NewOBIMomentum(0.9, 30)
+	strat := NewOBIMomentum(0.9, 30, 5)
 	now := time.Now()
 	tk := signal.Tick{Symbol: "SOLUSDT", Price: 50, Size: 1, Side: 1, Ts: now}
 	if sig := strat.OnTick(tk); sig != nil {
@@ -58,20 +59,26 @@ func TestOnTickBelowThreshold(t *testing.T) {
 	}
 }
 
-func TestBuildReturnsStrategy(t *testing.T) {
-	params := Params{OBIThreshold: 0.3, VolWindowSecs: 60}
-	strat := Build("obi_momentum", params)
-	if strat == nil {
-		t.Fatalf("expected strategy instance")
+func TestOnTickUsesTopBookLevels(t *testing.T) {
+	now := time.Now()
+	// Bids dominate the top two levels while asks dominate the full depth.
+	book := &signal.Book{
+		Bids: []signal.BookLevel{{Price: 99.9, Qty: 8}, {Price: 99.8, Qty: 8}, {Price: 99.7, Qty: 1}},
+		Asks: []signal.BookLevel{{Price: 100.1, Qty: 1}, {Price: 100.2, Qty: 1}, {Price: 100.3, Qty: 80}},
 	}
-	if strat.Name() == "" {
-		t.Fatalf("expected strategy name")
+	tick := signal.Tick{Symbol: "BTCUSDT", Price: book.Mid(), Ts: now, Book: book}
+
+	shallow := NewOBIMomentum(0.3, 30, 2)
+	sig := shallow.OnTick(tick)
+	if sig == nil || sig.Score <= 0 {
+		t.Fatalf("expected long signal from top-2 bid pressure, got %+v", sig)
 	}
-	trend := Build("trend_follow", Params{TrendThreshold: 0.05, TrendWindowSecs: 120, TrendMinVolumeUSD: 100})
-	if trend == nil {
-		t.Fatalf("expected trend strategy instance")
+	if !strings.Contains(sig.Reason, "(book)") {
+		t.Fatalf("expected book-driven reason, got %q", sig.Reason)
 	}
-	if trend.Name() != "TrendFollower" {
-		t.Fatalf("unexpected trend strategy name: %s", trend.Name())
+
+	deep := NewOBIMomentum(0.3, 30, 3)
+	if sig := deep.OnTick(tick); sig == nil || sig.Score >= 0 {
+		t.Fatalf("expected short signal once deep asks are included, got %+v", sig)
 	}
 }
@@ -10,10 +10,12 @@ import (
 )
 
 // TrendFollower emits signals when price momentum over a lookback window exceeds a threshold alongside minimum volume.
+// With a bar timeframe set it evaluates closed bars instead of individual ticks.
 type TrendFollower struct {
 	threshold    float64
 	window       time.Duration
 	minVolume    float64
+	barTimeframe time.Duration // zero evaluates every tick
 	mu           sync.Mutex
 	observations map[string]*trendSeries
 }
@@ -22,6 +24,41 @@ type trendSeries struct {
 	ticks []signal.Tick
 }
 
+func init() {
+	Register("trend_follow", func() *TrendParams { return &TrendParams{Threshold: 0.05, WindowSecs: 180} },
+		func(p *TrendParams) (Strategy, error) {
+			strat := NewTrendFollower(p.Threshold, p.WindowSecs, p.MinVolumeUSD)
+			strat.SetBarTimeframe(time.Duration(p.BarSecs) * time.Second)
+			return strat, nil
+		},
+		"trend", "trend_follower")
+}
+
+// TrendParams configures TrendFollower from the strategy.trend_follow block.
+type TrendParams struct {
+	Threshold    float64 `yaml:"threshold"`      // minimum fractional price change over the window
+	WindowSecs   int     `yaml:"window_secs"`    // look-back window
+	MinVolumeUSD float64 `yaml:"min_volume_usd"` // traded notional required inside the window
+	BarSecs      int     `yaml:"bar_secs"`       // evaluate closed bars of this length instead of ticks; 0 = ticks
+}
+
+// Validate implements Params.
+func (p *TrendParams) Validate() error {
+	switch {
+	case p.Threshold <= 0:
+		return fmt.Errorf("threshold must be positive, got %v", p.Threshold)
+	case p.WindowSecs <= 0:
+		return fmt.Errorf("window_secs must be positive, got %d", p.WindowSecs)
+	case p.MinVolumeUSD < 0:
+		return fmt.Errorf("min_volume_usd must not be negative, got %v", p.MinVolumeUSD)
+	case p.BarSecs < 0:
+		return fmt.Errorf("bar_secs must not be negative, got %d", p.BarSecs)
+	case p.BarSecs > p.WindowSecs:
+		return fmt.Errorf("bar_secs %d exceeds window_secs %d", p.BarSecs, p.WindowSecs)
+	}
+	return nil
+}
+
 // NewTrendFollower builds a trend-following strategy using percent change and volume filters.
 func NewTrendFollower(threshold float64, windowSecs int, minVolumeUSD float64) *TrendFollower {
 	if threshold <= 0 {
@@ -41,9 +78,42 @@ func NewTrendFollower(threshold float64, windowSecs int, minVolumeUSD float64) *
 // Name returns the configured identifier for logging.
 func (t *TrendFollower) Name() string { return "TrendFollower" }
 
-// OnTick evaluates momentum and volume to decide whether to emit a signal.
+// Threshold implements Thresholded.
+func (t *TrendFollower) Threshold() float64 { return t.threshold }
+
+// SetBarTimeframe switches the strategy to closed bars of timeframe tf, which the engine then aggregates for it;
+// zero restores per-tick evaluation. Call it before the strategy is handed to the engine.
+func (t *TrendFollower) SetBarTimeframe(tf time.Duration) {
+	t.barTimeframe = max(0, tf)
+}
+
+// Timeframes implements BarConsumer; it is empty in tick mode.
+func (t *TrendFollower) Timeframes() []time.Duration {
+	if t.barTimeframe <= 0 {
+		return nil
+	}
+	return []time.Duration{t.barTimeframe}
+}
+
+// OnTick evaluates momentum and volume to decide whether to emit a signal. In bar mode ticks are ignored.
 func (t *TrendFollower) OnTick(tk signal.Tick) *signal.Signal {
-	if tk.Symbol == "" || tk.Price <= 0 {
+	if t.barTimeframe > 0 {
+		return nil
+	}
+	return t.evaluate(tk)
+}
+
+// OnBar implements BarConsumer: each closed bar counts as one observation at its close, carrying the bar's
+// volume, so momentum is measured close to close across the window.
+func (t *TrendFollower) OnBar(b signal.Bar) *signal.Signal {
+	if t.barTimeframe <= 0 || b.Timeframe != t.barTimeframe {
+		return nil
+	}
+	return t.evaluate(signal.Tick{Provider: b.Provider, Symbol: b.Symbol, Price: b.Close, Size: b.Volume, Ts: b.End()})
+}
+
+func (t *TrendFollower) evaluate(tk signal.Tick) *signal.Signal {
+	if tk.Symbol == "" || tk.Price <= 0 || tk.Book != nil {
 		return nil
 	}
 
@@ -65,3 +65,30 @@ func TestTrendFollowerRespectsVolume(t *testing.T) {
 		t.Fatalf("expected nil signal due to insufficient volume")
 	}
 }
+
+func TestTrendFollowerOnBars(t *testing.T) {
+	strat := NewTrendFollower(0.02, 180, 100)
+	strat.SetBarTimeframe(time.Minute)
+	if tfs := strat.Timeframes(); len(tfs) != 1 || tfs[0] != time.Minute {
+		t.Fatalf("unexpected timeframes %v", tfs)
+	}
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	if sig := strat.OnTick(signal.Tick{Symbol: "WIFSOL", Price: 0.01, Size: 5000, Side: 1, Ts: base}); sig != nil {
+		t.Fatalf("expected ticks to be ignored in bar mode, got %+v", sig)
+	}
+
+	bars := []signal.Bar{
+		{Symbol: "WIFSOL", Timeframe: time.Minute, Start: base, Close: 0.01, Volume: 5000},
+		{Symbol: "WIFSOL", Timeframe: 5 * time.Second, Start: base.Add(time.Minute), Close: 0.02, Volume: 5000},
+		{Symbol: "WIFSOL", Timeframe: time.Minute, Start: base.Add(time.Minute), Close: 0.0105, Volume: 4000},
+	}
+	for _, b := range bars {
+		if sig := strat.OnBar(b); sig != nil {
+			t.Fatalf("expected no signal below the volume floor or on another timeframe, got %+v", sig)
+		}
+	}
+	sig := strat.OnBar(signal.Bar{Symbol: "WIFSOL", Timeframe: time.Minute, Start: base.Add(2 * time.Minute), Close: 0.011, Volume: 3000})
+	if sig == nil || sig.Score <= 0 || !sig.Ts.Equal(base.Add(3*time.Minute)) {
+		t.Fatalf("expected a long signal at the bar close, got %+v", sig)
+	}
+}